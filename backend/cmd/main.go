@@ -1,14 +1,32 @@
 package main
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
 	"log"
+	"math/big"
 	"os"
+	"strings"
+	"time"
 
+	"aa-sharing-backend/internal/bridge"
+	"aa-sharing-backend/internal/chain"
 	"aa-sharing-backend/internal/config"
+	"aa-sharing-backend/internal/gasless"
 	"aa-sharing-backend/internal/handlers"
-	"aa-sharing-backend/internal/models"
+	"aa-sharing-backend/internal/indexer"
+	"aa-sharing-backend/internal/ledger"
+	"aa-sharing-backend/internal/middleware"
+	"aa-sharing-backend/internal/migrations"
+	"aa-sharing-backend/internal/notify"
+	"aa-sharing-backend/internal/registry"
 	"aa-sharing-backend/internal/services"
+	"aa-sharing-backend/internal/txqueue"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
 	"github.com/rs/cors"
@@ -22,33 +40,129 @@ func main() {
 		log.Println("No .env file found")
 	}
 
+	cfg := config.LoadConfig()
+
 	// Initialize database
-	db, err := initDB()
+	db, err := initDB(cfg)
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
 
-	// Auto-migrate models
-	if err := db.AutoMigrate(
-		&models.User{},
-		&models.Partnership{},
-		&models.GratitudeEntry{},
-		&models.Goal{},
-		&models.Transaction{},
-		&models.WalletBalance{},
-	); err != nil {
+	// Migrate database schema
+	if err := migrations.Run(db); err != nil {
 		log.Fatal("Failed to migrate database:", err)
 	}
 
+	// Every journal entry must balance; report any that do not
+	if unbalanced, err := ledger.UnbalancedEntries(db); err != nil {
+		log.Println("Failed to check ledger invariants:", err)
+	} else if len(unbalanced) > 0 {
+		log.Printf("Ledger invariant violated by journal entries %v", unbalanced)
+	}
+
+	// "main relayer" runs the CrossChainBridge relayer instead of the API
+	if len(os.Args) > 1 && os.Args[1] == "relayer" {
+		if err := runRelayer(cfg, db); err != nil {
+			log.Fatal("Relayer stopped:", err)
+		}
+		return
+	}
+
+	// Load the supported networks and contract deployments
+	chains, err := registry.Load(cfg.DeploymentsDir)
+	if err != nil {
+		log.Fatal("Failed to load network registry:", err)
+	}
+	if network, ok := chains.Network(cfg.ChainID); ok {
+		if cfg.ChainRPCURL == "" {
+			cfg.ChainRPCURL = network.RPCURL
+		}
+		if address, ok := network.ContractAddress(registry.ContractAASharing); ok && cfg.AASharingAddress == "" {
+			cfg.AASharingAddress = address.Hex()
+		}
+	}
+
+	if cfg.JWTSecret == "" {
+		log.Fatal("JWT_SECRET must be set")
+	}
+
 	// Initialize services
-	userService := services.NewUserService(db)
+	userService := services.NewUserService(db, cfg.JWTSecret)
 	gratitudeService := services.NewGratitudeService(db)
 	walletService := services.NewWalletService(db)
+	partnershipService := services.NewPartnershipService(db, walletService)
+	siweService := services.NewSIWEService(db, cfg.SIWEDomain, cfg.SIWEURI, cfg.SIWEChainID, cfg.SIWENonceTTL)
+	idempotencyService := services.NewIdempotencyService(db, cfg.IdempotencyKeyTTL)
+	withdrawalService := services.NewWithdrawalService(db, walletService, cfg.WithdrawalRequestTTL)
+	expenseService := services.NewExpenseService(db)
+
+	// Start the on-chain indexer and deposit verification
+	var verificationService *services.DepositVerificationService
+	var aaSharing *chain.AASharingCaller
+	var relayer *gasless.Relayer
+	if cfg.ChainRPCURL != "" && cfg.AASharingAddress != "" {
+		var client *chain.Client
+		client, verificationService, err = startChainServices(cfg, db, chains, walletService)
+		if err != nil {
+			log.Fatal("Failed to start indexer:", err)
+		}
+		aaSharing, err = chain.NewAASharingCaller(common.HexToAddress(cfg.AASharingAddress), client)
+		if err != nil {
+			log.Fatal("Failed to bind AASharing:", err)
+		}
+		if cfg.GaslessRelayerKey != "" {
+			relayer, err = startGaslessRelayer(cfg, db, client, walletService)
+			if err != nil {
+				log.Fatal("Failed to start gasless relayer:", err)
+			}
+		}
+	}
+
+	// Reconcile stored balances with the ledger and the chain
+	reconciliationService := services.NewReconciliationService(db, walletService, aaSharing)
+	submissionService := services.NewSubmissionService(db)
+	bridgeService := services.NewBridgeService(db)
+	bridgeQuoteService := services.NewBridgeQuoteService(chains, cfg.BridgeQuoteTTL, cfg.BridgePollInterval)
+	go func() {
+		if err := reconciliationService.Run(context.Background(), cfg.ReconciliationInterval); err != nil {
+			log.Println("Reconciliation stopped:", err)
+		}
+	}()
+
+	// Drop stored idempotent responses once they can no longer be replayed
+	go func() {
+		if err := idempotencyService.Run(context.Background(), time.Hour); err != nil {
+			log.Println("Idempotency key cleanup stopped:", err)
+		}
+	}()
+
+	// Deliver activity events to the notification channels
+	activityService := services.NewActivityService(db)
+	channels := []notify.Channel{notify.Log{}}
+	if cfg.NotifyWebhookURL != "" {
+		channels = append(channels, notify.NewWebhook(cfg.NotifyWebhookURL))
+	}
+	dispatcher := notify.NewDispatcher(db, channels...)
+	go func() {
+		if err := dispatcher.Run(context.Background(), cfg.NotifyInterval); err != nil {
+			log.Println("Notification dispatch stopped:", err)
+		}
+	}()
 
 	// Initialize handlers
 	userHandler := handlers.NewUserHandler(userService)
-	gratitudeHandler := handlers.NewGratitudeHandler(gratitudeService)
-	walletHandler := handlers.NewWalletHandler(walletService)
+	siweHandler := handlers.NewSIWEHandler(siweService, userService)
+	partnershipHandler := handlers.NewPartnershipHandler(partnershipService)
+	gratitudeHandler := handlers.NewGratitudeHandler(gratitudeService, partnershipService)
+	walletHandler := handlers.NewWalletHandler(walletService, partnershipService, verificationService)
+	chainHandler := handlers.NewChainHandler(chains)
+	reconciliationHandler := handlers.NewReconciliationHandler(reconciliationService)
+	gaslessHandler := handlers.NewGaslessHandler(relayer, partnershipService)
+	submissionHandler := handlers.NewSubmissionHandler(submissionService)
+	bridgeHandler := handlers.NewBridgeHandler(bridgeService, bridgeQuoteService)
+	activityHandler := handlers.NewActivityHandler(activityService)
+	withdrawalHandler := handlers.NewWithdrawalHandler(withdrawalService, partnershipService)
+	expenseHandler := handlers.NewExpenseHandler(expenseService, partnershipService)
 
 	// Setup router
 	r := gin.Default()
@@ -65,28 +179,94 @@ func main() {
 	// Routes
 	api := r.Group("/api/v1")
 	{
-		// User routes
-		api.POST("/users", userHandler.CreateUser)
-		api.GET("/users/:id", userHandler.GetUser)
-		api.PUT("/users/:id", userHandler.UpdateUser)
-
 		// Authentication routes
-		api.POST("/auth/login", userHandler.Login)
 		api.POST("/auth/register", userHandler.Register)
+		api.GET("/auth/siwe/nonce", siweHandler.Nonce)
+		api.POST("/auth/siwe/verify", siweHandler.Verify)
+
+		// Network routes
+		api.GET("/chains", chainHandler.GetChains)
+		api.GET("/bridge/quote", bridgeHandler.GetQuote)
+	}
+
+	protected := api.Group("")
+	protected.Use(middleware.AuthRequired(userService))
+	requirePartner := middleware.RequirePartner(partnershipService, "partnershipId")
+	idempotent := middleware.Idempotent(idempotencyService)
+	{
+		// Wallets are bound to users only through a signed SIWE message
+		protected.POST("/auth/siwe/link", siweHandler.Link)
+
+		// User routes
+		protected.GET("/users/:id", userHandler.GetUser)
+		protected.PUT("/users/:id", middleware.RequireSelf("id"), userHandler.UpdateUser)
+
+		// Partnership routes
+		protected.POST("/partnerships", partnershipHandler.CreatePartnership)
+		protected.GET("/partnerships", partnershipHandler.GetPartnerships)
+		protected.GET("/partnerships/:partnershipId", requirePartner, partnershipHandler.GetPartnership)
+		protected.POST("/partnerships/:partnershipId/accept", partnershipHandler.AcceptPartnership)
+		protected.POST("/partnerships/:partnershipId/decline", partnershipHandler.DeclinePartnership)
+		protected.PUT("/partnerships/:partnershipId/status", partnershipHandler.UpdateStatus)
 
 		// Gratitude routes
-		api.POST("/gratitude", gratitudeHandler.CreateGratitude)
-		api.GET("/gratitude/user/:userId", gratitudeHandler.GetUserGratitude)
+		protected.POST("/gratitude", idempotent, gratitudeHandler.CreateGratitude)
+		protected.GET("/gratitude/user/:userId", middleware.RequireSelf("userId"), gratitudeHandler.GetUserGratitude)
+		protected.GET("/gratitude/partnership/:partnershipId", requirePartner, gratitudeHandler.GetPartnershipGratitude)
 
 		// Wallet routes
-		api.GET("/wallet/:partnershipId", walletHandler.GetWalletBalance)
-		api.POST("/wallet/contribute", walletHandler.Contribute)
-		api.GET("/wallet/transactions/:partnershipId", walletHandler.GetTransactions)
+		protected.GET("/wallet/:partnershipId", requirePartner, walletHandler.GetWalletBalance)
+		protected.POST("/wallet/contribute", idempotent, walletHandler.Contribute)
+		protected.GET("/wallet/contribute/gasless", gaslessHandler.PrepareContribution)
+		protected.POST("/wallet/contribute/gasless", idempotent, gaslessHandler.ContributeGasless)
+		protected.GET("/wallet/transactions/:partnershipId", requirePartner, walletHandler.GetTransactions)
+		protected.POST("/wallet/:partnershipId/split", requirePartner, idempotent, walletHandler.SplitFunds)
+		protected.GET("/wallet/ledger/:partnershipId", requirePartner, walletHandler.GetLedger)
 
 		// Goal routes
-		api.POST("/goals", walletHandler.CreateGoal)
-		api.GET("/goals/:partnershipId", walletHandler.GetGoals)
-		api.PUT("/goals/:id", walletHandler.UpdateGoal)
+		protected.POST("/goals", walletHandler.CreateGoal)
+		protected.GET("/goals/:partnershipId", requirePartner, walletHandler.GetGoals)
+		protected.PUT("/goals/:id", idempotent, walletHandler.UpdateGoal)
+		protected.POST("/goals/:partnershipId/allocate", idempotent, walletHandler.ReallocateFunds)
+
+		// Withdrawal request routes
+		protected.POST("/withdrawals", idempotent, withdrawalHandler.RequestWithdrawal)
+		protected.GET("/withdrawals/:partnershipId", requirePartner, withdrawalHandler.GetWithdrawals)
+		protected.POST("/withdrawals/:id/approve", idempotent, withdrawalHandler.ApproveWithdrawal)
+		protected.POST("/withdrawals/:id/reject", idempotent, withdrawalHandler.RejectWithdrawal)
+
+		// Shared expense routes
+		protected.POST("/expenses", idempotent, expenseHandler.CreateExpense)
+		protected.GET("/expenses/:partnershipId", requirePartner, expenseHandler.GetExpenses)
+		protected.DELETE("/expenses/:id", expenseHandler.DeleteExpense)
+		protected.GET("/expenses/:partnershipId/balances", requirePartner, expenseHandler.GetBalances)
+		protected.POST("/expenses/:partnershipId/settle", requirePartner, idempotent, expenseHandler.SettleUp)
+
+		// Activity feed routes
+		protected.GET("/activity/:partnershipId", requirePartner, activityHandler.GetActivity)
+
+		// Cross-chain deposit routes
+		protected.GET("/bridge/deposits", bridgeHandler.GetDeposits)
+		protected.GET("/bridge/deposits/:messageId", bridgeHandler.GetDeposit)
+		protected.GET("/bridge/deposits/partnership/:partnershipId", requirePartner, bridgeHandler.GetPartnershipDeposits)
+	}
+
+	admin := protected.Group("/admin")
+	admin.Use(middleware.AdminRequired(cfg.AdminUserIDs))
+	{
+		// User routes
+		admin.POST("/users", userHandler.CreateUser)
+
+		// Reconciliation routes
+		admin.GET("/reconciliation", reconciliationHandler.GetReconciliation)
+		admin.POST("/reconciliation/run", reconciliationHandler.RunReconciliation)
+		admin.POST("/reconciliation/:id/approve", reconciliationHandler.ApproveDiscrepancy)
+		admin.POST("/reconciliation/:id/dismiss", reconciliationHandler.DismissDiscrepancy)
+
+		// Transaction queue routes
+		admin.GET("/submissions", submissionHandler.GetSubmissions)
+		admin.GET("/submissions/:id", submissionHandler.GetSubmission)
+		admin.GET("/senders", submissionHandler.GetSenderNonces)
 	}
 
 	// Health check
@@ -94,16 +274,149 @@ func main() {
 		c.JSON(200, gin.H{"status": "ok"})
 	})
 
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	log.Printf("Server starting on port %s", cfg.Port)
+	log.Fatal(r.Run(":" + cfg.Port))
+}
+
+func initDB(cfg *config.Config) (*gorm.DB, error) {
+	return gorm.Open(postgres.Open(cfg.DatabaseURL), &gorm.Config{})
+}
+
+// startChainServices starts indexing the AASharing contract and returns the
+// chain client along with the service that verifies client-reported
+// deposits against the same chain. Deposits are confirmed at the depth the
+// registry gives for the chain, or INDEXER_CONFIRMATIONS on chains it does
+// not know.
+func startChainServices(cfg *config.Config, db *gorm.DB, chains *registry.Registry, walletService *services.WalletService) (*chain.Client, *services.DepositVerificationService, error) {
+	if !common.IsHexAddress(cfg.AASharingAddress) {
+		return nil, nil, fmt.Errorf("invalid AA_SHARING_ADDRESS %q", cfg.AASharingAddress)
 	}
 
-	log.Printf("Server starting on port %s", port)
-	log.Fatal(r.Run(":" + port))
+	client, err := chain.Dial(context.Background(), cfg.ChainRPCURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	chainID, err := client.ChainID(context.Background())
+	if err != nil {
+		return nil, nil, err
+	}
+	if network, ok := chains.Network(chainID.Uint64()); ok {
+		cfg.IndexerConfirmations = network.Confirmations
+	}
+	log.Printf("Confirming deposits on chain %s after %d blocks", chainID, cfg.IndexerConfirmations)
+
+	ix, err := indexer.New(db, client, walletService, indexer.Config{
+		Contract:      common.HexToAddress(cfg.AASharingAddress),
+		StartBlock:    cfg.IndexerStartBlock,
+		PollInterval:  cfg.IndexerPollInterval,
+		Confirmations: cfg.IndexerConfirmations,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	verificationService, err := services.NewDepositVerificationService(db, client, walletService,
+		common.HexToAddress(cfg.AASharingAddress), cfg.IndexerConfirmations)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	go func() {
+		log.Printf("Indexing AASharing %s", cfg.AASharingAddress)
+		if err := ix.Run(context.Background()); err != nil {
+			log.Println("Indexer stopped:", err)
+		}
+	}()
+	go func() {
+		if err := verificationService.Run(context.Background(), cfg.IndexerPollInterval); err != nil {
+			log.Println("Deposit verification stopped:", err)
+		}
+	}()
+	return client, verificationService, nil
 }
 
-func initDB() (*gorm.DB, error) {
-	cfg := config.LoadConfig()
-	return gorm.Open(postgres.Open(cfg.DatabaseURL), &gorm.Config{})
-}
\ No newline at end of file
+// startGaslessRelayer starts relaying signed contributions to the AASharing
+// contract from the GASLESS_RELAYER_KEY account.
+func startGaslessRelayer(cfg *config.Config, db *gorm.DB, client *chain.Client, walletService *services.WalletService) (*gasless.Relayer, error) {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(cfg.GaslessRelayerKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid GASLESS_RELAYER_KEY: %w", err)
+	}
+
+	queue, err := newQueue(cfg, db, client, key, cfg.IndexerConfirmations)
+	if err != nil {
+		return nil, err
+	}
+	relayer, err := gasless.New(db, client, queue, walletService, gasless.Config{
+		Contract:     common.HexToAddress(cfg.AASharingAddress),
+		PollInterval: cfg.IndexerPollInterval,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	go func() {
+		log.Printf("Relaying gasless contributions as %s", relayer.Sender().Hex())
+		if err := relayer.Run(context.Background()); err != nil {
+			log.Println("Gasless relayer stopped:", err)
+		}
+	}()
+	return relayer, nil
+}
+
+func runRelayer(cfg *config.Config, db *gorm.DB) error {
+	if !common.IsHexAddress(cfg.BridgeSourceAddress) {
+		return fmt.Errorf("invalid BRIDGE_SOURCE_ADDRESS %q", cfg.BridgeSourceAddress)
+	}
+	if !common.IsHexAddress(cfg.BridgeDestinationAddress) {
+		return fmt.Errorf("invalid BRIDGE_DESTINATION_ADDRESS %q", cfg.BridgeDestinationAddress)
+	}
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(cfg.BridgeValidatorKey, "0x"))
+	if err != nil {
+		return fmt.Errorf("invalid BRIDGE_VALIDATOR_KEY: %w", err)
+	}
+
+	source, err := chain.Dial(context.Background(), cfg.BridgeSourceRPCURL)
+	if err != nil {
+		return err
+	}
+	destination, err := chain.Dial(context.Background(), cfg.BridgeDestinationRPCURL)
+	if err != nil {
+		return err
+	}
+
+	queue, err := newQueue(cfg, db, destination, key, cfg.BridgeConfirmations)
+	if err != nil {
+		return err
+	}
+	relayer, err := bridge.New(db, source, destination, queue, bridge.Config{
+		SourceContract:      common.HexToAddress(cfg.BridgeSourceAddress),
+		DestinationContract: common.HexToAddress(cfg.BridgeDestinationAddress),
+		ValidatorKey:        key,
+		StartBlock:          cfg.BridgeStartBlock,
+		Confirmations:       cfg.BridgeConfirmations,
+		PollInterval:        cfg.BridgePollInterval,
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Relaying CrossChainBridge %s to %s as validator %s",
+		cfg.BridgeSourceAddress, cfg.BridgeDestinationAddress, relayer.Validator().Hex())
+	return relayer.Run(context.Background())
+}
+
+// newQueue creates the transaction queue of key's account on the chain of
+// client. Its transactions are confirmed after confirmations blocks.
+func newQueue(cfg *config.Config, db *gorm.DB, client *chain.Client, key *ecdsa.PrivateKey, confirmations uint64) (*txqueue.Queue, error) {
+	var maxGasPrice *big.Int
+	if cfg.TxMaxGasPriceGwei > 0 {
+		maxGasPrice = new(big.Int).Mul(new(big.Int).SetUint64(cfg.TxMaxGasPriceGwei), big.NewInt(params.GWei))
+	}
+	return txqueue.New(context.Background(), db, client, txqueue.Config{
+		Key:           key,
+		Confirmations: confirmations,
+		BumpAfter:     cfg.TxBumpAfter,
+		MaxGasPrice:   maxGasPrice,
+	})
+}
@@ -0,0 +1,35 @@
+package bridge
+
+// maxFeeBasisPoints is the highest fee setBridgeFee accepts.
+const maxFeeBasisPoints = 1000
+
+// CalculateFee splits amount into the fee and the net amount the way
+// CrossChainBridge.calculateFee does, for a fee of basisPoints.
+func CalculateFee(amount int64, basisPoints uint64) (fee, net int64) {
+	fee = amount * int64(basisPoints) / 10000
+	return fee, amount - fee
+}
+
+// GrossAmount returns the smallest amount whose calculateFee leaves net,
+// i.e. what a user sent for a deposit that was initiated with net.
+// CrossChainDepositInitiated only carries the net amount.
+func GrossAmount(net int64, basisPoints uint64) int64 {
+	if basisPoints == 0 || basisPoints > maxFeeBasisPoints {
+		return net
+	}
+	// net = amount - floor(amount*bps/10000) is nondecreasing in amount, so
+	// start from the real-valued solution and step to the smallest match
+	gross := (net*10000 + int64(10000-basisPoints) - 1) / int64(10000-basisPoints)
+	for gross > net {
+		if _, n := CalculateFee(gross-1, basisPoints); n < net {
+			break
+		}
+		gross--
+	}
+	for {
+		if _, n := CalculateFee(gross, basisPoints); n >= net {
+			return gross
+		}
+		gross++
+	}
+}
@@ -0,0 +1,482 @@
+// Package bridge relays CrossChainBridge deposits: it watches the source
+// chain for initiated deposits and completes them on the destination chain
+// with a validator signature.
+package bridge
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"aa-sharing-backend/internal/chain"
+	"aa-sharing-backend/internal/models"
+	"aa-sharing-backend/internal/txqueue"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxAttempts is how often a completion is tried before the message is
+// marked failed.
+const maxAttempts = 5
+
+// SourceClient is the JSON-RPC API used on the source chain.
+type SourceClient interface {
+	bind.ContractCaller
+	bind.ContractFilterer
+	ChainID(ctx context.Context) (*big.Int, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// DestinationClient is the JSON-RPC API used on the destination chain. Both
+// are satisfied by *ethclient.Client.
+type DestinationClient interface {
+	bind.ContractCaller
+}
+
+type Config struct {
+	SourceContract      common.Address
+	DestinationContract common.Address
+	ValidatorKey        *ecdsa.PrivateKey
+	StartBlock          uint64        // first source block to scan when there is no checkpoint
+	Confirmations       uint64        // source blocks on top of a deposit before it is relayed
+	BatchSize           uint64        // blocks per eth_getLogs request
+	PollInterval        time.Duration // wait between polls
+}
+
+// Relayer completes deposits through queue, a transaction queue on the
+// destination chain.
+type Relayer struct {
+	db        *gorm.DB
+	source    SourceClient
+	queue     *txqueue.Queue
+	cfg       Config
+	validator common.Address
+
+	sourceBridge      *chain.CrossChainBridgeCaller
+	sourceEvents      *chain.CrossChainBridgeFilterer
+	destinationBridge *chain.CrossChainBridgeCaller
+	// completions only packs calls for the queue, which sends them
+	completions *chain.CrossChainBridgeTransactor
+
+	sourceChainID *big.Int
+}
+
+func New(db *gorm.DB, source SourceClient, destination DestinationClient, queue *txqueue.Queue, cfg Config) (*Relayer, error) {
+	if cfg.ValidatorKey == nil {
+		return nil, errors.New("validator key is required")
+	}
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = 1000
+	}
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = 15 * time.Second
+	}
+	sourceBridge, err := chain.NewCrossChainBridgeCaller(cfg.SourceContract, source)
+	if err != nil {
+		return nil, err
+	}
+	sourceEvents, err := chain.NewCrossChainBridgeFilterer(cfg.SourceContract, source)
+	if err != nil {
+		return nil, err
+	}
+	destinationBridge, err := chain.NewCrossChainBridgeCaller(cfg.DestinationContract, destination)
+	if err != nil {
+		return nil, err
+	}
+	completions, err := chain.NewCrossChainBridgeTransactor(cfg.DestinationContract, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Relayer{
+		db:                db,
+		source:            source,
+		queue:             queue,
+		cfg:               cfg,
+		validator:         crypto.PubkeyToAddress(cfg.ValidatorKey.PublicKey),
+		sourceBridge:      sourceBridge,
+		sourceEvents:      sourceEvents,
+		destinationBridge: destinationBridge,
+		completions:       completions,
+	}, nil
+}
+
+// Validator returns the address of the validator key.
+func (r *Relayer) Validator() common.Address {
+	return r.validator
+}
+
+// Run relays deposits until ctx is cancelled.
+func (r *Relayer) Run(ctx context.Context) error {
+	for {
+		if err := r.Sync(ctx); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return err
+			}
+			log.Println("Relayer sync failed:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(r.cfg.PollInterval):
+		}
+	}
+}
+
+// Sync records new deposits from the source chain and advances the
+// completion transactions in the queue, then every message that is not yet
+// completed or failed.
+func (r *Relayer) Sync(ctx context.Context) error {
+	if r.sourceChainID == nil {
+		sourceChainID, err := r.source.ChainID(ctx)
+		if err != nil {
+			return err
+		}
+		r.sourceChainID = sourceChainID
+	}
+
+	if err := r.watch(ctx); err != nil {
+		return err
+	}
+	if err := r.queue.Sync(ctx); err != nil {
+		return err
+	}
+
+	var messages []models.BridgeMessage
+	if err := r.db.Where("destination_chain_id = ? AND status IN ?", r.queue.ChainID().Uint64(),
+		[]string{models.BridgeMessagePending, models.BridgeMessageSubmitted}).
+		Order("id").
+		Find(&messages).Error; err != nil {
+		return err
+	}
+	for i := range messages {
+		if err := r.advance(ctx, &messages[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// watch stores the deposits initiated on the source chain for the
+// destination chain, up to the confirmation depth.
+func (r *Relayer) watch(ctx context.Context) error {
+	checkpoint, err := r.loadCheckpoint()
+	if err != nil {
+		return err
+	}
+
+	head, err := r.source.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	if head < r.cfg.Confirmations {
+		return nil
+	}
+	head -= r.cfg.Confirmations
+
+	for from := checkpoint.LastBlock + 1; from <= head; from = checkpoint.LastBlock + 1 {
+		to := from + r.cfg.BatchSize - 1
+		if to > head {
+			to = head
+		}
+
+		if err := r.recordRange(ctx, from, to); err != nil {
+			return err
+		}
+
+		checkpoint.LastBlock = to
+		if err := r.db.Save(checkpoint).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordRange records the deposits initiated in blocks from to to.
+func (r *Relayer) recordRange(ctx context.Context, from, to uint64) error {
+	deposits, err := r.sourceEvents.FilterCrossChainDepositInitiated(&bind.FilterOpts{
+		Start:   from,
+		End:     &to,
+		Context: ctx,
+	}, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer deposits.Close()
+
+	for deposits.Next() {
+		if err := r.record(ctx, deposits.Event); err != nil {
+			return err
+		}
+	}
+	return deposits.Error()
+}
+
+// record stores a CrossChainDepositInitiated event as a pending message, with
+// the fee that was taken from the deposit.
+func (r *Relayer) record(ctx context.Context, deposit *chain.CrossChainBridgeCrossChainDepositInitiated) error {
+	l := deposit.Raw
+	if l.Removed {
+		return nil
+	}
+	messageID := common.Hash(deposit.MessageId)
+
+	if deposit.DestinationChain.Cmp(r.queue.ChainID()) != 0 {
+		return nil // relayed by whoever serves that chain
+	}
+	if !deposit.Amount.IsInt64() || !deposit.Nonce.IsUint64() || !deposit.PartnershipId.IsUint64() {
+		log.Printf("Relayer: skipping message %s, value out of range", messageID.Hex())
+		return nil
+	}
+
+	basisPoints, err := r.feeBasisPoints(ctx, l.BlockNumber)
+	if err != nil {
+		return err
+	}
+	amount := deposit.Amount.Int64()
+	gross := GrossAmount(amount, basisPoints)
+
+	message := models.BridgeMessage{
+		MessageID:          messageID.Hex(),
+		SourceChainID:      r.sourceChainID.Uint64(),
+		DestinationChainID: deposit.DestinationChain.Uint64(),
+		UserAddress:        deposit.User.Hex(),
+		ChainPartnershipID: deposit.PartnershipId.Uint64(),
+		Amount:             models.USDC(amount),
+		GrossAmount:        models.USDC(gross),
+		Fee:                models.USDC(gross - amount),
+		FeeBasisPoints:     basisPoints,
+		Nonce:              deposit.Nonce.Uint64(),
+		SourceTxHash:       l.TxHash.Hex(),
+		SourceLogIndex:     l.Index,
+		SourceBlockNumber:  l.BlockNumber,
+		Status:             models.BridgeMessagePending,
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&message)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		return tx.Create(&models.BridgeMessageEvent{
+			BridgeMessageID: message.ID,
+			Status:          models.BridgeMessagePending,
+			Detail:          "Deposit initiated on the source chain",
+			TxHash:          message.SourceTxHash,
+		}).Error
+	})
+}
+
+// feeBasisPoints reads bridgeFee from the source contract as of block. Nodes
+// that have pruned that state are asked for the current fee instead.
+func (r *Relayer) feeBasisPoints(ctx context.Context, block uint64) (uint64, error) {
+	fee, err := r.sourceBridge.BridgeFee(&bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(block)})
+	if err != nil {
+		if fee, err = r.sourceBridge.BridgeFee(&bind.CallOpts{Context: ctx}); err != nil {
+			return 0, err
+		}
+	}
+	if !fee.IsUint64() {
+		return 0, fmt.Errorf("bridge fee %s out of range", fee)
+	}
+	return fee.Uint64(), nil
+}
+
+// advance moves a message one step towards completion. The completion is
+// queued in the same database transaction that marks the message submitted,
+// so after a restart the stored transaction is broadcast again instead of a
+// new one being sent.
+func (r *Relayer) advance(ctx context.Context, message *models.BridgeMessage) error {
+	if message.Status == models.BridgeMessageSubmitted {
+		return r.checkSubmitted(ctx, message)
+	}
+
+	processed, err := r.processed(ctx, common.HexToHash(message.MessageID))
+	if err != nil {
+		return err
+	}
+	if processed {
+		return r.complete(message, "", "Completed on the destination chain by another relayer")
+	}
+
+	data, signature, err := r.buildCompletion(message)
+	if err != nil {
+		return err
+	}
+
+	var submission *models.ChainSubmission
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		submission, err = r.queue.Enqueue(ctx, tx, txqueue.Request{
+			To:        r.cfg.DestinationContract,
+			Data:      data,
+			Reference: fmt.Sprintf("bridge_message:%d", message.ID),
+		})
+		if err != nil {
+			return err
+		}
+		return r.setStatus(tx, message, map[string]interface{}{
+			"status":             models.BridgeMessageSubmitted,
+			"signature":          hexutil.Encode(signature),
+			"submission_id":      submission.ID,
+			"completion_tx_hash": submission.TxHash,
+			"attempts":           message.Attempts + 1,
+		}, "Completion submitted to the destination chain", submission.TxHash)
+	})
+	if errors.Is(err, txqueue.ErrEstimateGas) {
+		return r.recordFailure(message, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Relayer: submitted completion of %s in tx %s", message.MessageID, submission.TxHash)
+	return r.queue.Broadcast(ctx, submission)
+}
+
+// checkSubmitted settles a message whose completion has been queued, once
+// the queue has confirmed, reverted or dropped its transaction.
+func (r *Relayer) checkSubmitted(ctx context.Context, message *models.BridgeMessage) error {
+	submission, err := r.queue.Get(*message.SubmissionID)
+	if err != nil {
+		return err
+	}
+
+	switch submission.Status {
+	case models.SubmissionConfirmed:
+		return r.complete(message, submission.TxHash, "Completion confirmed on the destination chain")
+	case models.SubmissionReverted, models.SubmissionDropped:
+		// Someone else may have completed the message first
+		processed, err := r.processed(ctx, common.HexToHash(message.MessageID))
+		if err != nil {
+			return err
+		}
+		if processed {
+			return r.complete(message, "", "Completed on the destination chain by another relayer")
+		}
+		return r.recordFailure(message, fmt.Errorf("completion transaction %s", submission.Status))
+	}
+
+	// Still pending or not deep enough yet; follow gas price replacements
+	if submission.TxHash != message.CompletionTxHash {
+		return r.db.Model(message).Update("completion_tx_hash", submission.TxHash).Error
+	}
+	return nil
+}
+
+// recordFailure returns the message to pending so it is retried, or marks it
+// failed once it has used up its attempts.
+func (r *Relayer) recordFailure(message *models.BridgeMessage, cause error) error {
+	attempts := message.Attempts
+	if message.Status == models.BridgeMessagePending {
+		attempts++
+	}
+	status := models.BridgeMessagePending
+	if attempts >= maxAttempts {
+		status = models.BridgeMessageFailed
+		log.Printf("Relayer: giving up on message %s: %v", message.MessageID, cause)
+	}
+	return r.setStatus(r.db, message, map[string]interface{}{
+		"status":     status,
+		"attempts":   attempts,
+		"last_error": cause.Error(),
+	}, cause.Error(), "")
+}
+
+// complete marks the message completed. txHash is the completion
+// transaction, if it was ours.
+func (r *Relayer) complete(message *models.BridgeMessage, txHash, detail string) error {
+	changes := map[string]interface{}{
+		"status":       models.BridgeMessageCompleted,
+		"completed_at": time.Now(),
+	}
+	if txHash != "" {
+		changes["completion_tx_hash"] = txHash
+	}
+	return r.setStatus(r.db, message, changes, detail, txHash)
+}
+
+// setStatus applies changes, which include the new status, to the message
+// and adds the change to its history.
+func (r *Relayer) setStatus(db *gorm.DB, message *models.BridgeMessage, changes map[string]interface{}, detail, txHash string) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(message).Updates(changes).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.BridgeMessageEvent{
+			BridgeMessageID: message.ID,
+			Status:          changes["status"].(string),
+			Detail:          detail,
+			TxHash:          txHash,
+		}).Error
+	})
+}
+
+// buildCompletion signs the message as validator and packs the
+// completeCrossChainDeposit call.
+func (r *Relayer) buildCompletion(message *models.BridgeMessage) ([]byte, []byte, error) {
+	completion := Completion{
+		User:               common.HexToAddress(message.UserAddress),
+		PartnershipID:      new(big.Int).SetUint64(message.ChainPartnershipID),
+		Amount:             message.Amount.BigInt(),
+		SourceChainID:      new(big.Int).SetUint64(message.SourceChainID),
+		DestinationChainID: r.queue.ChainID(),
+		MessageID:          common.HexToHash(message.MessageID),
+		Nonce:              new(big.Int).SetUint64(message.Nonce),
+	}
+	signature, err := completion.Sign(r.cfg.ValidatorKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	call, err := r.completions.CompleteCrossChainDeposit(chain.PackOpts(),
+		completion.User,
+		completion.PartnershipID,
+		completion.Amount,
+		completion.SourceChainID,
+		completion.MessageID,
+		completion.Nonce,
+		signature,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return call.Data(), signature, nil
+}
+
+// processed reports whether the destination contract has completed the
+// message, whoever submitted it.
+func (r *Relayer) processed(ctx context.Context, messageID common.Hash) (bool, error) {
+	return r.destinationBridge.ProcessedMessages(&bind.CallOpts{Context: ctx}, messageID)
+}
+
+func (r *Relayer) loadCheckpoint() (*models.IndexerCheckpoint, error) {
+	checkpoint := models.IndexerCheckpoint{
+		ChainID:  r.sourceChainID.Uint64(),
+		Contract: strings.ToLower(r.cfg.SourceContract.Hex()),
+	}
+	if r.cfg.StartBlock > 0 {
+		checkpoint.LastBlock = r.cfg.StartBlock - 1
+	}
+	if err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&checkpoint).Error; err != nil {
+		return nil, err
+	}
+	if checkpoint.ID != 0 {
+		return &checkpoint, nil
+	}
+
+	if err := r.db.Where("chain_id = ? AND contract = ?", checkpoint.ChainID, checkpoint.Contract).
+		First(&checkpoint).Error; err != nil {
+		return nil, err
+	}
+	return &checkpoint, nil
+}
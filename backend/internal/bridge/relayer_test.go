@@ -0,0 +1,206 @@
+package bridge
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"aa-sharing-backend/internal/chain"
+	"aa-sharing-backend/internal/chaintest"
+	"aa-sharing-backend/internal/models"
+	"aa-sharing-backend/internal/testdb"
+	"aa-sharing-backend/internal/txqueue"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestRelayerCompletesDepositOnDestination(t *testing.T) {
+	db := testdb.Open(t)
+	source := chaintest.New(t)
+	destination := chaintest.Destination(t)
+	ctx := context.Background()
+
+	validator, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	validatorAddress := crypto.PubkeyToAddress(validator.PublicKey)
+
+	// The destination partnership receives the deposit made on the source
+	target := destination.DeployAASharing(t)
+	alice, bob := destination.Account(t), destination.Account(t)
+	partnershipID := target.CreatePartnership(t, alice, bob)
+	destinationBridge, _ := destination.Deploy(t, "CrossChainBridge", target.USDC, target.Address, validatorAddress)
+
+	origin := source.DeployAASharing(t)
+	sourceBridge, _ := source.Deploy(t, "CrossChainBridge", origin.USDC, origin.Address, validatorAddress)
+	depositor := source.Account(t)
+	source.Mined(t)(origin.Token.Transact(source.Transactor(t, source.Deployer), "mint",
+		crypto.PubkeyToAddress(depositor.PublicKey), big.NewInt(5_000_000)))
+	source.Mined(t)(origin.Token.Transact(source.Transactor(t, depositor), "approve", sourceBridge, big.NewInt(5_000_000)))
+
+	destinationChainID, err := destination.Client.ChainID(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	initiator, err := chain.NewCrossChainBridge(sourceBridge, source.Client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	source.Mined(t)(initiator.InitiateCrossChainDeposit(source.Transactor(t, depositor),
+		partnershipID, big.NewInt(5_000_000), destinationChainID))
+	source.Mine(t)
+
+	queue, err := txqueue.New(ctx, db, destination.Client, txqueue.Config{Key: destination.Account(t)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	relayer, err := New(db, source.Client, destination.Client, queue, Config{
+		SourceContract:      sourceBridge,
+		DestinationContract: destinationBridge,
+		ValidatorKey:        validator,
+		Confirmations:       1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Record and submit, see the completion mined, then confirmed
+	for i := 0; i < 3; i++ {
+		if err := relayer.Sync(ctx); err != nil {
+			t.Fatalf("sync %d: %v", i+1, err)
+		}
+	}
+
+	var message models.BridgeMessage
+	if err := db.First(&message).Error; err != nil {
+		t.Fatalf("deposit not recorded: %v", err)
+	}
+	if message.Status != models.BridgeMessageCompleted || message.CompletionTxHash == "" {
+		t.Errorf("message %s with completion %q, want completed", message.Status, message.CompletionTxHash)
+	}
+	if message.Amount != models.USDC(5_000_000) || message.ChainPartnershipID != partnershipID.Uint64() {
+		t.Errorf("message amount %s for partnership %d", message.Amount, message.ChainPartnershipID)
+	}
+
+	completed, err := chain.NewCrossChainBridgeCaller(destinationBridge, destination.Client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if processed, err := completed.ProcessedMessages(&bind.CallOpts{}, common.HexToHash(message.MessageID)); err != nil || !processed {
+		t.Errorf("processedMessages = %v (%v), want true", processed, err)
+	}
+	partnership, err := target.GetPartnership(&bind.CallOpts{}, partnershipID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if partnership.TotalBalance.Cmp(big.NewInt(5_000_000)) != 0 {
+		t.Errorf("destination partnership balance %s, want 5000000", partnership.TotalBalance)
+	}
+
+	// A completed message is not submitted again
+	if err := relayer.Sync(ctx); err != nil {
+		t.Fatal(err)
+	}
+	var submissions int64
+	db.Model(&models.ChainSubmission{}).Count(&submissions)
+	if submissions != 1 {
+		t.Errorf("%d completion transactions, want 1", submissions)
+	}
+}
+
+func TestGrossAmountInvertsCalculateFee(t *testing.T) {
+	for _, basisPoints := range []uint64{0, 1, 30, 50, 999, 1000} {
+		for _, amount := range []int64{1_000_000, 1_000_001, 1_999_999, 12_345_678, 100_000_000_000} {
+			_, net := CalculateFee(amount, basisPoints)
+			gross := GrossAmount(net, basisPoints)
+			if _, n := CalculateFee(gross, basisPoints); n != net || gross > amount {
+				t.Errorf("GrossAmount(%d, %d) = %d, which nets %d; sent %d", net, basisPoints, gross, n, amount)
+			}
+			if _, n := CalculateFee(gross-1, basisPoints); n == net {
+				t.Errorf("GrossAmount(%d, %d) = %d, but %d nets the same", net, basisPoints, gross, gross-1)
+			}
+		}
+	}
+}
+
+// TestRelayerRecordsFeeAndHistory relays a deposit back to the chain it was
+// made on; it is about the recorded fee and status history, not the hop.
+func TestRelayerRecordsFeeAndHistory(t *testing.T) {
+	db := testdb.Open(t)
+	c := chaintest.New(t)
+	ctx := context.Background()
+
+	validator, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	aa := c.DeployAASharing(t)
+	partnershipID := aa.CreatePartnership(t, c.Account(t), c.Account(t))
+	address, _ := c.Deploy(t, "CrossChainBridge", aa.USDC, aa.Address, crypto.PubkeyToAddress(validator.PublicKey))
+	bridge, err := chain.NewCrossChainBridge(address, c.Client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Mined(t)(bridge.SetBridgeFee(c.Transactor(t, c.Deployer), big.NewInt(50)))
+
+	depositor := c.Account(t)
+	c.Mined(t)(aa.Token.Transact(c.Transactor(t, c.Deployer), "mint",
+		crypto.PubkeyToAddress(depositor.PublicKey), big.NewInt(12_345_678)))
+	c.Mined(t)(aa.Token.Transact(c.Transactor(t, depositor), "approve", address, big.NewInt(12_345_678)))
+	chainID, err := c.Client.ChainID(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Mined(t)(bridge.InitiateCrossChainDeposit(c.Transactor(t, depositor), partnershipID, big.NewInt(12_345_678), chainID))
+	c.Mine(t)
+
+	queue, err := txqueue.New(ctx, db, c.Client, txqueue.Config{Key: c.Account(t)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	relayer, err := New(db, c.Client, c.Client, queue, Config{
+		SourceContract:      address,
+		DestinationContract: address,
+		ValidatorKey:        validator,
+		Confirmations:       1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := relayer.Sync(ctx); err != nil {
+			t.Fatalf("sync %d: %v", i+1, err)
+		}
+	}
+
+	var message models.BridgeMessage
+	if err := db.First(&message).Error; err != nil {
+		t.Fatalf("deposit not recorded: %v", err)
+	}
+	// 0.5% of 12.345678 USDC, rounded down by the contract
+	if message.GrossAmount != models.USDC(12_345_678) || message.Fee != models.USDC(61_728) ||
+		message.Amount != models.USDC(12_283_950) || message.FeeBasisPoints != 50 {
+		t.Errorf("sent %s, fee %s (%d bps), net %s; want 12.345678, 0.061728 (50 bps), 12.28395",
+			message.GrossAmount, message.Fee, message.FeeBasisPoints, message.Amount)
+	}
+
+	var history []models.BridgeMessageEvent
+	if err := db.Where("bridge_message_id = ?", message.ID).Order("id").Find(&history).Error; err != nil {
+		t.Fatal(err)
+	}
+	var statuses []string
+	for _, event := range history {
+		statuses = append(statuses, event.Status)
+	}
+	want := []string{models.BridgeMessagePending, models.BridgeMessageSubmitted, models.BridgeMessageCompleted}
+	if len(statuses) != len(want) || statuses[0] != want[0] || statuses[1] != want[1] || statuses[2] != want[2] {
+		t.Fatalf("history %v, want %v", statuses, want)
+	}
+	if history[0].TxHash != message.SourceTxHash || history[2].TxHash != message.CompletionTxHash {
+		t.Errorf("history transactions %s and %s, want the deposit %s and the completion %s",
+			history[0].TxHash, history[2].TxHash, message.SourceTxHash, message.CompletionTxHash)
+	}
+}
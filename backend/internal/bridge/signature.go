@@ -0,0 +1,50 @@
+package bridge
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Completion holds the arguments of completeCrossChainDeposit that the
+// validator signs.
+type Completion struct {
+	User               common.Address
+	PartnershipID      *big.Int
+	Amount             *big.Int
+	SourceChainID      *big.Int
+	DestinationChainID *big.Int
+	MessageID          common.Hash
+	Nonce              *big.Int
+}
+
+// Hash returns keccak256(abi.encodePacked(user, partnershipId, amount,
+// sourceChain, block.chainid, messageId, nonce)) as computed by the
+// destination contract.
+func (c *Completion) Hash() common.Hash {
+	return crypto.Keccak256Hash(
+		c.User.Bytes(),
+		math.U256Bytes(new(big.Int).Set(c.PartnershipID)),
+		math.U256Bytes(new(big.Int).Set(c.Amount)),
+		math.U256Bytes(new(big.Int).Set(c.SourceChainID)),
+		math.U256Bytes(new(big.Int).Set(c.DestinationChainID)),
+		c.MessageID.Bytes(),
+		math.U256Bytes(new(big.Int).Set(c.Nonce)),
+	)
+}
+
+// Sign signs the completion hash as an Ethereum signed message, which is what
+// the contract recovers the validator from.
+func (c *Completion) Sign(key *ecdsa.PrivateKey) ([]byte, error) {
+	hash := c.Hash()
+	signature, err := crypto.Sign(accounts.TextHash(hash.Bytes()), key)
+	if err != nil {
+		return nil, err
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+	return signature, nil
+}
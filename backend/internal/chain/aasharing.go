@@ -0,0 +1,2626 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package chain
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// AASharingGoal is an auto generated low-level Go binding around an user-defined struct.
+type AASharingGoal struct {
+	Name          string
+	Description   string
+	TargetAmount  *big.Int
+	CurrentAmount *big.Int
+	IsCompleted   bool
+	CreatedAt     *big.Int
+}
+
+// AASharingGratitudeEntry is an auto generated low-level Go binding around an user-defined struct.
+type AASharingGratitudeEntry struct {
+	Contributor common.Address
+	Text        string
+	UsdcAmount  *big.Int
+	Timestamp   *big.Int
+}
+
+// AASharingPartnership is an auto generated low-level Go binding around an user-defined struct.
+type AASharingPartnership struct {
+	Partner1     common.Address
+	Partner2     common.Address
+	Nickname1    string
+	Nickname2    string
+	TotalBalance *big.Int
+	CreatedAt    *big.Int
+	IsActive     bool
+	GoalCount    *big.Int
+}
+
+// AASharingMetaData contains all meta data concerning the AASharing contract.
+var AASharingMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_usdcToken\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[],\"name\":\"ECDSAInvalidSignature\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"length\",\"type\":\"uint256\"}],\"name\":\"ECDSAInvalidSignatureLength\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}],\"name\":\"ECDSAInvalidSignatureS\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"EnforcedPause\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"ExpectedPause\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidShortString\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"name\":\"OwnableInvalidOwner\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"OwnableUnauthorizedAccount\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"ReentrancyGuardReentrantCall\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"str\",\"type\":\"string\"}],\"name\":\"StringTooLong\",\"type\":\"error\"},{\"anonymous\":false,\"inputs\":[],\"name\":\"EIP712DomainChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"partnershipId\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"depositor\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"source\",\"type\":\"string\"}],\"name\":\"FundsDeposited\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"partnershipId\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"partner1\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"partner2\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amountEach\",\"type\":\"uint256\"}],\"name\":\"FundsWithdrawn\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"partnershipId\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"goalId\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"goalName\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"targetAmount\",\"type\":\"uint256\"}],\"name\":\"GoalCreated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"partnershipId\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"contributor\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"gratitudeText\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"usdcAmount\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"timestamp\",\"type\":\"uint256\"}],\"name\":\"GratitudeAdded\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"previousOwner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"OwnershipTransferred\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"partnershipId\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"partner1\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"partner2\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"nickname1\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"nickname2\",\"type\":\"string\"}],\"name\":\"PartnershipCreated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"Paused\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"Unpaused\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"CONTRIBUTION_TYPEHASH\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"partnershipId\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"gratitudeText\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"usdcAmount\",\"type\":\"uint256\"}],\"name\":\"addGratitude\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"contributionNonces\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"partnershipId\",\"type\":\"uint256\"},{\"internalType\":\"string\",\"name\":\"goalName\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"description\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"targetAmount\",\"type\":\"uint256\"}],\"name\":\"createGoal\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"partner2\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"nickname1\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"nickname2\",\"type\":\"string\"}],\"name\":\"createPartnership\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"partnershipId\",\"type\":\"uint256\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"partnershipId\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"nonce\",\"type\":\"bytes32\"},{\"internalType\":\"bytes\",\"name\":\"signature\",\"type\":\"bytes\"}],\"name\":\"crossChainDeposit\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"partnershipId\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"depositFunds\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"partnershipId\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"contributor\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"deadline\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"signature\",\"type\":\"bytes\"},{\"internalType\":\"uint8\",\"name\":\"permitV\",\"type\":\"uint8\"},{\"internalType\":\"bytes32\",\"name\":\"permitR\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"permitS\",\"type\":\"bytes32\"}],\"name\":\"depositFundsWithSignature\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"eip712Domain\",\"outputs\":[{\"internalType\":\"bytes1\",\"name\":\"fields\",\"type\":\"bytes1\"},{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"version\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"chainId\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"verifyingContract\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"salt\",\"type\":\"bytes32\"},{\"internalType\":\"uint256[]\",\"name\":\"extensions\",\"type\":\"uint256[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"emergencyWithdraw\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getContractStats\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"_totalPartnerships\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_totalGratitudeEntries\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_totalUSDCLocked\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_contractUSDCBalance\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"partnershipId\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"goalId\",\"type\":\"uint256\"}],\"name\":\"getGoal\",\"outputs\":[{\"internalType\":\"structAASharing.Goal\",\"name\":\"\",\"type\":\"tuple\",\"components\":[{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"description\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"targetAmount\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"currentAmount\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"isCompleted\",\"type\":\"bool\"},{\"internalType\":\"uint256\",\"name\":\"createdAt\",\"type\":\"uint256\"}]}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"partnershipId\",\"type\":\"uint256\"}],\"name\":\"getGratitudeCount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"partnershipId\",\"type\":\"uint256\"}],\"name\":\"getGratitudeEntries\",\"outputs\":[{\"internalType\":\"structAASharing.GratitudeEntry[]\",\"name\":\"\",\"type\":\"tuple[]\",\"components\":[{\"internalType\":\"address\",\"name\":\"contributor\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"text\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"usdcAmount\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"timestamp\",\"type\":\"uint256\"}]}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"partnershipId\",\"type\":\"uint256\"}],\"name\":\"getPartnership\",\"outputs\":[{\"internalType\":\"structAASharing.Partnership\",\"name\":\"\",\"type\":\"tuple\",\"components\":[{\"internalType\":\"address\",\"name\":\"partner1\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"partner2\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"nickname1\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"nickname2\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"totalBalance\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"createdAt\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"isActive\",\"type\":\"bool\"},{\"internalType\":\"uint256\",\"name\":\"goalCount\",\"type\":\"uint256\"}]}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"}],\"name\":\"getUserPartnerships\",\"outputs\":[{\"internalType\":\"uint256[]\",\"name\":\"\",\"type\":\"uint256[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"nextPartnershipId\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"partnershipGoals\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"description\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"targetAmount\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"currentAmount\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"isCompleted\",\"type\":\"bool\"},{\"internalType\":\"uint256\",\"name\":\"createdAt\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"partnershipGratitude\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"contributor\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"text\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"usdcAmount\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"timestamp\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"partnerships\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"partner1\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"partner2\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"nickname1\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"nickname2\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"totalBalance\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"createdAt\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"isActive\",\"type\":\"bool\"},{\"internalType\":\"uint256\",\"name\":\"goalCount\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"pause\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"paused\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"renounceOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"totalGratitudeEntries\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"totalPartnerships\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"totalUSDCLocked\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"transferOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"unpause\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"usdc\",\"outputs\":[{\"internalType\":\"contractIERC20\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"name\":\"usedNonces\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"userPartnerships\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"partnershipId\",\"type\":\"uint256\"}],\"name\":\"withdraw\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+}
+
+// AASharingABI is the input ABI used to generate the binding from.
+// Deprecated: Use AASharingMetaData.ABI instead.
+var AASharingABI = AASharingMetaData.ABI
+
+// AASharing is an auto generated Go binding around an Ethereum contract.
+type AASharing struct {
+	AASharingCaller     // Read-only binding to the contract
+	AASharingTransactor // Write-only binding to the contract
+	AASharingFilterer   // Log filterer for contract events
+}
+
+// AASharingCaller is an auto generated read-only Go binding around an Ethereum contract.
+type AASharingCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AASharingTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type AASharingTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AASharingFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type AASharingFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AASharingSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type AASharingSession struct {
+	Contract     *AASharing        // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// AASharingCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type AASharingCallerSession struct {
+	Contract *AASharingCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts    // Call options to use throughout this session
+}
+
+// AASharingTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type AASharingTransactorSession struct {
+	Contract     *AASharingTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts    // Transaction auth options to use throughout this session
+}
+
+// AASharingRaw is an auto generated low-level Go binding around an Ethereum contract.
+type AASharingRaw struct {
+	Contract *AASharing // Generic contract binding to access the raw methods on
+}
+
+// AASharingCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type AASharingCallerRaw struct {
+	Contract *AASharingCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// AASharingTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type AASharingTransactorRaw struct {
+	Contract *AASharingTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewAASharing creates a new instance of AASharing, bound to a specific deployed contract.
+func NewAASharing(address common.Address, backend bind.ContractBackend) (*AASharing, error) {
+	contract, err := bindAASharing(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &AASharing{AASharingCaller: AASharingCaller{contract: contract}, AASharingTransactor: AASharingTransactor{contract: contract}, AASharingFilterer: AASharingFilterer{contract: contract}}, nil
+}
+
+// NewAASharingCaller creates a new read-only instance of AASharing, bound to a specific deployed contract.
+func NewAASharingCaller(address common.Address, caller bind.ContractCaller) (*AASharingCaller, error) {
+	contract, err := bindAASharing(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &AASharingCaller{contract: contract}, nil
+}
+
+// NewAASharingTransactor creates a new write-only instance of AASharing, bound to a specific deployed contract.
+func NewAASharingTransactor(address common.Address, transactor bind.ContractTransactor) (*AASharingTransactor, error) {
+	contract, err := bindAASharing(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &AASharingTransactor{contract: contract}, nil
+}
+
+// NewAASharingFilterer creates a new log filterer instance of AASharing, bound to a specific deployed contract.
+func NewAASharingFilterer(address common.Address, filterer bind.ContractFilterer) (*AASharingFilterer, error) {
+	contract, err := bindAASharing(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &AASharingFilterer{contract: contract}, nil
+}
+
+// bindAASharing binds a generic wrapper to an already deployed contract.
+func bindAASharing(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := AASharingMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_AASharing *AASharingRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _AASharing.Contract.AASharingCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_AASharing *AASharingRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _AASharing.Contract.AASharingTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_AASharing *AASharingRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _AASharing.Contract.AASharingTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_AASharing *AASharingCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _AASharing.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_AASharing *AASharingTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _AASharing.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_AASharing *AASharingTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _AASharing.Contract.contract.Transact(opts, method, params...)
+}
+
+// CONTRIBUTIONTYPEHASH is a free data retrieval call binding the contract method 0x2fc2dbaf.
+//
+// Solidity: function CONTRIBUTION_TYPEHASH() view returns(bytes32)
+func (_AASharing *AASharingCaller) CONTRIBUTIONTYPEHASH(opts *bind.CallOpts) ([32]byte, error) {
+	var out []interface{}
+	err := _AASharing.contract.Call(opts, &out, "CONTRIBUTION_TYPEHASH")
+
+	if err != nil {
+		return *new([32]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([32]byte)).(*[32]byte)
+
+	return out0, err
+
+}
+
+// CONTRIBUTIONTYPEHASH is a free data retrieval call binding the contract method 0x2fc2dbaf.
+//
+// Solidity: function CONTRIBUTION_TYPEHASH() view returns(bytes32)
+func (_AASharing *AASharingSession) CONTRIBUTIONTYPEHASH() ([32]byte, error) {
+	return _AASharing.Contract.CONTRIBUTIONTYPEHASH(&_AASharing.CallOpts)
+}
+
+// CONTRIBUTIONTYPEHASH is a free data retrieval call binding the contract method 0x2fc2dbaf.
+//
+// Solidity: function CONTRIBUTION_TYPEHASH() view returns(bytes32)
+func (_AASharing *AASharingCallerSession) CONTRIBUTIONTYPEHASH() ([32]byte, error) {
+	return _AASharing.Contract.CONTRIBUTIONTYPEHASH(&_AASharing.CallOpts)
+}
+
+// ContributionNonces is a free data retrieval call binding the contract method 0x73e7c298.
+//
+// Solidity: function contributionNonces(address ) view returns(uint256)
+func (_AASharing *AASharingCaller) ContributionNonces(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _AASharing.contract.Call(opts, &out, "contributionNonces", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ContributionNonces is a free data retrieval call binding the contract method 0x73e7c298.
+//
+// Solidity: function contributionNonces(address ) view returns(uint256)
+func (_AASharing *AASharingSession) ContributionNonces(arg0 common.Address) (*big.Int, error) {
+	return _AASharing.Contract.ContributionNonces(&_AASharing.CallOpts, arg0)
+}
+
+// ContributionNonces is a free data retrieval call binding the contract method 0x73e7c298.
+//
+// Solidity: function contributionNonces(address ) view returns(uint256)
+func (_AASharing *AASharingCallerSession) ContributionNonces(arg0 common.Address) (*big.Int, error) {
+	return _AASharing.Contract.ContributionNonces(&_AASharing.CallOpts, arg0)
+}
+
+// Eip712Domain is a free data retrieval call binding the contract method 0x84b0196e.
+//
+// Solidity: function eip712Domain() view returns(bytes1 fields, string name, string version, uint256 chainId, address verifyingContract, bytes32 salt, uint256[] extensions)
+func (_AASharing *AASharingCaller) Eip712Domain(opts *bind.CallOpts) (struct {
+	Fields            [1]byte
+	Name              string
+	Version           string
+	ChainId           *big.Int
+	VerifyingContract common.Address
+	Salt              [32]byte
+	Extensions        []*big.Int
+}, error) {
+	var out []interface{}
+	err := _AASharing.contract.Call(opts, &out, "eip712Domain")
+
+	outstruct := new(struct {
+		Fields            [1]byte
+		Name              string
+		Version           string
+		ChainId           *big.Int
+		VerifyingContract common.Address
+		Salt              [32]byte
+		Extensions        []*big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Fields = *abi.ConvertType(out[0], new([1]byte)).(*[1]byte)
+	outstruct.Name = *abi.ConvertType(out[1], new(string)).(*string)
+	outstruct.Version = *abi.ConvertType(out[2], new(string)).(*string)
+	outstruct.ChainId = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.VerifyingContract = *abi.ConvertType(out[4], new(common.Address)).(*common.Address)
+	outstruct.Salt = *abi.ConvertType(out[5], new([32]byte)).(*[32]byte)
+	outstruct.Extensions = *abi.ConvertType(out[6], new([]*big.Int)).(*[]*big.Int)
+
+	return *outstruct, err
+
+}
+
+// Eip712Domain is a free data retrieval call binding the contract method 0x84b0196e.
+//
+// Solidity: function eip712Domain() view returns(bytes1 fields, string name, string version, uint256 chainId, address verifyingContract, bytes32 salt, uint256[] extensions)
+func (_AASharing *AASharingSession) Eip712Domain() (struct {
+	Fields            [1]byte
+	Name              string
+	Version           string
+	ChainId           *big.Int
+	VerifyingContract common.Address
+	Salt              [32]byte
+	Extensions        []*big.Int
+}, error) {
+	return _AASharing.Contract.Eip712Domain(&_AASharing.CallOpts)
+}
+
+// Eip712Domain is a free data retrieval call binding the contract method 0x84b0196e.
+//
+// Solidity: function eip712Domain() view returns(bytes1 fields, string name, string version, uint256 chainId, address verifyingContract, bytes32 salt, uint256[] extensions)
+func (_AASharing *AASharingCallerSession) Eip712Domain() (struct {
+	Fields            [1]byte
+	Name              string
+	Version           string
+	ChainId           *big.Int
+	VerifyingContract common.Address
+	Salt              [32]byte
+	Extensions        []*big.Int
+}, error) {
+	return _AASharing.Contract.Eip712Domain(&_AASharing.CallOpts)
+}
+
+// GetContractStats is a free data retrieval call binding the contract method 0xdfe6b5d6.
+//
+// Solidity: function getContractStats() view returns(uint256 _totalPartnerships, uint256 _totalGratitudeEntries, uint256 _totalUSDCLocked, uint256 _contractUSDCBalance)
+func (_AASharing *AASharingCaller) GetContractStats(opts *bind.CallOpts) (struct {
+	TotalPartnerships     *big.Int
+	TotalGratitudeEntries *big.Int
+	TotalUSDCLocked       *big.Int
+	ContractUSDCBalance   *big.Int
+}, error) {
+	var out []interface{}
+	err := _AASharing.contract.Call(opts, &out, "getContractStats")
+
+	outstruct := new(struct {
+		TotalPartnerships     *big.Int
+		TotalGratitudeEntries *big.Int
+		TotalUSDCLocked       *big.Int
+		ContractUSDCBalance   *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.TotalPartnerships = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.TotalGratitudeEntries = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.TotalUSDCLocked = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+	outstruct.ContractUSDCBalance = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// GetContractStats is a free data retrieval call binding the contract method 0xdfe6b5d6.
+//
+// Solidity: function getContractStats() view returns(uint256 _totalPartnerships, uint256 _totalGratitudeEntries, uint256 _totalUSDCLocked, uint256 _contractUSDCBalance)
+func (_AASharing *AASharingSession) GetContractStats() (struct {
+	TotalPartnerships     *big.Int
+	TotalGratitudeEntries *big.Int
+	TotalUSDCLocked       *big.Int
+	ContractUSDCBalance   *big.Int
+}, error) {
+	return _AASharing.Contract.GetContractStats(&_AASharing.CallOpts)
+}
+
+// GetContractStats is a free data retrieval call binding the contract method 0xdfe6b5d6.
+//
+// Solidity: function getContractStats() view returns(uint256 _totalPartnerships, uint256 _totalGratitudeEntries, uint256 _totalUSDCLocked, uint256 _contractUSDCBalance)
+func (_AASharing *AASharingCallerSession) GetContractStats() (struct {
+	TotalPartnerships     *big.Int
+	TotalGratitudeEntries *big.Int
+	TotalUSDCLocked       *big.Int
+	ContractUSDCBalance   *big.Int
+}, error) {
+	return _AASharing.Contract.GetContractStats(&_AASharing.CallOpts)
+}
+
+// GetGoal is a free data retrieval call binding the contract method 0x9208e378.
+//
+// Solidity: function getGoal(uint256 partnershipId, uint256 goalId) view returns((string,string,uint256,uint256,bool,uint256))
+func (_AASharing *AASharingCaller) GetGoal(opts *bind.CallOpts, partnershipId *big.Int, goalId *big.Int) (AASharingGoal, error) {
+	var out []interface{}
+	err := _AASharing.contract.Call(opts, &out, "getGoal", partnershipId, goalId)
+
+	if err != nil {
+		return *new(AASharingGoal), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(AASharingGoal)).(*AASharingGoal)
+
+	return out0, err
+
+}
+
+// GetGoal is a free data retrieval call binding the contract method 0x9208e378.
+//
+// Solidity: function getGoal(uint256 partnershipId, uint256 goalId) view returns((string,string,uint256,uint256,bool,uint256))
+func (_AASharing *AASharingSession) GetGoal(partnershipId *big.Int, goalId *big.Int) (AASharingGoal, error) {
+	return _AASharing.Contract.GetGoal(&_AASharing.CallOpts, partnershipId, goalId)
+}
+
+// GetGoal is a free data retrieval call binding the contract method 0x9208e378.
+//
+// Solidity: function getGoal(uint256 partnershipId, uint256 goalId) view returns((string,string,uint256,uint256,bool,uint256))
+func (_AASharing *AASharingCallerSession) GetGoal(partnershipId *big.Int, goalId *big.Int) (AASharingGoal, error) {
+	return _AASharing.Contract.GetGoal(&_AASharing.CallOpts, partnershipId, goalId)
+}
+
+// GetGratitudeCount is a free data retrieval call binding the contract method 0x4726efcd.
+//
+// Solidity: function getGratitudeCount(uint256 partnershipId) view returns(uint256)
+func (_AASharing *AASharingCaller) GetGratitudeCount(opts *bind.CallOpts, partnershipId *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _AASharing.contract.Call(opts, &out, "getGratitudeCount", partnershipId)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetGratitudeCount is a free data retrieval call binding the contract method 0x4726efcd.
+//
+// Solidity: function getGratitudeCount(uint256 partnershipId) view returns(uint256)
+func (_AASharing *AASharingSession) GetGratitudeCount(partnershipId *big.Int) (*big.Int, error) {
+	return _AASharing.Contract.GetGratitudeCount(&_AASharing.CallOpts, partnershipId)
+}
+
+// GetGratitudeCount is a free data retrieval call binding the contract method 0x4726efcd.
+//
+// Solidity: function getGratitudeCount(uint256 partnershipId) view returns(uint256)
+func (_AASharing *AASharingCallerSession) GetGratitudeCount(partnershipId *big.Int) (*big.Int, error) {
+	return _AASharing.Contract.GetGratitudeCount(&_AASharing.CallOpts, partnershipId)
+}
+
+// GetGratitudeEntries is a free data retrieval call binding the contract method 0xee1ad356.
+//
+// Solidity: function getGratitudeEntries(uint256 partnershipId) view returns((address,string,uint256,uint256)[])
+func (_AASharing *AASharingCaller) GetGratitudeEntries(opts *bind.CallOpts, partnershipId *big.Int) ([]AASharingGratitudeEntry, error) {
+	var out []interface{}
+	err := _AASharing.contract.Call(opts, &out, "getGratitudeEntries", partnershipId)
+
+	if err != nil {
+		return *new([]AASharingGratitudeEntry), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]AASharingGratitudeEntry)).(*[]AASharingGratitudeEntry)
+
+	return out0, err
+
+}
+
+// GetGratitudeEntries is a free data retrieval call binding the contract method 0xee1ad356.
+//
+// Solidity: function getGratitudeEntries(uint256 partnershipId) view returns((address,string,uint256,uint256)[])
+func (_AASharing *AASharingSession) GetGratitudeEntries(partnershipId *big.Int) ([]AASharingGratitudeEntry, error) {
+	return _AASharing.Contract.GetGratitudeEntries(&_AASharing.CallOpts, partnershipId)
+}
+
+// GetGratitudeEntries is a free data retrieval call binding the contract method 0xee1ad356.
+//
+// Solidity: function getGratitudeEntries(uint256 partnershipId) view returns((address,string,uint256,uint256)[])
+func (_AASharing *AASharingCallerSession) GetGratitudeEntries(partnershipId *big.Int) ([]AASharingGratitudeEntry, error) {
+	return _AASharing.Contract.GetGratitudeEntries(&_AASharing.CallOpts, partnershipId)
+}
+
+// GetPartnership is a free data retrieval call binding the contract method 0xaa0ef46a.
+//
+// Solidity: function getPartnership(uint256 partnershipId) view returns((address,address,string,string,uint256,uint256,bool,uint256))
+func (_AASharing *AASharingCaller) GetPartnership(opts *bind.CallOpts, partnershipId *big.Int) (AASharingPartnership, error) {
+	var out []interface{}
+	err := _AASharing.contract.Call(opts, &out, "getPartnership", partnershipId)
+
+	if err != nil {
+		return *new(AASharingPartnership), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(AASharingPartnership)).(*AASharingPartnership)
+
+	return out0, err
+
+}
+
+// GetPartnership is a free data retrieval call binding the contract method 0xaa0ef46a.
+//
+// Solidity: function getPartnership(uint256 partnershipId) view returns((address,address,string,string,uint256,uint256,bool,uint256))
+func (_AASharing *AASharingSession) GetPartnership(partnershipId *big.Int) (AASharingPartnership, error) {
+	return _AASharing.Contract.GetPartnership(&_AASharing.CallOpts, partnershipId)
+}
+
+// GetPartnership is a free data retrieval call binding the contract method 0xaa0ef46a.
+//
+// Solidity: function getPartnership(uint256 partnershipId) view returns((address,address,string,string,uint256,uint256,bool,uint256))
+func (_AASharing *AASharingCallerSession) GetPartnership(partnershipId *big.Int) (AASharingPartnership, error) {
+	return _AASharing.Contract.GetPartnership(&_AASharing.CallOpts, partnershipId)
+}
+
+// GetUserPartnerships is a free data retrieval call binding the contract method 0xe5cad4e4.
+//
+// Solidity: function getUserPartnerships(address user) view returns(uint256[])
+func (_AASharing *AASharingCaller) GetUserPartnerships(opts *bind.CallOpts, user common.Address) ([]*big.Int, error) {
+	var out []interface{}
+	err := _AASharing.contract.Call(opts, &out, "getUserPartnerships", user)
+
+	if err != nil {
+		return *new([]*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]*big.Int)).(*[]*big.Int)
+
+	return out0, err
+
+}
+
+// GetUserPartnerships is a free data retrieval call binding the contract method 0xe5cad4e4.
+//
+// Solidity: function getUserPartnerships(address user) view returns(uint256[])
+func (_AASharing *AASharingSession) GetUserPartnerships(user common.Address) ([]*big.Int, error) {
+	return _AASharing.Contract.GetUserPartnerships(&_AASharing.CallOpts, user)
+}
+
+// GetUserPartnerships is a free data retrieval call binding the contract method 0xe5cad4e4.
+//
+// Solidity: function getUserPartnerships(address user) view returns(uint256[])
+func (_AASharing *AASharingCallerSession) GetUserPartnerships(user common.Address) ([]*big.Int, error) {
+	return _AASharing.Contract.GetUserPartnerships(&_AASharing.CallOpts, user)
+}
+
+// NextPartnershipId is a free data retrieval call binding the contract method 0x496970a3.
+//
+// Solidity: function nextPartnershipId() view returns(uint256)
+func (_AASharing *AASharingCaller) NextPartnershipId(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _AASharing.contract.Call(opts, &out, "nextPartnershipId")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// NextPartnershipId is a free data retrieval call binding the contract method 0x496970a3.
+//
+// Solidity: function nextPartnershipId() view returns(uint256)
+func (_AASharing *AASharingSession) NextPartnershipId() (*big.Int, error) {
+	return _AASharing.Contract.NextPartnershipId(&_AASharing.CallOpts)
+}
+
+// NextPartnershipId is a free data retrieval call binding the contract method 0x496970a3.
+//
+// Solidity: function nextPartnershipId() view returns(uint256)
+func (_AASharing *AASharingCallerSession) NextPartnershipId() (*big.Int, error) {
+	return _AASharing.Contract.NextPartnershipId(&_AASharing.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_AASharing *AASharingCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _AASharing.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_AASharing *AASharingSession) Owner() (common.Address, error) {
+	return _AASharing.Contract.Owner(&_AASharing.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_AASharing *AASharingCallerSession) Owner() (common.Address, error) {
+	return _AASharing.Contract.Owner(&_AASharing.CallOpts)
+}
+
+// PartnershipGoals is a free data retrieval call binding the contract method 0xdeb647bb.
+//
+// Solidity: function partnershipGoals(uint256 , uint256 ) view returns(string name, string description, uint256 targetAmount, uint256 currentAmount, bool isCompleted, uint256 createdAt)
+func (_AASharing *AASharingCaller) PartnershipGoals(opts *bind.CallOpts, arg0 *big.Int, arg1 *big.Int) (struct {
+	Name          string
+	Description   string
+	TargetAmount  *big.Int
+	CurrentAmount *big.Int
+	IsCompleted   bool
+	CreatedAt     *big.Int
+}, error) {
+	var out []interface{}
+	err := _AASharing.contract.Call(opts, &out, "partnershipGoals", arg0, arg1)
+
+	outstruct := new(struct {
+		Name          string
+		Description   string
+		TargetAmount  *big.Int
+		CurrentAmount *big.Int
+		IsCompleted   bool
+		CreatedAt     *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Name = *abi.ConvertType(out[0], new(string)).(*string)
+	outstruct.Description = *abi.ConvertType(out[1], new(string)).(*string)
+	outstruct.TargetAmount = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+	outstruct.CurrentAmount = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+	outstruct.IsCompleted = *abi.ConvertType(out[4], new(bool)).(*bool)
+	outstruct.CreatedAt = *abi.ConvertType(out[5], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// PartnershipGoals is a free data retrieval call binding the contract method 0xdeb647bb.
+//
+// Solidity: function partnershipGoals(uint256 , uint256 ) view returns(string name, string description, uint256 targetAmount, uint256 currentAmount, bool isCompleted, uint256 createdAt)
+func (_AASharing *AASharingSession) PartnershipGoals(arg0 *big.Int, arg1 *big.Int) (struct {
+	Name          string
+	Description   string
+	TargetAmount  *big.Int
+	CurrentAmount *big.Int
+	IsCompleted   bool
+	CreatedAt     *big.Int
+}, error) {
+	return _AASharing.Contract.PartnershipGoals(&_AASharing.CallOpts, arg0, arg1)
+}
+
+// PartnershipGoals is a free data retrieval call binding the contract method 0xdeb647bb.
+//
+// Solidity: function partnershipGoals(uint256 , uint256 ) view returns(string name, string description, uint256 targetAmount, uint256 currentAmount, bool isCompleted, uint256 createdAt)
+func (_AASharing *AASharingCallerSession) PartnershipGoals(arg0 *big.Int, arg1 *big.Int) (struct {
+	Name          string
+	Description   string
+	TargetAmount  *big.Int
+	CurrentAmount *big.Int
+	IsCompleted   bool
+	CreatedAt     *big.Int
+}, error) {
+	return _AASharing.Contract.PartnershipGoals(&_AASharing.CallOpts, arg0, arg1)
+}
+
+// PartnershipGratitude is a free data retrieval call binding the contract method 0x9a069a28.
+//
+// Solidity: function partnershipGratitude(uint256 , uint256 ) view returns(address contributor, string text, uint256 usdcAmount, uint256 timestamp)
+func (_AASharing *AASharingCaller) PartnershipGratitude(opts *bind.CallOpts, arg0 *big.Int, arg1 *big.Int) (struct {
+	Contributor common.Address
+	Text        string
+	UsdcAmount  *big.Int
+	Timestamp   *big.Int
+}, error) {
+	var out []interface{}
+	err := _AASharing.contract.Call(opts, &out, "partnershipGratitude", arg0, arg1)
+
+	outstruct := new(struct {
+		Contributor common.Address
+		Text        string
+		UsdcAmount  *big.Int
+		Timestamp   *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Contributor = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	outstruct.Text = *abi.ConvertType(out[1], new(string)).(*string)
+	outstruct.UsdcAmount = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+	outstruct.Timestamp = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// PartnershipGratitude is a free data retrieval call binding the contract method 0x9a069a28.
+//
+// Solidity: function partnershipGratitude(uint256 , uint256 ) view returns(address contributor, string text, uint256 usdcAmount, uint256 timestamp)
+func (_AASharing *AASharingSession) PartnershipGratitude(arg0 *big.Int, arg1 *big.Int) (struct {
+	Contributor common.Address
+	Text        string
+	UsdcAmount  *big.Int
+	Timestamp   *big.Int
+}, error) {
+	return _AASharing.Contract.PartnershipGratitude(&_AASharing.CallOpts, arg0, arg1)
+}
+
+// PartnershipGratitude is a free data retrieval call binding the contract method 0x9a069a28.
+//
+// Solidity: function partnershipGratitude(uint256 , uint256 ) view returns(address contributor, string text, uint256 usdcAmount, uint256 timestamp)
+func (_AASharing *AASharingCallerSession) PartnershipGratitude(arg0 *big.Int, arg1 *big.Int) (struct {
+	Contributor common.Address
+	Text        string
+	UsdcAmount  *big.Int
+	Timestamp   *big.Int
+}, error) {
+	return _AASharing.Contract.PartnershipGratitude(&_AASharing.CallOpts, arg0, arg1)
+}
+
+// Partnerships is a free data retrieval call binding the contract method 0x44f018eb.
+//
+// Solidity: function partnerships(uint256 ) view returns(address partner1, address partner2, string nickname1, string nickname2, uint256 totalBalance, uint256 createdAt, bool isActive, uint256 goalCount)
+func (_AASharing *AASharingCaller) Partnerships(opts *bind.CallOpts, arg0 *big.Int) (struct {
+	Partner1     common.Address
+	Partner2     common.Address
+	Nickname1    string
+	Nickname2    string
+	TotalBalance *big.Int
+	CreatedAt    *big.Int
+	IsActive     bool
+	GoalCount    *big.Int
+}, error) {
+	var out []interface{}
+	err := _AASharing.contract.Call(opts, &out, "partnerships", arg0)
+
+	outstruct := new(struct {
+		Partner1     common.Address
+		Partner2     common.Address
+		Nickname1    string
+		Nickname2    string
+		TotalBalance *big.Int
+		CreatedAt    *big.Int
+		IsActive     bool
+		GoalCount    *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Partner1 = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	outstruct.Partner2 = *abi.ConvertType(out[1], new(common.Address)).(*common.Address)
+	outstruct.Nickname1 = *abi.ConvertType(out[2], new(string)).(*string)
+	outstruct.Nickname2 = *abi.ConvertType(out[3], new(string)).(*string)
+	outstruct.TotalBalance = *abi.ConvertType(out[4], new(*big.Int)).(**big.Int)
+	outstruct.CreatedAt = *abi.ConvertType(out[5], new(*big.Int)).(**big.Int)
+	outstruct.IsActive = *abi.ConvertType(out[6], new(bool)).(*bool)
+	outstruct.GoalCount = *abi.ConvertType(out[7], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// Partnerships is a free data retrieval call binding the contract method 0x44f018eb.
+//
+// Solidity: function partnerships(uint256 ) view returns(address partner1, address partner2, string nickname1, string nickname2, uint256 totalBalance, uint256 createdAt, bool isActive, uint256 goalCount)
+func (_AASharing *AASharingSession) Partnerships(arg0 *big.Int) (struct {
+	Partner1     common.Address
+	Partner2     common.Address
+	Nickname1    string
+	Nickname2    string
+	TotalBalance *big.Int
+	CreatedAt    *big.Int
+	IsActive     bool
+	GoalCount    *big.Int
+}, error) {
+	return _AASharing.Contract.Partnerships(&_AASharing.CallOpts, arg0)
+}
+
+// Partnerships is a free data retrieval call binding the contract method 0x44f018eb.
+//
+// Solidity: function partnerships(uint256 ) view returns(address partner1, address partner2, string nickname1, string nickname2, uint256 totalBalance, uint256 createdAt, bool isActive, uint256 goalCount)
+func (_AASharing *AASharingCallerSession) Partnerships(arg0 *big.Int) (struct {
+	Partner1     common.Address
+	Partner2     common.Address
+	Nickname1    string
+	Nickname2    string
+	TotalBalance *big.Int
+	CreatedAt    *big.Int
+	IsActive     bool
+	GoalCount    *big.Int
+}, error) {
+	return _AASharing.Contract.Partnerships(&_AASharing.CallOpts, arg0)
+}
+
+// Paused is a free data retrieval call binding the contract method 0x5c975abb.
+//
+// Solidity: function paused() view returns(bool)
+func (_AASharing *AASharingCaller) Paused(opts *bind.CallOpts) (bool, error) {
+	var out []interface{}
+	err := _AASharing.contract.Call(opts, &out, "paused")
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// Paused is a free data retrieval call binding the contract method 0x5c975abb.
+//
+// Solidity: function paused() view returns(bool)
+func (_AASharing *AASharingSession) Paused() (bool, error) {
+	return _AASharing.Contract.Paused(&_AASharing.CallOpts)
+}
+
+// Paused is a free data retrieval call binding the contract method 0x5c975abb.
+//
+// Solidity: function paused() view returns(bool)
+func (_AASharing *AASharingCallerSession) Paused() (bool, error) {
+	return _AASharing.Contract.Paused(&_AASharing.CallOpts)
+}
+
+// TotalGratitudeEntries is a free data retrieval call binding the contract method 0x4758ad43.
+//
+// Solidity: function totalGratitudeEntries() view returns(uint256)
+func (_AASharing *AASharingCaller) TotalGratitudeEntries(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _AASharing.contract.Call(opts, &out, "totalGratitudeEntries")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TotalGratitudeEntries is a free data retrieval call binding the contract method 0x4758ad43.
+//
+// Solidity: function totalGratitudeEntries() view returns(uint256)
+func (_AASharing *AASharingSession) TotalGratitudeEntries() (*big.Int, error) {
+	return _AASharing.Contract.TotalGratitudeEntries(&_AASharing.CallOpts)
+}
+
+// TotalGratitudeEntries is a free data retrieval call binding the contract method 0x4758ad43.
+//
+// Solidity: function totalGratitudeEntries() view returns(uint256)
+func (_AASharing *AASharingCallerSession) TotalGratitudeEntries() (*big.Int, error) {
+	return _AASharing.Contract.TotalGratitudeEntries(&_AASharing.CallOpts)
+}
+
+// TotalPartnerships is a free data retrieval call binding the contract method 0x6a7c92b7.
+//
+// Solidity: function totalPartnerships() view returns(uint256)
+func (_AASharing *AASharingCaller) TotalPartnerships(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _AASharing.contract.Call(opts, &out, "totalPartnerships")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TotalPartnerships is a free data retrieval call binding the contract method 0x6a7c92b7.
+//
+// Solidity: function totalPartnerships() view returns(uint256)
+func (_AASharing *AASharingSession) TotalPartnerships() (*big.Int, error) {
+	return _AASharing.Contract.TotalPartnerships(&_AASharing.CallOpts)
+}
+
+// TotalPartnerships is a free data retrieval call binding the contract method 0x6a7c92b7.
+//
+// Solidity: function totalPartnerships() view returns(uint256)
+func (_AASharing *AASharingCallerSession) TotalPartnerships() (*big.Int, error) {
+	return _AASharing.Contract.TotalPartnerships(&_AASharing.CallOpts)
+}
+
+// TotalUSDCLocked is a free data retrieval call binding the contract method 0xa1420cb0.
+//
+// Solidity: function totalUSDCLocked() view returns(uint256)
+func (_AASharing *AASharingCaller) TotalUSDCLocked(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _AASharing.contract.Call(opts, &out, "totalUSDCLocked")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TotalUSDCLocked is a free data retrieval call binding the contract method 0xa1420cb0.
+//
+// Solidity: function totalUSDCLocked() view returns(uint256)
+func (_AASharing *AASharingSession) TotalUSDCLocked() (*big.Int, error) {
+	return _AASharing.Contract.TotalUSDCLocked(&_AASharing.CallOpts)
+}
+
+// TotalUSDCLocked is a free data retrieval call binding the contract method 0xa1420cb0.
+//
+// Solidity: function totalUSDCLocked() view returns(uint256)
+func (_AASharing *AASharingCallerSession) TotalUSDCLocked() (*big.Int, error) {
+	return _AASharing.Contract.TotalUSDCLocked(&_AASharing.CallOpts)
+}
+
+// Usdc is a free data retrieval call binding the contract method 0x3e413bee.
+//
+// Solidity: function usdc() view returns(address)
+func (_AASharing *AASharingCaller) Usdc(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _AASharing.contract.Call(opts, &out, "usdc")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Usdc is a free data retrieval call binding the contract method 0x3e413bee.
+//
+// Solidity: function usdc() view returns(address)
+func (_AASharing *AASharingSession) Usdc() (common.Address, error) {
+	return _AASharing.Contract.Usdc(&_AASharing.CallOpts)
+}
+
+// Usdc is a free data retrieval call binding the contract method 0x3e413bee.
+//
+// Solidity: function usdc() view returns(address)
+func (_AASharing *AASharingCallerSession) Usdc() (common.Address, error) {
+	return _AASharing.Contract.Usdc(&_AASharing.CallOpts)
+}
+
+// UsedNonces is a free data retrieval call binding the contract method 0xfeb61724.
+//
+// Solidity: function usedNonces(bytes32 ) view returns(bool)
+func (_AASharing *AASharingCaller) UsedNonces(opts *bind.CallOpts, arg0 [32]byte) (bool, error) {
+	var out []interface{}
+	err := _AASharing.contract.Call(opts, &out, "usedNonces", arg0)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// UsedNonces is a free data retrieval call binding the contract method 0xfeb61724.
+//
+// Solidity: function usedNonces(bytes32 ) view returns(bool)
+func (_AASharing *AASharingSession) UsedNonces(arg0 [32]byte) (bool, error) {
+	return _AASharing.Contract.UsedNonces(&_AASharing.CallOpts, arg0)
+}
+
+// UsedNonces is a free data retrieval call binding the contract method 0xfeb61724.
+//
+// Solidity: function usedNonces(bytes32 ) view returns(bool)
+func (_AASharing *AASharingCallerSession) UsedNonces(arg0 [32]byte) (bool, error) {
+	return _AASharing.Contract.UsedNonces(&_AASharing.CallOpts, arg0)
+}
+
+// UserPartnerships is a free data retrieval call binding the contract method 0x1ebcbd59.
+//
+// Solidity: function userPartnerships(address , uint256 ) view returns(uint256)
+func (_AASharing *AASharingCaller) UserPartnerships(opts *bind.CallOpts, arg0 common.Address, arg1 *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _AASharing.contract.Call(opts, &out, "userPartnerships", arg0, arg1)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// UserPartnerships is a free data retrieval call binding the contract method 0x1ebcbd59.
+//
+// Solidity: function userPartnerships(address , uint256 ) view returns(uint256)
+func (_AASharing *AASharingSession) UserPartnerships(arg0 common.Address, arg1 *big.Int) (*big.Int, error) {
+	return _AASharing.Contract.UserPartnerships(&_AASharing.CallOpts, arg0, arg1)
+}
+
+// UserPartnerships is a free data retrieval call binding the contract method 0x1ebcbd59.
+//
+// Solidity: function userPartnerships(address , uint256 ) view returns(uint256)
+func (_AASharing *AASharingCallerSession) UserPartnerships(arg0 common.Address, arg1 *big.Int) (*big.Int, error) {
+	return _AASharing.Contract.UserPartnerships(&_AASharing.CallOpts, arg0, arg1)
+}
+
+// AddGratitude is a paid mutator transaction binding the contract method 0x2f85dc6d.
+//
+// Solidity: function addGratitude(uint256 partnershipId, string gratitudeText, uint256 usdcAmount) returns()
+func (_AASharing *AASharingTransactor) AddGratitude(opts *bind.TransactOpts, partnershipId *big.Int, gratitudeText string, usdcAmount *big.Int) (*types.Transaction, error) {
+	return _AASharing.contract.Transact(opts, "addGratitude", partnershipId, gratitudeText, usdcAmount)
+}
+
+// AddGratitude is a paid mutator transaction binding the contract method 0x2f85dc6d.
+//
+// Solidity: function addGratitude(uint256 partnershipId, string gratitudeText, uint256 usdcAmount) returns()
+func (_AASharing *AASharingSession) AddGratitude(partnershipId *big.Int, gratitudeText string, usdcAmount *big.Int) (*types.Transaction, error) {
+	return _AASharing.Contract.AddGratitude(&_AASharing.TransactOpts, partnershipId, gratitudeText, usdcAmount)
+}
+
+// AddGratitude is a paid mutator transaction binding the contract method 0x2f85dc6d.
+//
+// Solidity: function addGratitude(uint256 partnershipId, string gratitudeText, uint256 usdcAmount) returns()
+func (_AASharing *AASharingTransactorSession) AddGratitude(partnershipId *big.Int, gratitudeText string, usdcAmount *big.Int) (*types.Transaction, error) {
+	return _AASharing.Contract.AddGratitude(&_AASharing.TransactOpts, partnershipId, gratitudeText, usdcAmount)
+}
+
+// CreateGoal is a paid mutator transaction binding the contract method 0x9376361a.
+//
+// Solidity: function createGoal(uint256 partnershipId, string goalName, string description, uint256 targetAmount) returns()
+func (_AASharing *AASharingTransactor) CreateGoal(opts *bind.TransactOpts, partnershipId *big.Int, goalName string, description string, targetAmount *big.Int) (*types.Transaction, error) {
+	return _AASharing.contract.Transact(opts, "createGoal", partnershipId, goalName, description, targetAmount)
+}
+
+// CreateGoal is a paid mutator transaction binding the contract method 0x9376361a.
+//
+// Solidity: function createGoal(uint256 partnershipId, string goalName, string description, uint256 targetAmount) returns()
+func (_AASharing *AASharingSession) CreateGoal(partnershipId *big.Int, goalName string, description string, targetAmount *big.Int) (*types.Transaction, error) {
+	return _AASharing.Contract.CreateGoal(&_AASharing.TransactOpts, partnershipId, goalName, description, targetAmount)
+}
+
+// CreateGoal is a paid mutator transaction binding the contract method 0x9376361a.
+//
+// Solidity: function createGoal(uint256 partnershipId, string goalName, string description, uint256 targetAmount) returns()
+func (_AASharing *AASharingTransactorSession) CreateGoal(partnershipId *big.Int, goalName string, description string, targetAmount *big.Int) (*types.Transaction, error) {
+	return _AASharing.Contract.CreateGoal(&_AASharing.TransactOpts, partnershipId, goalName, description, targetAmount)
+}
+
+// CreatePartnership is a paid mutator transaction binding the contract method 0xa9e1531a.
+//
+// Solidity: function createPartnership(address partner2, string nickname1, string nickname2) returns(uint256 partnershipId)
+func (_AASharing *AASharingTransactor) CreatePartnership(opts *bind.TransactOpts, partner2 common.Address, nickname1 string, nickname2 string) (*types.Transaction, error) {
+	return _AASharing.contract.Transact(opts, "createPartnership", partner2, nickname1, nickname2)
+}
+
+// CreatePartnership is a paid mutator transaction binding the contract method 0xa9e1531a.
+//
+// Solidity: function createPartnership(address partner2, string nickname1, string nickname2) returns(uint256 partnershipId)
+func (_AASharing *AASharingSession) CreatePartnership(partner2 common.Address, nickname1 string, nickname2 string) (*types.Transaction, error) {
+	return _AASharing.Contract.CreatePartnership(&_AASharing.TransactOpts, partner2, nickname1, nickname2)
+}
+
+// CreatePartnership is a paid mutator transaction binding the contract method 0xa9e1531a.
+//
+// Solidity: function createPartnership(address partner2, string nickname1, string nickname2) returns(uint256 partnershipId)
+func (_AASharing *AASharingTransactorSession) CreatePartnership(partner2 common.Address, nickname1 string, nickname2 string) (*types.Transaction, error) {
+	return _AASharing.Contract.CreatePartnership(&_AASharing.TransactOpts, partner2, nickname1, nickname2)
+}
+
+// CrossChainDeposit is a paid mutator transaction binding the contract method 0xe04325e5.
+//
+// Solidity: function crossChainDeposit(uint256 partnershipId, uint256 amount, bytes32 nonce, bytes signature) returns()
+func (_AASharing *AASharingTransactor) CrossChainDeposit(opts *bind.TransactOpts, partnershipId *big.Int, amount *big.Int, nonce [32]byte, signature []byte) (*types.Transaction, error) {
+	return _AASharing.contract.Transact(opts, "crossChainDeposit", partnershipId, amount, nonce, signature)
+}
+
+// CrossChainDeposit is a paid mutator transaction binding the contract method 0xe04325e5.
+//
+// Solidity: function crossChainDeposit(uint256 partnershipId, uint256 amount, bytes32 nonce, bytes signature) returns()
+func (_AASharing *AASharingSession) CrossChainDeposit(partnershipId *big.Int, amount *big.Int, nonce [32]byte, signature []byte) (*types.Transaction, error) {
+	return _AASharing.Contract.CrossChainDeposit(&_AASharing.TransactOpts, partnershipId, amount, nonce, signature)
+}
+
+// CrossChainDeposit is a paid mutator transaction binding the contract method 0xe04325e5.
+//
+// Solidity: function crossChainDeposit(uint256 partnershipId, uint256 amount, bytes32 nonce, bytes signature) returns()
+func (_AASharing *AASharingTransactorSession) CrossChainDeposit(partnershipId *big.Int, amount *big.Int, nonce [32]byte, signature []byte) (*types.Transaction, error) {
+	return _AASharing.Contract.CrossChainDeposit(&_AASharing.TransactOpts, partnershipId, amount, nonce, signature)
+}
+
+// DepositFunds is a paid mutator transaction binding the contract method 0x61638ed5.
+//
+// Solidity: function depositFunds(uint256 partnershipId, uint256 amount) returns()
+func (_AASharing *AASharingTransactor) DepositFunds(opts *bind.TransactOpts, partnershipId *big.Int, amount *big.Int) (*types.Transaction, error) {
+	return _AASharing.contract.Transact(opts, "depositFunds", partnershipId, amount)
+}
+
+// DepositFunds is a paid mutator transaction binding the contract method 0x61638ed5.
+//
+// Solidity: function depositFunds(uint256 partnershipId, uint256 amount) returns()
+func (_AASharing *AASharingSession) DepositFunds(partnershipId *big.Int, amount *big.Int) (*types.Transaction, error) {
+	return _AASharing.Contract.DepositFunds(&_AASharing.TransactOpts, partnershipId, amount)
+}
+
+// DepositFunds is a paid mutator transaction binding the contract method 0x61638ed5.
+//
+// Solidity: function depositFunds(uint256 partnershipId, uint256 amount) returns()
+func (_AASharing *AASharingTransactorSession) DepositFunds(partnershipId *big.Int, amount *big.Int) (*types.Transaction, error) {
+	return _AASharing.Contract.DepositFunds(&_AASharing.TransactOpts, partnershipId, amount)
+}
+
+// DepositFundsWithSignature is a paid mutator transaction binding the contract method 0xe2f9fd7a.
+//
+// Solidity: function depositFundsWithSignature(uint256 partnershipId, address contributor, uint256 amount, uint256 nonce, uint256 deadline, bytes signature, uint8 permitV, bytes32 permitR, bytes32 permitS) returns()
+func (_AASharing *AASharingTransactor) DepositFundsWithSignature(opts *bind.TransactOpts, partnershipId *big.Int, contributor common.Address, amount *big.Int, nonce *big.Int, deadline *big.Int, signature []byte, permitV uint8, permitR [32]byte, permitS [32]byte) (*types.Transaction, error) {
+	return _AASharing.contract.Transact(opts, "depositFundsWithSignature", partnershipId, contributor, amount, nonce, deadline, signature, permitV, permitR, permitS)
+}
+
+// DepositFundsWithSignature is a paid mutator transaction binding the contract method 0xe2f9fd7a.
+//
+// Solidity: function depositFundsWithSignature(uint256 partnershipId, address contributor, uint256 amount, uint256 nonce, uint256 deadline, bytes signature, uint8 permitV, bytes32 permitR, bytes32 permitS) returns()
+func (_AASharing *AASharingSession) DepositFundsWithSignature(partnershipId *big.Int, contributor common.Address, amount *big.Int, nonce *big.Int, deadline *big.Int, signature []byte, permitV uint8, permitR [32]byte, permitS [32]byte) (*types.Transaction, error) {
+	return _AASharing.Contract.DepositFundsWithSignature(&_AASharing.TransactOpts, partnershipId, contributor, amount, nonce, deadline, signature, permitV, permitR, permitS)
+}
+
+// DepositFundsWithSignature is a paid mutator transaction binding the contract method 0xe2f9fd7a.
+//
+// Solidity: function depositFundsWithSignature(uint256 partnershipId, address contributor, uint256 amount, uint256 nonce, uint256 deadline, bytes signature, uint8 permitV, bytes32 permitR, bytes32 permitS) returns()
+func (_AASharing *AASharingTransactorSession) DepositFundsWithSignature(partnershipId *big.Int, contributor common.Address, amount *big.Int, nonce *big.Int, deadline *big.Int, signature []byte, permitV uint8, permitR [32]byte, permitS [32]byte) (*types.Transaction, error) {
+	return _AASharing.Contract.DepositFundsWithSignature(&_AASharing.TransactOpts, partnershipId, contributor, amount, nonce, deadline, signature, permitV, permitR, permitS)
+}
+
+// EmergencyWithdraw is a paid mutator transaction binding the contract method 0x95ccea67.
+//
+// Solidity: function emergencyWithdraw(address token, uint256 amount) returns()
+func (_AASharing *AASharingTransactor) EmergencyWithdraw(opts *bind.TransactOpts, token common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _AASharing.contract.Transact(opts, "emergencyWithdraw", token, amount)
+}
+
+// EmergencyWithdraw is a paid mutator transaction binding the contract method 0x95ccea67.
+//
+// Solidity: function emergencyWithdraw(address token, uint256 amount) returns()
+func (_AASharing *AASharingSession) EmergencyWithdraw(token common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _AASharing.Contract.EmergencyWithdraw(&_AASharing.TransactOpts, token, amount)
+}
+
+// EmergencyWithdraw is a paid mutator transaction binding the contract method 0x95ccea67.
+//
+// Solidity: function emergencyWithdraw(address token, uint256 amount) returns()
+func (_AASharing *AASharingTransactorSession) EmergencyWithdraw(token common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _AASharing.Contract.EmergencyWithdraw(&_AASharing.TransactOpts, token, amount)
+}
+
+// Pause is a paid mutator transaction binding the contract method 0x8456cb59.
+//
+// Solidity: function pause() returns()
+func (_AASharing *AASharingTransactor) Pause(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _AASharing.contract.Transact(opts, "pause")
+}
+
+// Pause is a paid mutator transaction binding the contract method 0x8456cb59.
+//
+// Solidity: function pause() returns()
+func (_AASharing *AASharingSession) Pause() (*types.Transaction, error) {
+	return _AASharing.Contract.Pause(&_AASharing.TransactOpts)
+}
+
+// Pause is a paid mutator transaction binding the contract method 0x8456cb59.
+//
+// Solidity: function pause() returns()
+func (_AASharing *AASharingTransactorSession) Pause() (*types.Transaction, error) {
+	return _AASharing.Contract.Pause(&_AASharing.TransactOpts)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_AASharing *AASharingTransactor) RenounceOwnership(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _AASharing.contract.Transact(opts, "renounceOwnership")
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_AASharing *AASharingSession) RenounceOwnership() (*types.Transaction, error) {
+	return _AASharing.Contract.RenounceOwnership(&_AASharing.TransactOpts)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_AASharing *AASharingTransactorSession) RenounceOwnership() (*types.Transaction, error) {
+	return _AASharing.Contract.RenounceOwnership(&_AASharing.TransactOpts)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_AASharing *AASharingTransactor) TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*types.Transaction, error) {
+	return _AASharing.contract.Transact(opts, "transferOwnership", newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_AASharing *AASharingSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _AASharing.Contract.TransferOwnership(&_AASharing.TransactOpts, newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_AASharing *AASharingTransactorSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _AASharing.Contract.TransferOwnership(&_AASharing.TransactOpts, newOwner)
+}
+
+// Unpause is a paid mutator transaction binding the contract method 0x3f4ba83a.
+//
+// Solidity: function unpause() returns()
+func (_AASharing *AASharingTransactor) Unpause(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _AASharing.contract.Transact(opts, "unpause")
+}
+
+// Unpause is a paid mutator transaction binding the contract method 0x3f4ba83a.
+//
+// Solidity: function unpause() returns()
+func (_AASharing *AASharingSession) Unpause() (*types.Transaction, error) {
+	return _AASharing.Contract.Unpause(&_AASharing.TransactOpts)
+}
+
+// Unpause is a paid mutator transaction binding the contract method 0x3f4ba83a.
+//
+// Solidity: function unpause() returns()
+func (_AASharing *AASharingTransactorSession) Unpause() (*types.Transaction, error) {
+	return _AASharing.Contract.Unpause(&_AASharing.TransactOpts)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x2e1a7d4d.
+//
+// Solidity: function withdraw(uint256 partnershipId) returns()
+func (_AASharing *AASharingTransactor) Withdraw(opts *bind.TransactOpts, partnershipId *big.Int) (*types.Transaction, error) {
+	return _AASharing.contract.Transact(opts, "withdraw", partnershipId)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x2e1a7d4d.
+//
+// Solidity: function withdraw(uint256 partnershipId) returns()
+func (_AASharing *AASharingSession) Withdraw(partnershipId *big.Int) (*types.Transaction, error) {
+	return _AASharing.Contract.Withdraw(&_AASharing.TransactOpts, partnershipId)
+}
+
+// Withdraw is a paid mutator transaction binding the contract method 0x2e1a7d4d.
+//
+// Solidity: function withdraw(uint256 partnershipId) returns()
+func (_AASharing *AASharingTransactorSession) Withdraw(partnershipId *big.Int) (*types.Transaction, error) {
+	return _AASharing.Contract.Withdraw(&_AASharing.TransactOpts, partnershipId)
+}
+
+// AASharingEIP712DomainChangedIterator is returned from FilterEIP712DomainChanged and is used to iterate over the raw logs and unpacked data for EIP712DomainChanged events raised by the AASharing contract.
+type AASharingEIP712DomainChangedIterator struct {
+	Event *AASharingEIP712DomainChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AASharingEIP712DomainChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AASharingEIP712DomainChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AASharingEIP712DomainChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AASharingEIP712DomainChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AASharingEIP712DomainChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AASharingEIP712DomainChanged represents a EIP712DomainChanged event raised by the AASharing contract.
+type AASharingEIP712DomainChanged struct {
+	Raw types.Log // Blockchain specific contextual infos
+}
+
+// FilterEIP712DomainChanged is a free log retrieval operation binding the contract event 0x0a6387c9ea3628b88a633bb4f3b151770f70085117a15f9bf3787cda53f13d31.
+//
+// Solidity: event EIP712DomainChanged()
+func (_AASharing *AASharingFilterer) FilterEIP712DomainChanged(opts *bind.FilterOpts) (*AASharingEIP712DomainChangedIterator, error) {
+
+	logs, sub, err := _AASharing.contract.FilterLogs(opts, "EIP712DomainChanged")
+	if err != nil {
+		return nil, err
+	}
+	return &AASharingEIP712DomainChangedIterator{contract: _AASharing.contract, event: "EIP712DomainChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchEIP712DomainChanged is a free log subscription operation binding the contract event 0x0a6387c9ea3628b88a633bb4f3b151770f70085117a15f9bf3787cda53f13d31.
+//
+// Solidity: event EIP712DomainChanged()
+func (_AASharing *AASharingFilterer) WatchEIP712DomainChanged(opts *bind.WatchOpts, sink chan<- *AASharingEIP712DomainChanged) (event.Subscription, error) {
+
+	logs, sub, err := _AASharing.contract.WatchLogs(opts, "EIP712DomainChanged")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AASharingEIP712DomainChanged)
+				if err := _AASharing.contract.UnpackLog(event, "EIP712DomainChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseEIP712DomainChanged is a log parse operation binding the contract event 0x0a6387c9ea3628b88a633bb4f3b151770f70085117a15f9bf3787cda53f13d31.
+//
+// Solidity: event EIP712DomainChanged()
+func (_AASharing *AASharingFilterer) ParseEIP712DomainChanged(log types.Log) (*AASharingEIP712DomainChanged, error) {
+	event := new(AASharingEIP712DomainChanged)
+	if err := _AASharing.contract.UnpackLog(event, "EIP712DomainChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// AASharingFundsDepositedIterator is returned from FilterFundsDeposited and is used to iterate over the raw logs and unpacked data for FundsDeposited events raised by the AASharing contract.
+type AASharingFundsDepositedIterator struct {
+	Event *AASharingFundsDeposited // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AASharingFundsDepositedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AASharingFundsDeposited)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AASharingFundsDeposited)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AASharingFundsDepositedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AASharingFundsDepositedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AASharingFundsDeposited represents a FundsDeposited event raised by the AASharing contract.
+type AASharingFundsDeposited struct {
+	PartnershipId *big.Int
+	Depositor     common.Address
+	Amount        *big.Int
+	Source        string
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterFundsDeposited is a free log retrieval operation binding the contract event 0xb9b32029c823f299153fca3b251cebede300203716cdb7a7eca5a25db24cd8c6.
+//
+// Solidity: event FundsDeposited(uint256 indexed partnershipId, address indexed depositor, uint256 amount, string source)
+func (_AASharing *AASharingFilterer) FilterFundsDeposited(opts *bind.FilterOpts, partnershipId []*big.Int, depositor []common.Address) (*AASharingFundsDepositedIterator, error) {
+
+	var partnershipIdRule []interface{}
+	for _, partnershipIdItem := range partnershipId {
+		partnershipIdRule = append(partnershipIdRule, partnershipIdItem)
+	}
+	var depositorRule []interface{}
+	for _, depositorItem := range depositor {
+		depositorRule = append(depositorRule, depositorItem)
+	}
+
+	logs, sub, err := _AASharing.contract.FilterLogs(opts, "FundsDeposited", partnershipIdRule, depositorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &AASharingFundsDepositedIterator{contract: _AASharing.contract, event: "FundsDeposited", logs: logs, sub: sub}, nil
+}
+
+// WatchFundsDeposited is a free log subscription operation binding the contract event 0xb9b32029c823f299153fca3b251cebede300203716cdb7a7eca5a25db24cd8c6.
+//
+// Solidity: event FundsDeposited(uint256 indexed partnershipId, address indexed depositor, uint256 amount, string source)
+func (_AASharing *AASharingFilterer) WatchFundsDeposited(opts *bind.WatchOpts, sink chan<- *AASharingFundsDeposited, partnershipId []*big.Int, depositor []common.Address) (event.Subscription, error) {
+
+	var partnershipIdRule []interface{}
+	for _, partnershipIdItem := range partnershipId {
+		partnershipIdRule = append(partnershipIdRule, partnershipIdItem)
+	}
+	var depositorRule []interface{}
+	for _, depositorItem := range depositor {
+		depositorRule = append(depositorRule, depositorItem)
+	}
+
+	logs, sub, err := _AASharing.contract.WatchLogs(opts, "FundsDeposited", partnershipIdRule, depositorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AASharingFundsDeposited)
+				if err := _AASharing.contract.UnpackLog(event, "FundsDeposited", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseFundsDeposited is a log parse operation binding the contract event 0xb9b32029c823f299153fca3b251cebede300203716cdb7a7eca5a25db24cd8c6.
+//
+// Solidity: event FundsDeposited(uint256 indexed partnershipId, address indexed depositor, uint256 amount, string source)
+func (_AASharing *AASharingFilterer) ParseFundsDeposited(log types.Log) (*AASharingFundsDeposited, error) {
+	event := new(AASharingFundsDeposited)
+	if err := _AASharing.contract.UnpackLog(event, "FundsDeposited", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// AASharingFundsWithdrawnIterator is returned from FilterFundsWithdrawn and is used to iterate over the raw logs and unpacked data for FundsWithdrawn events raised by the AASharing contract.
+type AASharingFundsWithdrawnIterator struct {
+	Event *AASharingFundsWithdrawn // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AASharingFundsWithdrawnIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AASharingFundsWithdrawn)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AASharingFundsWithdrawn)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AASharingFundsWithdrawnIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AASharingFundsWithdrawnIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AASharingFundsWithdrawn represents a FundsWithdrawn event raised by the AASharing contract.
+type AASharingFundsWithdrawn struct {
+	PartnershipId *big.Int
+	Partner1      common.Address
+	Partner2      common.Address
+	AmountEach    *big.Int
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterFundsWithdrawn is a free log retrieval operation binding the contract event 0x9467881c16409d70790ef2aa073424972ede02854453f7ff5036d66e860ee42f.
+//
+// Solidity: event FundsWithdrawn(uint256 indexed partnershipId, address indexed partner1, address indexed partner2, uint256 amountEach)
+func (_AASharing *AASharingFilterer) FilterFundsWithdrawn(opts *bind.FilterOpts, partnershipId []*big.Int, partner1 []common.Address, partner2 []common.Address) (*AASharingFundsWithdrawnIterator, error) {
+
+	var partnershipIdRule []interface{}
+	for _, partnershipIdItem := range partnershipId {
+		partnershipIdRule = append(partnershipIdRule, partnershipIdItem)
+	}
+	var partner1Rule []interface{}
+	for _, partner1Item := range partner1 {
+		partner1Rule = append(partner1Rule, partner1Item)
+	}
+	var partner2Rule []interface{}
+	for _, partner2Item := range partner2 {
+		partner2Rule = append(partner2Rule, partner2Item)
+	}
+
+	logs, sub, err := _AASharing.contract.FilterLogs(opts, "FundsWithdrawn", partnershipIdRule, partner1Rule, partner2Rule)
+	if err != nil {
+		return nil, err
+	}
+	return &AASharingFundsWithdrawnIterator{contract: _AASharing.contract, event: "FundsWithdrawn", logs: logs, sub: sub}, nil
+}
+
+// WatchFundsWithdrawn is a free log subscription operation binding the contract event 0x9467881c16409d70790ef2aa073424972ede02854453f7ff5036d66e860ee42f.
+//
+// Solidity: event FundsWithdrawn(uint256 indexed partnershipId, address indexed partner1, address indexed partner2, uint256 amountEach)
+func (_AASharing *AASharingFilterer) WatchFundsWithdrawn(opts *bind.WatchOpts, sink chan<- *AASharingFundsWithdrawn, partnershipId []*big.Int, partner1 []common.Address, partner2 []common.Address) (event.Subscription, error) {
+
+	var partnershipIdRule []interface{}
+	for _, partnershipIdItem := range partnershipId {
+		partnershipIdRule = append(partnershipIdRule, partnershipIdItem)
+	}
+	var partner1Rule []interface{}
+	for _, partner1Item := range partner1 {
+		partner1Rule = append(partner1Rule, partner1Item)
+	}
+	var partner2Rule []interface{}
+	for _, partner2Item := range partner2 {
+		partner2Rule = append(partner2Rule, partner2Item)
+	}
+
+	logs, sub, err := _AASharing.contract.WatchLogs(opts, "FundsWithdrawn", partnershipIdRule, partner1Rule, partner2Rule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AASharingFundsWithdrawn)
+				if err := _AASharing.contract.UnpackLog(event, "FundsWithdrawn", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseFundsWithdrawn is a log parse operation binding the contract event 0x9467881c16409d70790ef2aa073424972ede02854453f7ff5036d66e860ee42f.
+//
+// Solidity: event FundsWithdrawn(uint256 indexed partnershipId, address indexed partner1, address indexed partner2, uint256 amountEach)
+func (_AASharing *AASharingFilterer) ParseFundsWithdrawn(log types.Log) (*AASharingFundsWithdrawn, error) {
+	event := new(AASharingFundsWithdrawn)
+	if err := _AASharing.contract.UnpackLog(event, "FundsWithdrawn", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// AASharingGoalCreatedIterator is returned from FilterGoalCreated and is used to iterate over the raw logs and unpacked data for GoalCreated events raised by the AASharing contract.
+type AASharingGoalCreatedIterator struct {
+	Event *AASharingGoalCreated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AASharingGoalCreatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AASharingGoalCreated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AASharingGoalCreated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AASharingGoalCreatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AASharingGoalCreatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AASharingGoalCreated represents a GoalCreated event raised by the AASharing contract.
+type AASharingGoalCreated struct {
+	PartnershipId *big.Int
+	GoalId        *big.Int
+	GoalName      string
+	TargetAmount  *big.Int
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterGoalCreated is a free log retrieval operation binding the contract event 0xe2f7a213f1a7c3751ab666565b2d993a33deb39f5ec451bd2e0a67a4de280f8e.
+//
+// Solidity: event GoalCreated(uint256 indexed partnershipId, uint256 indexed goalId, string goalName, uint256 targetAmount)
+func (_AASharing *AASharingFilterer) FilterGoalCreated(opts *bind.FilterOpts, partnershipId []*big.Int, goalId []*big.Int) (*AASharingGoalCreatedIterator, error) {
+
+	var partnershipIdRule []interface{}
+	for _, partnershipIdItem := range partnershipId {
+		partnershipIdRule = append(partnershipIdRule, partnershipIdItem)
+	}
+	var goalIdRule []interface{}
+	for _, goalIdItem := range goalId {
+		goalIdRule = append(goalIdRule, goalIdItem)
+	}
+
+	logs, sub, err := _AASharing.contract.FilterLogs(opts, "GoalCreated", partnershipIdRule, goalIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &AASharingGoalCreatedIterator{contract: _AASharing.contract, event: "GoalCreated", logs: logs, sub: sub}, nil
+}
+
+// WatchGoalCreated is a free log subscription operation binding the contract event 0xe2f7a213f1a7c3751ab666565b2d993a33deb39f5ec451bd2e0a67a4de280f8e.
+//
+// Solidity: event GoalCreated(uint256 indexed partnershipId, uint256 indexed goalId, string goalName, uint256 targetAmount)
+func (_AASharing *AASharingFilterer) WatchGoalCreated(opts *bind.WatchOpts, sink chan<- *AASharingGoalCreated, partnershipId []*big.Int, goalId []*big.Int) (event.Subscription, error) {
+
+	var partnershipIdRule []interface{}
+	for _, partnershipIdItem := range partnershipId {
+		partnershipIdRule = append(partnershipIdRule, partnershipIdItem)
+	}
+	var goalIdRule []interface{}
+	for _, goalIdItem := range goalId {
+		goalIdRule = append(goalIdRule, goalIdItem)
+	}
+
+	logs, sub, err := _AASharing.contract.WatchLogs(opts, "GoalCreated", partnershipIdRule, goalIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AASharingGoalCreated)
+				if err := _AASharing.contract.UnpackLog(event, "GoalCreated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseGoalCreated is a log parse operation binding the contract event 0xe2f7a213f1a7c3751ab666565b2d993a33deb39f5ec451bd2e0a67a4de280f8e.
+//
+// Solidity: event GoalCreated(uint256 indexed partnershipId, uint256 indexed goalId, string goalName, uint256 targetAmount)
+func (_AASharing *AASharingFilterer) ParseGoalCreated(log types.Log) (*AASharingGoalCreated, error) {
+	event := new(AASharingGoalCreated)
+	if err := _AASharing.contract.UnpackLog(event, "GoalCreated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// AASharingGratitudeAddedIterator is returned from FilterGratitudeAdded and is used to iterate over the raw logs and unpacked data for GratitudeAdded events raised by the AASharing contract.
+type AASharingGratitudeAddedIterator struct {
+	Event *AASharingGratitudeAdded // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AASharingGratitudeAddedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AASharingGratitudeAdded)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AASharingGratitudeAdded)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AASharingGratitudeAddedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AASharingGratitudeAddedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AASharingGratitudeAdded represents a GratitudeAdded event raised by the AASharing contract.
+type AASharingGratitudeAdded struct {
+	PartnershipId *big.Int
+	Contributor   common.Address
+	GratitudeText string
+	UsdcAmount    *big.Int
+	Timestamp     *big.Int
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterGratitudeAdded is a free log retrieval operation binding the contract event 0x3ec49515ffdd7a458cccbd42c5fa8de9c259a4ed925a59a6f5557b596807aade.
+//
+// Solidity: event GratitudeAdded(uint256 indexed partnershipId, address indexed contributor, string gratitudeText, uint256 usdcAmount, uint256 timestamp)
+func (_AASharing *AASharingFilterer) FilterGratitudeAdded(opts *bind.FilterOpts, partnershipId []*big.Int, contributor []common.Address) (*AASharingGratitudeAddedIterator, error) {
+
+	var partnershipIdRule []interface{}
+	for _, partnershipIdItem := range partnershipId {
+		partnershipIdRule = append(partnershipIdRule, partnershipIdItem)
+	}
+	var contributorRule []interface{}
+	for _, contributorItem := range contributor {
+		contributorRule = append(contributorRule, contributorItem)
+	}
+
+	logs, sub, err := _AASharing.contract.FilterLogs(opts, "GratitudeAdded", partnershipIdRule, contributorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &AASharingGratitudeAddedIterator{contract: _AASharing.contract, event: "GratitudeAdded", logs: logs, sub: sub}, nil
+}
+
+// WatchGratitudeAdded is a free log subscription operation binding the contract event 0x3ec49515ffdd7a458cccbd42c5fa8de9c259a4ed925a59a6f5557b596807aade.
+//
+// Solidity: event GratitudeAdded(uint256 indexed partnershipId, address indexed contributor, string gratitudeText, uint256 usdcAmount, uint256 timestamp)
+func (_AASharing *AASharingFilterer) WatchGratitudeAdded(opts *bind.WatchOpts, sink chan<- *AASharingGratitudeAdded, partnershipId []*big.Int, contributor []common.Address) (event.Subscription, error) {
+
+	var partnershipIdRule []interface{}
+	for _, partnershipIdItem := range partnershipId {
+		partnershipIdRule = append(partnershipIdRule, partnershipIdItem)
+	}
+	var contributorRule []interface{}
+	for _, contributorItem := range contributor {
+		contributorRule = append(contributorRule, contributorItem)
+	}
+
+	logs, sub, err := _AASharing.contract.WatchLogs(opts, "GratitudeAdded", partnershipIdRule, contributorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AASharingGratitudeAdded)
+				if err := _AASharing.contract.UnpackLog(event, "GratitudeAdded", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseGratitudeAdded is a log parse operation binding the contract event 0x3ec49515ffdd7a458cccbd42c5fa8de9c259a4ed925a59a6f5557b596807aade.
+//
+// Solidity: event GratitudeAdded(uint256 indexed partnershipId, address indexed contributor, string gratitudeText, uint256 usdcAmount, uint256 timestamp)
+func (_AASharing *AASharingFilterer) ParseGratitudeAdded(log types.Log) (*AASharingGratitudeAdded, error) {
+	event := new(AASharingGratitudeAdded)
+	if err := _AASharing.contract.UnpackLog(event, "GratitudeAdded", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// AASharingOwnershipTransferredIterator is returned from FilterOwnershipTransferred and is used to iterate over the raw logs and unpacked data for OwnershipTransferred events raised by the AASharing contract.
+type AASharingOwnershipTransferredIterator struct {
+	Event *AASharingOwnershipTransferred // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AASharingOwnershipTransferredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AASharingOwnershipTransferred)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AASharingOwnershipTransferred)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AASharingOwnershipTransferredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AASharingOwnershipTransferredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AASharingOwnershipTransferred represents a OwnershipTransferred event raised by the AASharing contract.
+type AASharingOwnershipTransferred struct {
+	PreviousOwner common.Address
+	NewOwner      common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterOwnershipTransferred is a free log retrieval operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_AASharing *AASharingFilterer) FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*AASharingOwnershipTransferredIterator, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _AASharing.contract.FilterLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &AASharingOwnershipTransferredIterator{contract: _AASharing.contract, event: "OwnershipTransferred", logs: logs, sub: sub}, nil
+}
+
+// WatchOwnershipTransferred is a free log subscription operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_AASharing *AASharingFilterer) WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *AASharingOwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _AASharing.contract.WatchLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AASharingOwnershipTransferred)
+				if err := _AASharing.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOwnershipTransferred is a log parse operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_AASharing *AASharingFilterer) ParseOwnershipTransferred(log types.Log) (*AASharingOwnershipTransferred, error) {
+	event := new(AASharingOwnershipTransferred)
+	if err := _AASharing.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// AASharingPartnershipCreatedIterator is returned from FilterPartnershipCreated and is used to iterate over the raw logs and unpacked data for PartnershipCreated events raised by the AASharing contract.
+type AASharingPartnershipCreatedIterator struct {
+	Event *AASharingPartnershipCreated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AASharingPartnershipCreatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AASharingPartnershipCreated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AASharingPartnershipCreated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AASharingPartnershipCreatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AASharingPartnershipCreatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AASharingPartnershipCreated represents a PartnershipCreated event raised by the AASharing contract.
+type AASharingPartnershipCreated struct {
+	PartnershipId *big.Int
+	Partner1      common.Address
+	Partner2      common.Address
+	Nickname1     string
+	Nickname2     string
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterPartnershipCreated is a free log retrieval operation binding the contract event 0xb55d130bf782a19e525d6b50bb92a58429c2b14da6c1f89f92465321b14ad3be.
+//
+// Solidity: event PartnershipCreated(uint256 indexed partnershipId, address indexed partner1, address indexed partner2, string nickname1, string nickname2)
+func (_AASharing *AASharingFilterer) FilterPartnershipCreated(opts *bind.FilterOpts, partnershipId []*big.Int, partner1 []common.Address, partner2 []common.Address) (*AASharingPartnershipCreatedIterator, error) {
+
+	var partnershipIdRule []interface{}
+	for _, partnershipIdItem := range partnershipId {
+		partnershipIdRule = append(partnershipIdRule, partnershipIdItem)
+	}
+	var partner1Rule []interface{}
+	for _, partner1Item := range partner1 {
+		partner1Rule = append(partner1Rule, partner1Item)
+	}
+	var partner2Rule []interface{}
+	for _, partner2Item := range partner2 {
+		partner2Rule = append(partner2Rule, partner2Item)
+	}
+
+	logs, sub, err := _AASharing.contract.FilterLogs(opts, "PartnershipCreated", partnershipIdRule, partner1Rule, partner2Rule)
+	if err != nil {
+		return nil, err
+	}
+	return &AASharingPartnershipCreatedIterator{contract: _AASharing.contract, event: "PartnershipCreated", logs: logs, sub: sub}, nil
+}
+
+// WatchPartnershipCreated is a free log subscription operation binding the contract event 0xb55d130bf782a19e525d6b50bb92a58429c2b14da6c1f89f92465321b14ad3be.
+//
+// Solidity: event PartnershipCreated(uint256 indexed partnershipId, address indexed partner1, address indexed partner2, string nickname1, string nickname2)
+func (_AASharing *AASharingFilterer) WatchPartnershipCreated(opts *bind.WatchOpts, sink chan<- *AASharingPartnershipCreated, partnershipId []*big.Int, partner1 []common.Address, partner2 []common.Address) (event.Subscription, error) {
+
+	var partnershipIdRule []interface{}
+	for _, partnershipIdItem := range partnershipId {
+		partnershipIdRule = append(partnershipIdRule, partnershipIdItem)
+	}
+	var partner1Rule []interface{}
+	for _, partner1Item := range partner1 {
+		partner1Rule = append(partner1Rule, partner1Item)
+	}
+	var partner2Rule []interface{}
+	for _, partner2Item := range partner2 {
+		partner2Rule = append(partner2Rule, partner2Item)
+	}
+
+	logs, sub, err := _AASharing.contract.WatchLogs(opts, "PartnershipCreated", partnershipIdRule, partner1Rule, partner2Rule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AASharingPartnershipCreated)
+				if err := _AASharing.contract.UnpackLog(event, "PartnershipCreated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePartnershipCreated is a log parse operation binding the contract event 0xb55d130bf782a19e525d6b50bb92a58429c2b14da6c1f89f92465321b14ad3be.
+//
+// Solidity: event PartnershipCreated(uint256 indexed partnershipId, address indexed partner1, address indexed partner2, string nickname1, string nickname2)
+func (_AASharing *AASharingFilterer) ParsePartnershipCreated(log types.Log) (*AASharingPartnershipCreated, error) {
+	event := new(AASharingPartnershipCreated)
+	if err := _AASharing.contract.UnpackLog(event, "PartnershipCreated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// AASharingPausedIterator is returned from FilterPaused and is used to iterate over the raw logs and unpacked data for Paused events raised by the AASharing contract.
+type AASharingPausedIterator struct {
+	Event *AASharingPaused // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AASharingPausedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AASharingPaused)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AASharingPaused)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AASharingPausedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AASharingPausedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AASharingPaused represents a Paused event raised by the AASharing contract.
+type AASharingPaused struct {
+	Account common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterPaused is a free log retrieval operation binding the contract event 0x62e78cea01bee320cd4e420270b5ea74000d11b0c9f74754ebdbfc544b05a258.
+//
+// Solidity: event Paused(address account)
+func (_AASharing *AASharingFilterer) FilterPaused(opts *bind.FilterOpts) (*AASharingPausedIterator, error) {
+
+	logs, sub, err := _AASharing.contract.FilterLogs(opts, "Paused")
+	if err != nil {
+		return nil, err
+	}
+	return &AASharingPausedIterator{contract: _AASharing.contract, event: "Paused", logs: logs, sub: sub}, nil
+}
+
+// WatchPaused is a free log subscription operation binding the contract event 0x62e78cea01bee320cd4e420270b5ea74000d11b0c9f74754ebdbfc544b05a258.
+//
+// Solidity: event Paused(address account)
+func (_AASharing *AASharingFilterer) WatchPaused(opts *bind.WatchOpts, sink chan<- *AASharingPaused) (event.Subscription, error) {
+
+	logs, sub, err := _AASharing.contract.WatchLogs(opts, "Paused")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AASharingPaused)
+				if err := _AASharing.contract.UnpackLog(event, "Paused", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePaused is a log parse operation binding the contract event 0x62e78cea01bee320cd4e420270b5ea74000d11b0c9f74754ebdbfc544b05a258.
+//
+// Solidity: event Paused(address account)
+func (_AASharing *AASharingFilterer) ParsePaused(log types.Log) (*AASharingPaused, error) {
+	event := new(AASharingPaused)
+	if err := _AASharing.contract.UnpackLog(event, "Paused", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// AASharingUnpausedIterator is returned from FilterUnpaused and is used to iterate over the raw logs and unpacked data for Unpaused events raised by the AASharing contract.
+type AASharingUnpausedIterator struct {
+	Event *AASharingUnpaused // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AASharingUnpausedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AASharingUnpaused)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AASharingUnpaused)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AASharingUnpausedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AASharingUnpausedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AASharingUnpaused represents a Unpaused event raised by the AASharing contract.
+type AASharingUnpaused struct {
+	Account common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterUnpaused is a free log retrieval operation binding the contract event 0x5db9ee0a495bf2e6ff9c91a7834c1ba4fdd244a5e8aa4e537bd38aeae4b073aa.
+//
+// Solidity: event Unpaused(address account)
+func (_AASharing *AASharingFilterer) FilterUnpaused(opts *bind.FilterOpts) (*AASharingUnpausedIterator, error) {
+
+	logs, sub, err := _AASharing.contract.FilterLogs(opts, "Unpaused")
+	if err != nil {
+		return nil, err
+	}
+	return &AASharingUnpausedIterator{contract: _AASharing.contract, event: "Unpaused", logs: logs, sub: sub}, nil
+}
+
+// WatchUnpaused is a free log subscription operation binding the contract event 0x5db9ee0a495bf2e6ff9c91a7834c1ba4fdd244a5e8aa4e537bd38aeae4b073aa.
+//
+// Solidity: event Unpaused(address account)
+func (_AASharing *AASharingFilterer) WatchUnpaused(opts *bind.WatchOpts, sink chan<- *AASharingUnpaused) (event.Subscription, error) {
+
+	logs, sub, err := _AASharing.contract.WatchLogs(opts, "Unpaused")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AASharingUnpaused)
+				if err := _AASharing.contract.UnpackLog(event, "Unpaused", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseUnpaused is a log parse operation binding the contract event 0x5db9ee0a495bf2e6ff9c91a7834c1ba4fdd244a5e8aa4e537bd38aeae4b073aa.
+//
+// Solidity: event Unpaused(address account)
+func (_AASharing *AASharingFilterer) ParseUnpaused(log types.Log) (*AASharingUnpaused, error) {
+	event := new(AASharingUnpaused)
+	if err := _AASharing.contract.UnpackLog(event, "Unpaused", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
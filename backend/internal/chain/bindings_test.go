@@ -0,0 +1,110 @@
+package chain_test
+
+import (
+	"math/big"
+	"testing"
+
+	"aa-sharing-backend/internal/chain"
+	"aa-sharing-backend/internal/chaintest"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestAASharingBinding(t *testing.T) {
+	c := chaintest.New(t)
+	aa := c.DeployAASharing(t)
+	alice, bob := c.Account(t), c.Account(t)
+
+	id := aa.CreatePartnership(t, alice, bob)
+	aa.Fund(t, alice, 10_000_001)
+	c.Mined(t)(aa.DepositFunds(c.Transactor(t, alice), id, big.NewInt(10_000_001)))
+
+	partnership, err := aa.GetPartnership(&bind.CallOpts{}, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if partnership.Partner1 != crypto.PubkeyToAddress(alice.PublicKey) || partnership.Nickname2 != "Bob" ||
+		partnership.TotalBalance.Cmp(big.NewInt(10_000_001)) != 0 || !partnership.IsActive {
+		t.Errorf("partnership %+v", partnership)
+	}
+	nonce, err := aa.ContributionNonces(&bind.CallOpts{}, crypto.PubkeyToAddress(alice.PublicKey))
+	if err != nil || nonce.Sign() != 0 {
+		t.Errorf("contribution nonce %v (%v), want 0", nonce, err)
+	}
+
+	deposits, err := aa.FilterFundsDeposited(&bind.FilterOpts{}, []*big.Int{id}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer deposits.Close()
+	if !deposits.Next() {
+		t.Fatalf("no FundsDeposited event (%v)", deposits.Error())
+	}
+	if deposits.Event.Amount.Cmp(big.NewInt(10_000_001)) != 0 || deposits.Event.Source != "direct" {
+		t.Errorf("FundsDeposited %+v", deposits.Event)
+	}
+
+	receipt := c.Mined(t)(aa.Withdraw(c.Transactor(t, bob), id))
+	var withdrawn *chain.AASharingFundsWithdrawn
+	for _, l := range receipt.Logs {
+		if event, err := aa.ParseFundsWithdrawn(*l); err == nil {
+			withdrawn = event
+		}
+	}
+	if withdrawn == nil || withdrawn.AmountEach.Cmp(big.NewInt(5_000_000)) != 0 {
+		t.Errorf("FundsWithdrawn %+v, want 5000000 each", withdrawn)
+	}
+}
+
+func TestCrossChainBridgeBinding(t *testing.T) {
+	c := chaintest.New(t)
+	aa := c.DeployAASharing(t)
+	validator := crypto.PubkeyToAddress(c.Account(t).PublicKey)
+	address, _ := c.Deploy(t, "CrossChainBridge", aa.USDC, aa.Address, validator)
+	bridge, err := chain.NewCrossChainBridge(address, c.Client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.Mined(t)(bridge.SetBridgeFee(c.Transactor(t, c.Deployer), big.NewInt(30)))
+	fee, err := bridge.BridgeFee(&bind.CallOpts{})
+	if err != nil || fee.Int64() != 30 {
+		t.Fatalf("bridge fee %v (%v), want 30", fee, err)
+	}
+	quote, err := bridge.CalculateFee(&bind.CallOpts{}, big.NewInt(10_000_000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if quote.Fee.Int64() != 30_000 || quote.NetAmount.Int64() != 9_970_000 {
+		t.Errorf("fee %s and net %s of 10 USDC", quote.Fee, quote.NetAmount)
+	}
+	if got, err := bridge.Validator(&bind.CallOpts{}); err != nil || got != validator {
+		t.Errorf("validator %s (%v), want %s", got.Hex(), err, validator.Hex())
+	}
+	if processed, err := bridge.ProcessedMessages(&bind.CallOpts{}, [32]byte{1}); err != nil || processed {
+		t.Errorf("processedMessages of an unknown message = %v (%v)", processed, err)
+	}
+}
+
+func TestPackOptsPacksWithoutBackend(t *testing.T) {
+	transactor, err := chain.NewCrossChainBridgeTransactor([20]byte{1}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	call, err := transactor.SetBridgeFee(chain.PackOpts(), big.NewInt(30))
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := chain.CrossChainBridgeMetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := parsed.Pack("setBridgeFee", big.NewInt(30))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(call.Data()) != string(want) {
+		t.Errorf("calldata %x, want %x", call.Data(), want)
+	}
+}
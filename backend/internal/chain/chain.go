@@ -0,0 +1,17 @@
+// Package chain holds type-safe bindings for the contracts in
+// smart-contracts/contracts and a client shared by the services that talk to
+// them.
+//
+// The bindings are generated by abigen from the ABIs in abi/, which are taken
+// from the Hardhat artifacts. After changing a contract run
+// `npx hardhat compile` in smart-contracts and then `go generate` here.
+package chain
+
+//go:generate sh -c "jq .abi ../../../smart-contracts/artifacts/contracts/AASharing.sol/AASharing.json > abi/AASharing.abi"
+//go:generate sh -c "jq .abi ../../../smart-contracts/artifacts/contracts/CrossChainBridge.sol/CrossChainBridge.json > abi/CrossChainBridge.abi"
+//go:generate sh -c "jq .abi ../../../smart-contracts/artifacts/contracts/SimpleStorage.sol/SimpleStorage.json > abi/SimpleStorage.abi"
+//go:generate sh -c "jq .abi ../../../smart-contracts/artifacts/contracts/IUSDC.sol/IUSDC.json > abi/USDC.abi"
+//go:generate abigen --abi abi/AASharing.abi --pkg chain --type AASharing --out aasharing.go
+//go:generate abigen --abi abi/CrossChainBridge.abi --pkg chain --type CrossChainBridge --out cross_chain_bridge.go
+//go:generate abigen --abi abi/SimpleStorage.abi --pkg chain --type SimpleStorage --out simple_storage.go
+//go:generate abigen --abi abi/USDC.abi --pkg chain --type USDC --out usdc.go
@@ -0,0 +1,102 @@
+package chain
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// ErrReverted is returned by WaitMined for a transaction that was mined but
+// reverted.
+var ErrReverted = errors.New("transaction reverted")
+
+// Client is a JSON-RPC connection to one chain. It can be used wherever an
+// *ethclient.Client or a bind.ContractBackend is expected.
+type Client struct {
+	*ethclient.Client
+	chainID *big.Int
+}
+
+// Dial connects to the node at rawURL and reads its chain ID.
+func Dial(ctx context.Context, rawURL string) (*Client, error) {
+	rpc, err := ethclient.DialContext(ctx, rawURL)
+	if err != nil {
+		return nil, err
+	}
+	chainID, err := rpc.ChainID(ctx)
+	if err != nil {
+		rpc.Close()
+		return nil, err
+	}
+	return &Client{Client: rpc, chainID: chainID}, nil
+}
+
+// ChainID returns the chain ID read when the client was dialed.
+func (c *Client) ChainID(ctx context.Context) (*big.Int, error) {
+	return new(big.Int).Set(c.chainID), nil
+}
+
+// AASharing binds the AASharing contract deployed at address.
+func (c *Client) AASharing(address common.Address) (*AASharing, error) {
+	return NewAASharing(address, c)
+}
+
+// CrossChainBridge binds the CrossChainBridge contract deployed at address.
+func (c *Client) CrossChainBridge(address common.Address) (*CrossChainBridge, error) {
+	return NewCrossChainBridge(address, c)
+}
+
+// SimpleStorage binds the SimpleStorage contract deployed at address.
+func (c *Client) SimpleStorage(address common.Address) (*SimpleStorage, error) {
+	return NewSimpleStorage(address, c)
+}
+
+// Transactor returns options that sign transactions with key for this chain.
+func (c *Client) Transactor(ctx context.Context, key *ecdsa.PrivateKey) (*bind.TransactOpts, error) {
+	opts, err := bind.NewKeyedTransactorWithChainID(key, c.chainID)
+	if err != nil {
+		return nil, err
+	}
+	opts.Context = ctx
+	return opts, nil
+}
+
+// PackOpts returns options under which a binding's transactor methods build
+// the call without signing or sending it, so that its calldata can be handed
+// to a queue that signs and sends transactions itself:
+//
+//	call, err := bridge.CompleteCrossChainDeposit(chain.PackOpts(), ...)
+//	data := call.Data()
+//
+// Nonce, gas and price are set so that the binding makes no RPC calls; the
+// transaction it returns is not meant to be sent.
+func PackOpts() *bind.TransactOpts {
+	return &bind.TransactOpts{
+		Signer: func(_ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			return tx, nil
+		},
+		Nonce:    new(big.Int),
+		GasPrice: new(big.Int),
+		GasLimit: 1,
+		NoSend:   true,
+	}
+}
+
+// WaitMined waits until tx is mined and returns its receipt, or ErrReverted
+// along with the receipt if it failed.
+func (c *Client) WaitMined(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+	receipt, err := bind.WaitMined(ctx, c, tx)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return receipt, ErrReverted
+	}
+	return receipt, nil
+}
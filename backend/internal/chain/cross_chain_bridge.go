@@ -0,0 +1,1777 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package chain
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// CrossChainBridgeMetaData contains all meta data concerning the CrossChainBridge contract.
+var CrossChainBridgeMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_usdc\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_aaSharing\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"_validator\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[],\"name\":\"ECDSAInvalidSignature\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"length\",\"type\":\"uint256\"}],\"name\":\"ECDSAInvalidSignatureLength\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"s\",\"type\":\"bytes32\"}],\"name\":\"ECDSAInvalidSignatureS\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidAmount\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidPartnership\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidSignature\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"MessageAlreadyProcessed\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"owner\",\"type\":\"address\"}],\"name\":\"OwnableInvalidOwner\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"OwnableUnauthorizedAccount\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"ReentrancyGuardReentrantCall\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"TransferFailed\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"Unauthorized\",\"type\":\"error\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"oldContract\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newContract\",\"type\":\"address\"}],\"name\":\"AAContractUpdated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"messageId\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"destinationChain\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"message\",\"type\":\"bytes\"}],\"name\":\"AvailMessageSent\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"partnershipId\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"sourceChain\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"messageId\",\"type\":\"bytes32\"}],\"name\":\"CrossChainDepositCompleted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"partnershipId\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"destinationChain\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"messageId\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"}],\"name\":\"CrossChainDepositInitiated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"newFee\",\"type\":\"uint256\"}],\"name\":\"FeesUpdated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"previousOwner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"OwnershipTransferred\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"oldValidator\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newValidator\",\"type\":\"address\"}],\"name\":\"ValidatorUpdated\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"aaSharing\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"bridgeFee\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"calculateFee\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"fee\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"netAmount\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"partnershipId\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"sourceChain\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"messageId\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"signature\",\"type\":\"bytes\"}],\"name\":\"completeCrossChainDeposit\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"token\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"emergencyWithdraw\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"messageId\",\"type\":\"bytes32\"}],\"name\":\"getMessageStatus\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"processed\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"partnershipId\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"destinationChain\",\"type\":\"uint256\"}],\"name\":\"initiateCrossChainDeposit\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"maxDeposit\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"minDeposit\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"name\":\"processedMessages\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"renounceOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newAAContract\",\"type\":\"address\"}],\"name\":\"setAAContract\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"newFee\",\"type\":\"uint256\"}],\"name\":\"setBridgeFee\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"newMin\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"newMax\",\"type\":\"uint256\"}],\"name\":\"setDepositLimits\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newValidator\",\"type\":\"address\"}],\"name\":\"setValidator\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"transferOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"usdc\",\"outputs\":[{\"internalType\":\"contractIERC20\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"userNonces\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"validator\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+}
+
+// CrossChainBridgeABI is the input ABI used to generate the binding from.
+// Deprecated: Use CrossChainBridgeMetaData.ABI instead.
+var CrossChainBridgeABI = CrossChainBridgeMetaData.ABI
+
+// CrossChainBridge is an auto generated Go binding around an Ethereum contract.
+type CrossChainBridge struct {
+	CrossChainBridgeCaller     // Read-only binding to the contract
+	CrossChainBridgeTransactor // Write-only binding to the contract
+	CrossChainBridgeFilterer   // Log filterer for contract events
+}
+
+// CrossChainBridgeCaller is an auto generated read-only Go binding around an Ethereum contract.
+type CrossChainBridgeCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// CrossChainBridgeTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type CrossChainBridgeTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// CrossChainBridgeFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type CrossChainBridgeFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// CrossChainBridgeSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type CrossChainBridgeSession struct {
+	Contract     *CrossChainBridge // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// CrossChainBridgeCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type CrossChainBridgeCallerSession struct {
+	Contract *CrossChainBridgeCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts           // Call options to use throughout this session
+}
+
+// CrossChainBridgeTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type CrossChainBridgeTransactorSession struct {
+	Contract     *CrossChainBridgeTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts           // Transaction auth options to use throughout this session
+}
+
+// CrossChainBridgeRaw is an auto generated low-level Go binding around an Ethereum contract.
+type CrossChainBridgeRaw struct {
+	Contract *CrossChainBridge // Generic contract binding to access the raw methods on
+}
+
+// CrossChainBridgeCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type CrossChainBridgeCallerRaw struct {
+	Contract *CrossChainBridgeCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// CrossChainBridgeTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type CrossChainBridgeTransactorRaw struct {
+	Contract *CrossChainBridgeTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewCrossChainBridge creates a new instance of CrossChainBridge, bound to a specific deployed contract.
+func NewCrossChainBridge(address common.Address, backend bind.ContractBackend) (*CrossChainBridge, error) {
+	contract, err := bindCrossChainBridge(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &CrossChainBridge{CrossChainBridgeCaller: CrossChainBridgeCaller{contract: contract}, CrossChainBridgeTransactor: CrossChainBridgeTransactor{contract: contract}, CrossChainBridgeFilterer: CrossChainBridgeFilterer{contract: contract}}, nil
+}
+
+// NewCrossChainBridgeCaller creates a new read-only instance of CrossChainBridge, bound to a specific deployed contract.
+func NewCrossChainBridgeCaller(address common.Address, caller bind.ContractCaller) (*CrossChainBridgeCaller, error) {
+	contract, err := bindCrossChainBridge(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &CrossChainBridgeCaller{contract: contract}, nil
+}
+
+// NewCrossChainBridgeTransactor creates a new write-only instance of CrossChainBridge, bound to a specific deployed contract.
+func NewCrossChainBridgeTransactor(address common.Address, transactor bind.ContractTransactor) (*CrossChainBridgeTransactor, error) {
+	contract, err := bindCrossChainBridge(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &CrossChainBridgeTransactor{contract: contract}, nil
+}
+
+// NewCrossChainBridgeFilterer creates a new log filterer instance of CrossChainBridge, bound to a specific deployed contract.
+func NewCrossChainBridgeFilterer(address common.Address, filterer bind.ContractFilterer) (*CrossChainBridgeFilterer, error) {
+	contract, err := bindCrossChainBridge(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &CrossChainBridgeFilterer{contract: contract}, nil
+}
+
+// bindCrossChainBridge binds a generic wrapper to an already deployed contract.
+func bindCrossChainBridge(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := CrossChainBridgeMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_CrossChainBridge *CrossChainBridgeRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _CrossChainBridge.Contract.CrossChainBridgeCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_CrossChainBridge *CrossChainBridgeRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _CrossChainBridge.Contract.CrossChainBridgeTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_CrossChainBridge *CrossChainBridgeRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _CrossChainBridge.Contract.CrossChainBridgeTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_CrossChainBridge *CrossChainBridgeCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _CrossChainBridge.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_CrossChainBridge *CrossChainBridgeTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _CrossChainBridge.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_CrossChainBridge *CrossChainBridgeTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _CrossChainBridge.Contract.contract.Transact(opts, method, params...)
+}
+
+// AaSharing is a free data retrieval call binding the contract method 0xd91795de.
+//
+// Solidity: function aaSharing() view returns(address)
+func (_CrossChainBridge *CrossChainBridgeCaller) AaSharing(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _CrossChainBridge.contract.Call(opts, &out, "aaSharing")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// AaSharing is a free data retrieval call binding the contract method 0xd91795de.
+//
+// Solidity: function aaSharing() view returns(address)
+func (_CrossChainBridge *CrossChainBridgeSession) AaSharing() (common.Address, error) {
+	return _CrossChainBridge.Contract.AaSharing(&_CrossChainBridge.CallOpts)
+}
+
+// AaSharing is a free data retrieval call binding the contract method 0xd91795de.
+//
+// Solidity: function aaSharing() view returns(address)
+func (_CrossChainBridge *CrossChainBridgeCallerSession) AaSharing() (common.Address, error) {
+	return _CrossChainBridge.Contract.AaSharing(&_CrossChainBridge.CallOpts)
+}
+
+// BridgeFee is a free data retrieval call binding the contract method 0x82b12dd7.
+//
+// Solidity: function bridgeFee() view returns(uint256)
+func (_CrossChainBridge *CrossChainBridgeCaller) BridgeFee(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _CrossChainBridge.contract.Call(opts, &out, "bridgeFee")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// BridgeFee is a free data retrieval call binding the contract method 0x82b12dd7.
+//
+// Solidity: function bridgeFee() view returns(uint256)
+func (_CrossChainBridge *CrossChainBridgeSession) BridgeFee() (*big.Int, error) {
+	return _CrossChainBridge.Contract.BridgeFee(&_CrossChainBridge.CallOpts)
+}
+
+// BridgeFee is a free data retrieval call binding the contract method 0x82b12dd7.
+//
+// Solidity: function bridgeFee() view returns(uint256)
+func (_CrossChainBridge *CrossChainBridgeCallerSession) BridgeFee() (*big.Int, error) {
+	return _CrossChainBridge.Contract.BridgeFee(&_CrossChainBridge.CallOpts)
+}
+
+// CalculateFee is a free data retrieval call binding the contract method 0x99a5d747.
+//
+// Solidity: function calculateFee(uint256 amount) view returns(uint256 fee, uint256 netAmount)
+func (_CrossChainBridge *CrossChainBridgeCaller) CalculateFee(opts *bind.CallOpts, amount *big.Int) (struct {
+	Fee       *big.Int
+	NetAmount *big.Int
+}, error) {
+	var out []interface{}
+	err := _CrossChainBridge.contract.Call(opts, &out, "calculateFee", amount)
+
+	outstruct := new(struct {
+		Fee       *big.Int
+		NetAmount *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Fee = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.NetAmount = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// CalculateFee is a free data retrieval call binding the contract method 0x99a5d747.
+//
+// Solidity: function calculateFee(uint256 amount) view returns(uint256 fee, uint256 netAmount)
+func (_CrossChainBridge *CrossChainBridgeSession) CalculateFee(amount *big.Int) (struct {
+	Fee       *big.Int
+	NetAmount *big.Int
+}, error) {
+	return _CrossChainBridge.Contract.CalculateFee(&_CrossChainBridge.CallOpts, amount)
+}
+
+// CalculateFee is a free data retrieval call binding the contract method 0x99a5d747.
+//
+// Solidity: function calculateFee(uint256 amount) view returns(uint256 fee, uint256 netAmount)
+func (_CrossChainBridge *CrossChainBridgeCallerSession) CalculateFee(amount *big.Int) (struct {
+	Fee       *big.Int
+	NetAmount *big.Int
+}, error) {
+	return _CrossChainBridge.Contract.CalculateFee(&_CrossChainBridge.CallOpts, amount)
+}
+
+// GetMessageStatus is a free data retrieval call binding the contract method 0x5075a9d4.
+//
+// Solidity: function getMessageStatus(bytes32 messageId) view returns(bool processed)
+func (_CrossChainBridge *CrossChainBridgeCaller) GetMessageStatus(opts *bind.CallOpts, messageId [32]byte) (bool, error) {
+	var out []interface{}
+	err := _CrossChainBridge.contract.Call(opts, &out, "getMessageStatus", messageId)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// GetMessageStatus is a free data retrieval call binding the contract method 0x5075a9d4.
+//
+// Solidity: function getMessageStatus(bytes32 messageId) view returns(bool processed)
+func (_CrossChainBridge *CrossChainBridgeSession) GetMessageStatus(messageId [32]byte) (bool, error) {
+	return _CrossChainBridge.Contract.GetMessageStatus(&_CrossChainBridge.CallOpts, messageId)
+}
+
+// GetMessageStatus is a free data retrieval call binding the contract method 0x5075a9d4.
+//
+// Solidity: function getMessageStatus(bytes32 messageId) view returns(bool processed)
+func (_CrossChainBridge *CrossChainBridgeCallerSession) GetMessageStatus(messageId [32]byte) (bool, error) {
+	return _CrossChainBridge.Contract.GetMessageStatus(&_CrossChainBridge.CallOpts, messageId)
+}
+
+// MaxDeposit is a free data retrieval call binding the contract method 0x6083e59a.
+//
+// Solidity: function maxDeposit() view returns(uint256)
+func (_CrossChainBridge *CrossChainBridgeCaller) MaxDeposit(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _CrossChainBridge.contract.Call(opts, &out, "maxDeposit")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MaxDeposit is a free data retrieval call binding the contract method 0x6083e59a.
+//
+// Solidity: function maxDeposit() view returns(uint256)
+func (_CrossChainBridge *CrossChainBridgeSession) MaxDeposit() (*big.Int, error) {
+	return _CrossChainBridge.Contract.MaxDeposit(&_CrossChainBridge.CallOpts)
+}
+
+// MaxDeposit is a free data retrieval call binding the contract method 0x6083e59a.
+//
+// Solidity: function maxDeposit() view returns(uint256)
+func (_CrossChainBridge *CrossChainBridgeCallerSession) MaxDeposit() (*big.Int, error) {
+	return _CrossChainBridge.Contract.MaxDeposit(&_CrossChainBridge.CallOpts)
+}
+
+// MinDeposit is a free data retrieval call binding the contract method 0x41b3d185.
+//
+// Solidity: function minDeposit() view returns(uint256)
+func (_CrossChainBridge *CrossChainBridgeCaller) MinDeposit(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _CrossChainBridge.contract.Call(opts, &out, "minDeposit")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MinDeposit is a free data retrieval call binding the contract method 0x41b3d185.
+//
+// Solidity: function minDeposit() view returns(uint256)
+func (_CrossChainBridge *CrossChainBridgeSession) MinDeposit() (*big.Int, error) {
+	return _CrossChainBridge.Contract.MinDeposit(&_CrossChainBridge.CallOpts)
+}
+
+// MinDeposit is a free data retrieval call binding the contract method 0x41b3d185.
+//
+// Solidity: function minDeposit() view returns(uint256)
+func (_CrossChainBridge *CrossChainBridgeCallerSession) MinDeposit() (*big.Int, error) {
+	return _CrossChainBridge.Contract.MinDeposit(&_CrossChainBridge.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_CrossChainBridge *CrossChainBridgeCaller) Owner(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _CrossChainBridge.contract.Call(opts, &out, "owner")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_CrossChainBridge *CrossChainBridgeSession) Owner() (common.Address, error) {
+	return _CrossChainBridge.Contract.Owner(&_CrossChainBridge.CallOpts)
+}
+
+// Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
+//
+// Solidity: function owner() view returns(address)
+func (_CrossChainBridge *CrossChainBridgeCallerSession) Owner() (common.Address, error) {
+	return _CrossChainBridge.Contract.Owner(&_CrossChainBridge.CallOpts)
+}
+
+// ProcessedMessages is a free data retrieval call binding the contract method 0x88ba16ab.
+//
+// Solidity: function processedMessages(bytes32 ) view returns(bool)
+func (_CrossChainBridge *CrossChainBridgeCaller) ProcessedMessages(opts *bind.CallOpts, arg0 [32]byte) (bool, error) {
+	var out []interface{}
+	err := _CrossChainBridge.contract.Call(opts, &out, "processedMessages", arg0)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// ProcessedMessages is a free data retrieval call binding the contract method 0x88ba16ab.
+//
+// Solidity: function processedMessages(bytes32 ) view returns(bool)
+func (_CrossChainBridge *CrossChainBridgeSession) ProcessedMessages(arg0 [32]byte) (bool, error) {
+	return _CrossChainBridge.Contract.ProcessedMessages(&_CrossChainBridge.CallOpts, arg0)
+}
+
+// ProcessedMessages is a free data retrieval call binding the contract method 0x88ba16ab.
+//
+// Solidity: function processedMessages(bytes32 ) view returns(bool)
+func (_CrossChainBridge *CrossChainBridgeCallerSession) ProcessedMessages(arg0 [32]byte) (bool, error) {
+	return _CrossChainBridge.Contract.ProcessedMessages(&_CrossChainBridge.CallOpts, arg0)
+}
+
+// Usdc is a free data retrieval call binding the contract method 0x3e413bee.
+//
+// Solidity: function usdc() view returns(address)
+func (_CrossChainBridge *CrossChainBridgeCaller) Usdc(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _CrossChainBridge.contract.Call(opts, &out, "usdc")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Usdc is a free data retrieval call binding the contract method 0x3e413bee.
+//
+// Solidity: function usdc() view returns(address)
+func (_CrossChainBridge *CrossChainBridgeSession) Usdc() (common.Address, error) {
+	return _CrossChainBridge.Contract.Usdc(&_CrossChainBridge.CallOpts)
+}
+
+// Usdc is a free data retrieval call binding the contract method 0x3e413bee.
+//
+// Solidity: function usdc() view returns(address)
+func (_CrossChainBridge *CrossChainBridgeCallerSession) Usdc() (common.Address, error) {
+	return _CrossChainBridge.Contract.Usdc(&_CrossChainBridge.CallOpts)
+}
+
+// UserNonces is a free data retrieval call binding the contract method 0x2f7801f4.
+//
+// Solidity: function userNonces(address ) view returns(uint256)
+func (_CrossChainBridge *CrossChainBridgeCaller) UserNonces(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _CrossChainBridge.contract.Call(opts, &out, "userNonces", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// UserNonces is a free data retrieval call binding the contract method 0x2f7801f4.
+//
+// Solidity: function userNonces(address ) view returns(uint256)
+func (_CrossChainBridge *CrossChainBridgeSession) UserNonces(arg0 common.Address) (*big.Int, error) {
+	return _CrossChainBridge.Contract.UserNonces(&_CrossChainBridge.CallOpts, arg0)
+}
+
+// UserNonces is a free data retrieval call binding the contract method 0x2f7801f4.
+//
+// Solidity: function userNonces(address ) view returns(uint256)
+func (_CrossChainBridge *CrossChainBridgeCallerSession) UserNonces(arg0 common.Address) (*big.Int, error) {
+	return _CrossChainBridge.Contract.UserNonces(&_CrossChainBridge.CallOpts, arg0)
+}
+
+// Validator is a free data retrieval call binding the contract method 0x3a5381b5.
+//
+// Solidity: function validator() view returns(address)
+func (_CrossChainBridge *CrossChainBridgeCaller) Validator(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _CrossChainBridge.contract.Call(opts, &out, "validator")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Validator is a free data retrieval call binding the contract method 0x3a5381b5.
+//
+// Solidity: function validator() view returns(address)
+func (_CrossChainBridge *CrossChainBridgeSession) Validator() (common.Address, error) {
+	return _CrossChainBridge.Contract.Validator(&_CrossChainBridge.CallOpts)
+}
+
+// Validator is a free data retrieval call binding the contract method 0x3a5381b5.
+//
+// Solidity: function validator() view returns(address)
+func (_CrossChainBridge *CrossChainBridgeCallerSession) Validator() (common.Address, error) {
+	return _CrossChainBridge.Contract.Validator(&_CrossChainBridge.CallOpts)
+}
+
+// CompleteCrossChainDeposit is a paid mutator transaction binding the contract method 0x664fe0b8.
+//
+// Solidity: function completeCrossChainDeposit(address user, uint256 partnershipId, uint256 amount, uint256 sourceChain, bytes32 messageId, uint256 nonce, bytes signature) returns()
+func (_CrossChainBridge *CrossChainBridgeTransactor) CompleteCrossChainDeposit(opts *bind.TransactOpts, user common.Address, partnershipId *big.Int, amount *big.Int, sourceChain *big.Int, messageId [32]byte, nonce *big.Int, signature []byte) (*types.Transaction, error) {
+	return _CrossChainBridge.contract.Transact(opts, "completeCrossChainDeposit", user, partnershipId, amount, sourceChain, messageId, nonce, signature)
+}
+
+// CompleteCrossChainDeposit is a paid mutator transaction binding the contract method 0x664fe0b8.
+//
+// Solidity: function completeCrossChainDeposit(address user, uint256 partnershipId, uint256 amount, uint256 sourceChain, bytes32 messageId, uint256 nonce, bytes signature) returns()
+func (_CrossChainBridge *CrossChainBridgeSession) CompleteCrossChainDeposit(user common.Address, partnershipId *big.Int, amount *big.Int, sourceChain *big.Int, messageId [32]byte, nonce *big.Int, signature []byte) (*types.Transaction, error) {
+	return _CrossChainBridge.Contract.CompleteCrossChainDeposit(&_CrossChainBridge.TransactOpts, user, partnershipId, amount, sourceChain, messageId, nonce, signature)
+}
+
+// CompleteCrossChainDeposit is a paid mutator transaction binding the contract method 0x664fe0b8.
+//
+// Solidity: function completeCrossChainDeposit(address user, uint256 partnershipId, uint256 amount, uint256 sourceChain, bytes32 messageId, uint256 nonce, bytes signature) returns()
+func (_CrossChainBridge *CrossChainBridgeTransactorSession) CompleteCrossChainDeposit(user common.Address, partnershipId *big.Int, amount *big.Int, sourceChain *big.Int, messageId [32]byte, nonce *big.Int, signature []byte) (*types.Transaction, error) {
+	return _CrossChainBridge.Contract.CompleteCrossChainDeposit(&_CrossChainBridge.TransactOpts, user, partnershipId, amount, sourceChain, messageId, nonce, signature)
+}
+
+// EmergencyWithdraw is a paid mutator transaction binding the contract method 0x95ccea67.
+//
+// Solidity: function emergencyWithdraw(address token, uint256 amount) returns()
+func (_CrossChainBridge *CrossChainBridgeTransactor) EmergencyWithdraw(opts *bind.TransactOpts, token common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _CrossChainBridge.contract.Transact(opts, "emergencyWithdraw", token, amount)
+}
+
+// EmergencyWithdraw is a paid mutator transaction binding the contract method 0x95ccea67.
+//
+// Solidity: function emergencyWithdraw(address token, uint256 amount) returns()
+func (_CrossChainBridge *CrossChainBridgeSession) EmergencyWithdraw(token common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _CrossChainBridge.Contract.EmergencyWithdraw(&_CrossChainBridge.TransactOpts, token, amount)
+}
+
+// EmergencyWithdraw is a paid mutator transaction binding the contract method 0x95ccea67.
+//
+// Solidity: function emergencyWithdraw(address token, uint256 amount) returns()
+func (_CrossChainBridge *CrossChainBridgeTransactorSession) EmergencyWithdraw(token common.Address, amount *big.Int) (*types.Transaction, error) {
+	return _CrossChainBridge.Contract.EmergencyWithdraw(&_CrossChainBridge.TransactOpts, token, amount)
+}
+
+// InitiateCrossChainDeposit is a paid mutator transaction binding the contract method 0x9695b455.
+//
+// Solidity: function initiateCrossChainDeposit(uint256 partnershipId, uint256 amount, uint256 destinationChain) returns()
+func (_CrossChainBridge *CrossChainBridgeTransactor) InitiateCrossChainDeposit(opts *bind.TransactOpts, partnershipId *big.Int, amount *big.Int, destinationChain *big.Int) (*types.Transaction, error) {
+	return _CrossChainBridge.contract.Transact(opts, "initiateCrossChainDeposit", partnershipId, amount, destinationChain)
+}
+
+// InitiateCrossChainDeposit is a paid mutator transaction binding the contract method 0x9695b455.
+//
+// Solidity: function initiateCrossChainDeposit(uint256 partnershipId, uint256 amount, uint256 destinationChain) returns()
+func (_CrossChainBridge *CrossChainBridgeSession) InitiateCrossChainDeposit(partnershipId *big.Int, amount *big.Int, destinationChain *big.Int) (*types.Transaction, error) {
+	return _CrossChainBridge.Contract.InitiateCrossChainDeposit(&_CrossChainBridge.TransactOpts, partnershipId, amount, destinationChain)
+}
+
+// InitiateCrossChainDeposit is a paid mutator transaction binding the contract method 0x9695b455.
+//
+// Solidity: function initiateCrossChainDeposit(uint256 partnershipId, uint256 amount, uint256 destinationChain) returns()
+func (_CrossChainBridge *CrossChainBridgeTransactorSession) InitiateCrossChainDeposit(partnershipId *big.Int, amount *big.Int, destinationChain *big.Int) (*types.Transaction, error) {
+	return _CrossChainBridge.Contract.InitiateCrossChainDeposit(&_CrossChainBridge.TransactOpts, partnershipId, amount, destinationChain)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_CrossChainBridge *CrossChainBridgeTransactor) RenounceOwnership(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _CrossChainBridge.contract.Transact(opts, "renounceOwnership")
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_CrossChainBridge *CrossChainBridgeSession) RenounceOwnership() (*types.Transaction, error) {
+	return _CrossChainBridge.Contract.RenounceOwnership(&_CrossChainBridge.TransactOpts)
+}
+
+// RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
+//
+// Solidity: function renounceOwnership() returns()
+func (_CrossChainBridge *CrossChainBridgeTransactorSession) RenounceOwnership() (*types.Transaction, error) {
+	return _CrossChainBridge.Contract.RenounceOwnership(&_CrossChainBridge.TransactOpts)
+}
+
+// SetAAContract is a paid mutator transaction binding the contract method 0x049733fa.
+//
+// Solidity: function setAAContract(address newAAContract) returns()
+func (_CrossChainBridge *CrossChainBridgeTransactor) SetAAContract(opts *bind.TransactOpts, newAAContract common.Address) (*types.Transaction, error) {
+	return _CrossChainBridge.contract.Transact(opts, "setAAContract", newAAContract)
+}
+
+// SetAAContract is a paid mutator transaction binding the contract method 0x049733fa.
+//
+// Solidity: function setAAContract(address newAAContract) returns()
+func (_CrossChainBridge *CrossChainBridgeSession) SetAAContract(newAAContract common.Address) (*types.Transaction, error) {
+	return _CrossChainBridge.Contract.SetAAContract(&_CrossChainBridge.TransactOpts, newAAContract)
+}
+
+// SetAAContract is a paid mutator transaction binding the contract method 0x049733fa.
+//
+// Solidity: function setAAContract(address newAAContract) returns()
+func (_CrossChainBridge *CrossChainBridgeTransactorSession) SetAAContract(newAAContract common.Address) (*types.Transaction, error) {
+	return _CrossChainBridge.Contract.SetAAContract(&_CrossChainBridge.TransactOpts, newAAContract)
+}
+
+// SetBridgeFee is a paid mutator transaction binding the contract method 0x998cdf83.
+//
+// Solidity: function setBridgeFee(uint256 newFee) returns()
+func (_CrossChainBridge *CrossChainBridgeTransactor) SetBridgeFee(opts *bind.TransactOpts, newFee *big.Int) (*types.Transaction, error) {
+	return _CrossChainBridge.contract.Transact(opts, "setBridgeFee", newFee)
+}
+
+// SetBridgeFee is a paid mutator transaction binding the contract method 0x998cdf83.
+//
+// Solidity: function setBridgeFee(uint256 newFee) returns()
+func (_CrossChainBridge *CrossChainBridgeSession) SetBridgeFee(newFee *big.Int) (*types.Transaction, error) {
+	return _CrossChainBridge.Contract.SetBridgeFee(&_CrossChainBridge.TransactOpts, newFee)
+}
+
+// SetBridgeFee is a paid mutator transaction binding the contract method 0x998cdf83.
+//
+// Solidity: function setBridgeFee(uint256 newFee) returns()
+func (_CrossChainBridge *CrossChainBridgeTransactorSession) SetBridgeFee(newFee *big.Int) (*types.Transaction, error) {
+	return _CrossChainBridge.Contract.SetBridgeFee(&_CrossChainBridge.TransactOpts, newFee)
+}
+
+// SetDepositLimits is a paid mutator transaction binding the contract method 0x4eddea06.
+//
+// Solidity: function setDepositLimits(uint256 newMin, uint256 newMax) returns()
+func (_CrossChainBridge *CrossChainBridgeTransactor) SetDepositLimits(opts *bind.TransactOpts, newMin *big.Int, newMax *big.Int) (*types.Transaction, error) {
+	return _CrossChainBridge.contract.Transact(opts, "setDepositLimits", newMin, newMax)
+}
+
+// SetDepositLimits is a paid mutator transaction binding the contract method 0x4eddea06.
+//
+// Solidity: function setDepositLimits(uint256 newMin, uint256 newMax) returns()
+func (_CrossChainBridge *CrossChainBridgeSession) SetDepositLimits(newMin *big.Int, newMax *big.Int) (*types.Transaction, error) {
+	return _CrossChainBridge.Contract.SetDepositLimits(&_CrossChainBridge.TransactOpts, newMin, newMax)
+}
+
+// SetDepositLimits is a paid mutator transaction binding the contract method 0x4eddea06.
+//
+// Solidity: function setDepositLimits(uint256 newMin, uint256 newMax) returns()
+func (_CrossChainBridge *CrossChainBridgeTransactorSession) SetDepositLimits(newMin *big.Int, newMax *big.Int) (*types.Transaction, error) {
+	return _CrossChainBridge.Contract.SetDepositLimits(&_CrossChainBridge.TransactOpts, newMin, newMax)
+}
+
+// SetValidator is a paid mutator transaction binding the contract method 0x1327d3d8.
+//
+// Solidity: function setValidator(address newValidator) returns()
+func (_CrossChainBridge *CrossChainBridgeTransactor) SetValidator(opts *bind.TransactOpts, newValidator common.Address) (*types.Transaction, error) {
+	return _CrossChainBridge.contract.Transact(opts, "setValidator", newValidator)
+}
+
+// SetValidator is a paid mutator transaction binding the contract method 0x1327d3d8.
+//
+// Solidity: function setValidator(address newValidator) returns()
+func (_CrossChainBridge *CrossChainBridgeSession) SetValidator(newValidator common.Address) (*types.Transaction, error) {
+	return _CrossChainBridge.Contract.SetValidator(&_CrossChainBridge.TransactOpts, newValidator)
+}
+
+// SetValidator is a paid mutator transaction binding the contract method 0x1327d3d8.
+//
+// Solidity: function setValidator(address newValidator) returns()
+func (_CrossChainBridge *CrossChainBridgeTransactorSession) SetValidator(newValidator common.Address) (*types.Transaction, error) {
+	return _CrossChainBridge.Contract.SetValidator(&_CrossChainBridge.TransactOpts, newValidator)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_CrossChainBridge *CrossChainBridgeTransactor) TransferOwnership(opts *bind.TransactOpts, newOwner common.Address) (*types.Transaction, error) {
+	return _CrossChainBridge.contract.Transact(opts, "transferOwnership", newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_CrossChainBridge *CrossChainBridgeSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _CrossChainBridge.Contract.TransferOwnership(&_CrossChainBridge.TransactOpts, newOwner)
+}
+
+// TransferOwnership is a paid mutator transaction binding the contract method 0xf2fde38b.
+//
+// Solidity: function transferOwnership(address newOwner) returns()
+func (_CrossChainBridge *CrossChainBridgeTransactorSession) TransferOwnership(newOwner common.Address) (*types.Transaction, error) {
+	return _CrossChainBridge.Contract.TransferOwnership(&_CrossChainBridge.TransactOpts, newOwner)
+}
+
+// CrossChainBridgeAAContractUpdatedIterator is returned from FilterAAContractUpdated and is used to iterate over the raw logs and unpacked data for AAContractUpdated events raised by the CrossChainBridge contract.
+type CrossChainBridgeAAContractUpdatedIterator struct {
+	Event *CrossChainBridgeAAContractUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CrossChainBridgeAAContractUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CrossChainBridgeAAContractUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CrossChainBridgeAAContractUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CrossChainBridgeAAContractUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CrossChainBridgeAAContractUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CrossChainBridgeAAContractUpdated represents a AAContractUpdated event raised by the CrossChainBridge contract.
+type CrossChainBridgeAAContractUpdated struct {
+	OldContract common.Address
+	NewContract common.Address
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterAAContractUpdated is a free log retrieval operation binding the contract event 0x5add372af2fb89f05c4cdbdbbd801e0c4e6eaad5639753fee25a67dccfe3a2e0.
+//
+// Solidity: event AAContractUpdated(address indexed oldContract, address indexed newContract)
+func (_CrossChainBridge *CrossChainBridgeFilterer) FilterAAContractUpdated(opts *bind.FilterOpts, oldContract []common.Address, newContract []common.Address) (*CrossChainBridgeAAContractUpdatedIterator, error) {
+
+	var oldContractRule []interface{}
+	for _, oldContractItem := range oldContract {
+		oldContractRule = append(oldContractRule, oldContractItem)
+	}
+	var newContractRule []interface{}
+	for _, newContractItem := range newContract {
+		newContractRule = append(newContractRule, newContractItem)
+	}
+
+	logs, sub, err := _CrossChainBridge.contract.FilterLogs(opts, "AAContractUpdated", oldContractRule, newContractRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CrossChainBridgeAAContractUpdatedIterator{contract: _CrossChainBridge.contract, event: "AAContractUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchAAContractUpdated is a free log subscription operation binding the contract event 0x5add372af2fb89f05c4cdbdbbd801e0c4e6eaad5639753fee25a67dccfe3a2e0.
+//
+// Solidity: event AAContractUpdated(address indexed oldContract, address indexed newContract)
+func (_CrossChainBridge *CrossChainBridgeFilterer) WatchAAContractUpdated(opts *bind.WatchOpts, sink chan<- *CrossChainBridgeAAContractUpdated, oldContract []common.Address, newContract []common.Address) (event.Subscription, error) {
+
+	var oldContractRule []interface{}
+	for _, oldContractItem := range oldContract {
+		oldContractRule = append(oldContractRule, oldContractItem)
+	}
+	var newContractRule []interface{}
+	for _, newContractItem := range newContract {
+		newContractRule = append(newContractRule, newContractItem)
+	}
+
+	logs, sub, err := _CrossChainBridge.contract.WatchLogs(opts, "AAContractUpdated", oldContractRule, newContractRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CrossChainBridgeAAContractUpdated)
+				if err := _CrossChainBridge.contract.UnpackLog(event, "AAContractUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAAContractUpdated is a log parse operation binding the contract event 0x5add372af2fb89f05c4cdbdbbd801e0c4e6eaad5639753fee25a67dccfe3a2e0.
+//
+// Solidity: event AAContractUpdated(address indexed oldContract, address indexed newContract)
+func (_CrossChainBridge *CrossChainBridgeFilterer) ParseAAContractUpdated(log types.Log) (*CrossChainBridgeAAContractUpdated, error) {
+	event := new(CrossChainBridgeAAContractUpdated)
+	if err := _CrossChainBridge.contract.UnpackLog(event, "AAContractUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CrossChainBridgeAvailMessageSentIterator is returned from FilterAvailMessageSent and is used to iterate over the raw logs and unpacked data for AvailMessageSent events raised by the CrossChainBridge contract.
+type CrossChainBridgeAvailMessageSentIterator struct {
+	Event *CrossChainBridgeAvailMessageSent // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CrossChainBridgeAvailMessageSentIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CrossChainBridgeAvailMessageSent)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CrossChainBridgeAvailMessageSent)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CrossChainBridgeAvailMessageSentIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CrossChainBridgeAvailMessageSentIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CrossChainBridgeAvailMessageSent represents a AvailMessageSent event raised by the CrossChainBridge contract.
+type CrossChainBridgeAvailMessageSent struct {
+	MessageId        [32]byte
+	DestinationChain *big.Int
+	Message          []byte
+	Raw              types.Log // Blockchain specific contextual infos
+}
+
+// FilterAvailMessageSent is a free log retrieval operation binding the contract event 0xd198652b067a3d03c60470836a224703ccb7a51c36a8fa4306936b2b9d0ab9df.
+//
+// Solidity: event AvailMessageSent(bytes32 indexed messageId, uint256 destinationChain, bytes message)
+func (_CrossChainBridge *CrossChainBridgeFilterer) FilterAvailMessageSent(opts *bind.FilterOpts, messageId [][32]byte) (*CrossChainBridgeAvailMessageSentIterator, error) {
+
+	var messageIdRule []interface{}
+	for _, messageIdItem := range messageId {
+		messageIdRule = append(messageIdRule, messageIdItem)
+	}
+
+	logs, sub, err := _CrossChainBridge.contract.FilterLogs(opts, "AvailMessageSent", messageIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CrossChainBridgeAvailMessageSentIterator{contract: _CrossChainBridge.contract, event: "AvailMessageSent", logs: logs, sub: sub}, nil
+}
+
+// WatchAvailMessageSent is a free log subscription operation binding the contract event 0xd198652b067a3d03c60470836a224703ccb7a51c36a8fa4306936b2b9d0ab9df.
+//
+// Solidity: event AvailMessageSent(bytes32 indexed messageId, uint256 destinationChain, bytes message)
+func (_CrossChainBridge *CrossChainBridgeFilterer) WatchAvailMessageSent(opts *bind.WatchOpts, sink chan<- *CrossChainBridgeAvailMessageSent, messageId [][32]byte) (event.Subscription, error) {
+
+	var messageIdRule []interface{}
+	for _, messageIdItem := range messageId {
+		messageIdRule = append(messageIdRule, messageIdItem)
+	}
+
+	logs, sub, err := _CrossChainBridge.contract.WatchLogs(opts, "AvailMessageSent", messageIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CrossChainBridgeAvailMessageSent)
+				if err := _CrossChainBridge.contract.UnpackLog(event, "AvailMessageSent", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAvailMessageSent is a log parse operation binding the contract event 0xd198652b067a3d03c60470836a224703ccb7a51c36a8fa4306936b2b9d0ab9df.
+//
+// Solidity: event AvailMessageSent(bytes32 indexed messageId, uint256 destinationChain, bytes message)
+func (_CrossChainBridge *CrossChainBridgeFilterer) ParseAvailMessageSent(log types.Log) (*CrossChainBridgeAvailMessageSent, error) {
+	event := new(CrossChainBridgeAvailMessageSent)
+	if err := _CrossChainBridge.contract.UnpackLog(event, "AvailMessageSent", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CrossChainBridgeCrossChainDepositCompletedIterator is returned from FilterCrossChainDepositCompleted and is used to iterate over the raw logs and unpacked data for CrossChainDepositCompleted events raised by the CrossChainBridge contract.
+type CrossChainBridgeCrossChainDepositCompletedIterator struct {
+	Event *CrossChainBridgeCrossChainDepositCompleted // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CrossChainBridgeCrossChainDepositCompletedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CrossChainBridgeCrossChainDepositCompleted)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CrossChainBridgeCrossChainDepositCompleted)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CrossChainBridgeCrossChainDepositCompletedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CrossChainBridgeCrossChainDepositCompletedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CrossChainBridgeCrossChainDepositCompleted represents a CrossChainDepositCompleted event raised by the CrossChainBridge contract.
+type CrossChainBridgeCrossChainDepositCompleted struct {
+	User          common.Address
+	PartnershipId *big.Int
+	Amount        *big.Int
+	SourceChain   *big.Int
+	MessageId     [32]byte
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterCrossChainDepositCompleted is a free log retrieval operation binding the contract event 0xfd27a8fd5f0be047a896200a45040156fa19886d780ae059a00e7d4365a2ef34.
+//
+// Solidity: event CrossChainDepositCompleted(address indexed user, uint256 indexed partnershipId, uint256 amount, uint256 sourceChain, bytes32 messageId)
+func (_CrossChainBridge *CrossChainBridgeFilterer) FilterCrossChainDepositCompleted(opts *bind.FilterOpts, user []common.Address, partnershipId []*big.Int) (*CrossChainBridgeCrossChainDepositCompletedIterator, error) {
+
+	var userRule []interface{}
+	for _, userItem := range user {
+		userRule = append(userRule, userItem)
+	}
+	var partnershipIdRule []interface{}
+	for _, partnershipIdItem := range partnershipId {
+		partnershipIdRule = append(partnershipIdRule, partnershipIdItem)
+	}
+
+	logs, sub, err := _CrossChainBridge.contract.FilterLogs(opts, "CrossChainDepositCompleted", userRule, partnershipIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CrossChainBridgeCrossChainDepositCompletedIterator{contract: _CrossChainBridge.contract, event: "CrossChainDepositCompleted", logs: logs, sub: sub}, nil
+}
+
+// WatchCrossChainDepositCompleted is a free log subscription operation binding the contract event 0xfd27a8fd5f0be047a896200a45040156fa19886d780ae059a00e7d4365a2ef34.
+//
+// Solidity: event CrossChainDepositCompleted(address indexed user, uint256 indexed partnershipId, uint256 amount, uint256 sourceChain, bytes32 messageId)
+func (_CrossChainBridge *CrossChainBridgeFilterer) WatchCrossChainDepositCompleted(opts *bind.WatchOpts, sink chan<- *CrossChainBridgeCrossChainDepositCompleted, user []common.Address, partnershipId []*big.Int) (event.Subscription, error) {
+
+	var userRule []interface{}
+	for _, userItem := range user {
+		userRule = append(userRule, userItem)
+	}
+	var partnershipIdRule []interface{}
+	for _, partnershipIdItem := range partnershipId {
+		partnershipIdRule = append(partnershipIdRule, partnershipIdItem)
+	}
+
+	logs, sub, err := _CrossChainBridge.contract.WatchLogs(opts, "CrossChainDepositCompleted", userRule, partnershipIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CrossChainBridgeCrossChainDepositCompleted)
+				if err := _CrossChainBridge.contract.UnpackLog(event, "CrossChainDepositCompleted", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseCrossChainDepositCompleted is a log parse operation binding the contract event 0xfd27a8fd5f0be047a896200a45040156fa19886d780ae059a00e7d4365a2ef34.
+//
+// Solidity: event CrossChainDepositCompleted(address indexed user, uint256 indexed partnershipId, uint256 amount, uint256 sourceChain, bytes32 messageId)
+func (_CrossChainBridge *CrossChainBridgeFilterer) ParseCrossChainDepositCompleted(log types.Log) (*CrossChainBridgeCrossChainDepositCompleted, error) {
+	event := new(CrossChainBridgeCrossChainDepositCompleted)
+	if err := _CrossChainBridge.contract.UnpackLog(event, "CrossChainDepositCompleted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CrossChainBridgeCrossChainDepositInitiatedIterator is returned from FilterCrossChainDepositInitiated and is used to iterate over the raw logs and unpacked data for CrossChainDepositInitiated events raised by the CrossChainBridge contract.
+type CrossChainBridgeCrossChainDepositInitiatedIterator struct {
+	Event *CrossChainBridgeCrossChainDepositInitiated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CrossChainBridgeCrossChainDepositInitiatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CrossChainBridgeCrossChainDepositInitiated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CrossChainBridgeCrossChainDepositInitiated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CrossChainBridgeCrossChainDepositInitiatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CrossChainBridgeCrossChainDepositInitiatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CrossChainBridgeCrossChainDepositInitiated represents a CrossChainDepositInitiated event raised by the CrossChainBridge contract.
+type CrossChainBridgeCrossChainDepositInitiated struct {
+	User             common.Address
+	PartnershipId    *big.Int
+	Amount           *big.Int
+	DestinationChain *big.Int
+	MessageId        [32]byte
+	Nonce            *big.Int
+	Raw              types.Log // Blockchain specific contextual infos
+}
+
+// FilterCrossChainDepositInitiated is a free log retrieval operation binding the contract event 0x1336ce66da19d67f74ecbe99eabf613de6e4a422375da01f07df0c081fa843ba.
+//
+// Solidity: event CrossChainDepositInitiated(address indexed user, uint256 indexed partnershipId, uint256 amount, uint256 destinationChain, bytes32 messageId, uint256 nonce)
+func (_CrossChainBridge *CrossChainBridgeFilterer) FilterCrossChainDepositInitiated(opts *bind.FilterOpts, user []common.Address, partnershipId []*big.Int) (*CrossChainBridgeCrossChainDepositInitiatedIterator, error) {
+
+	var userRule []interface{}
+	for _, userItem := range user {
+		userRule = append(userRule, userItem)
+	}
+	var partnershipIdRule []interface{}
+	for _, partnershipIdItem := range partnershipId {
+		partnershipIdRule = append(partnershipIdRule, partnershipIdItem)
+	}
+
+	logs, sub, err := _CrossChainBridge.contract.FilterLogs(opts, "CrossChainDepositInitiated", userRule, partnershipIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CrossChainBridgeCrossChainDepositInitiatedIterator{contract: _CrossChainBridge.contract, event: "CrossChainDepositInitiated", logs: logs, sub: sub}, nil
+}
+
+// WatchCrossChainDepositInitiated is a free log subscription operation binding the contract event 0x1336ce66da19d67f74ecbe99eabf613de6e4a422375da01f07df0c081fa843ba.
+//
+// Solidity: event CrossChainDepositInitiated(address indexed user, uint256 indexed partnershipId, uint256 amount, uint256 destinationChain, bytes32 messageId, uint256 nonce)
+func (_CrossChainBridge *CrossChainBridgeFilterer) WatchCrossChainDepositInitiated(opts *bind.WatchOpts, sink chan<- *CrossChainBridgeCrossChainDepositInitiated, user []common.Address, partnershipId []*big.Int) (event.Subscription, error) {
+
+	var userRule []interface{}
+	for _, userItem := range user {
+		userRule = append(userRule, userItem)
+	}
+	var partnershipIdRule []interface{}
+	for _, partnershipIdItem := range partnershipId {
+		partnershipIdRule = append(partnershipIdRule, partnershipIdItem)
+	}
+
+	logs, sub, err := _CrossChainBridge.contract.WatchLogs(opts, "CrossChainDepositInitiated", userRule, partnershipIdRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CrossChainBridgeCrossChainDepositInitiated)
+				if err := _CrossChainBridge.contract.UnpackLog(event, "CrossChainDepositInitiated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseCrossChainDepositInitiated is a log parse operation binding the contract event 0x1336ce66da19d67f74ecbe99eabf613de6e4a422375da01f07df0c081fa843ba.
+//
+// Solidity: event CrossChainDepositInitiated(address indexed user, uint256 indexed partnershipId, uint256 amount, uint256 destinationChain, bytes32 messageId, uint256 nonce)
+func (_CrossChainBridge *CrossChainBridgeFilterer) ParseCrossChainDepositInitiated(log types.Log) (*CrossChainBridgeCrossChainDepositInitiated, error) {
+	event := new(CrossChainBridgeCrossChainDepositInitiated)
+	if err := _CrossChainBridge.contract.UnpackLog(event, "CrossChainDepositInitiated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CrossChainBridgeFeesUpdatedIterator is returned from FilterFeesUpdated and is used to iterate over the raw logs and unpacked data for FeesUpdated events raised by the CrossChainBridge contract.
+type CrossChainBridgeFeesUpdatedIterator struct {
+	Event *CrossChainBridgeFeesUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CrossChainBridgeFeesUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CrossChainBridgeFeesUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CrossChainBridgeFeesUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CrossChainBridgeFeesUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CrossChainBridgeFeesUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CrossChainBridgeFeesUpdated represents a FeesUpdated event raised by the CrossChainBridge contract.
+type CrossChainBridgeFeesUpdated struct {
+	NewFee *big.Int
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterFeesUpdated is a free log retrieval operation binding the contract event 0x9fe6eeb0f0541c644a56c67efeb872dbadd803a60b909d7dde1b35a3fe230b0e.
+//
+// Solidity: event FeesUpdated(uint256 newFee)
+func (_CrossChainBridge *CrossChainBridgeFilterer) FilterFeesUpdated(opts *bind.FilterOpts) (*CrossChainBridgeFeesUpdatedIterator, error) {
+
+	logs, sub, err := _CrossChainBridge.contract.FilterLogs(opts, "FeesUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return &CrossChainBridgeFeesUpdatedIterator{contract: _CrossChainBridge.contract, event: "FeesUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchFeesUpdated is a free log subscription operation binding the contract event 0x9fe6eeb0f0541c644a56c67efeb872dbadd803a60b909d7dde1b35a3fe230b0e.
+//
+// Solidity: event FeesUpdated(uint256 newFee)
+func (_CrossChainBridge *CrossChainBridgeFilterer) WatchFeesUpdated(opts *bind.WatchOpts, sink chan<- *CrossChainBridgeFeesUpdated) (event.Subscription, error) {
+
+	logs, sub, err := _CrossChainBridge.contract.WatchLogs(opts, "FeesUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CrossChainBridgeFeesUpdated)
+				if err := _CrossChainBridge.contract.UnpackLog(event, "FeesUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseFeesUpdated is a log parse operation binding the contract event 0x9fe6eeb0f0541c644a56c67efeb872dbadd803a60b909d7dde1b35a3fe230b0e.
+//
+// Solidity: event FeesUpdated(uint256 newFee)
+func (_CrossChainBridge *CrossChainBridgeFilterer) ParseFeesUpdated(log types.Log) (*CrossChainBridgeFeesUpdated, error) {
+	event := new(CrossChainBridgeFeesUpdated)
+	if err := _CrossChainBridge.contract.UnpackLog(event, "FeesUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CrossChainBridgeOwnershipTransferredIterator is returned from FilterOwnershipTransferred and is used to iterate over the raw logs and unpacked data for OwnershipTransferred events raised by the CrossChainBridge contract.
+type CrossChainBridgeOwnershipTransferredIterator struct {
+	Event *CrossChainBridgeOwnershipTransferred // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CrossChainBridgeOwnershipTransferredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CrossChainBridgeOwnershipTransferred)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CrossChainBridgeOwnershipTransferred)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CrossChainBridgeOwnershipTransferredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CrossChainBridgeOwnershipTransferredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CrossChainBridgeOwnershipTransferred represents a OwnershipTransferred event raised by the CrossChainBridge contract.
+type CrossChainBridgeOwnershipTransferred struct {
+	PreviousOwner common.Address
+	NewOwner      common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterOwnershipTransferred is a free log retrieval operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_CrossChainBridge *CrossChainBridgeFilterer) FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*CrossChainBridgeOwnershipTransferredIterator, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _CrossChainBridge.contract.FilterLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CrossChainBridgeOwnershipTransferredIterator{contract: _CrossChainBridge.contract, event: "OwnershipTransferred", logs: logs, sub: sub}, nil
+}
+
+// WatchOwnershipTransferred is a free log subscription operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_CrossChainBridge *CrossChainBridgeFilterer) WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *CrossChainBridgeOwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _CrossChainBridge.contract.WatchLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CrossChainBridgeOwnershipTransferred)
+				if err := _CrossChainBridge.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOwnershipTransferred is a log parse operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_CrossChainBridge *CrossChainBridgeFilterer) ParseOwnershipTransferred(log types.Log) (*CrossChainBridgeOwnershipTransferred, error) {
+	event := new(CrossChainBridgeOwnershipTransferred)
+	if err := _CrossChainBridge.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// CrossChainBridgeValidatorUpdatedIterator is returned from FilterValidatorUpdated and is used to iterate over the raw logs and unpacked data for ValidatorUpdated events raised by the CrossChainBridge contract.
+type CrossChainBridgeValidatorUpdatedIterator struct {
+	Event *CrossChainBridgeValidatorUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *CrossChainBridgeValidatorUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(CrossChainBridgeValidatorUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(CrossChainBridgeValidatorUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *CrossChainBridgeValidatorUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *CrossChainBridgeValidatorUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// CrossChainBridgeValidatorUpdated represents a ValidatorUpdated event raised by the CrossChainBridge contract.
+type CrossChainBridgeValidatorUpdated struct {
+	OldValidator common.Address
+	NewValidator common.Address
+	Raw          types.Log // Blockchain specific contextual infos
+}
+
+// FilterValidatorUpdated is a free log retrieval operation binding the contract event 0xcfac5dc75b8d9a7e074162f59d9adcd33da59f0fe8dfb21580db298fc0fdad0d.
+//
+// Solidity: event ValidatorUpdated(address indexed oldValidator, address indexed newValidator)
+func (_CrossChainBridge *CrossChainBridgeFilterer) FilterValidatorUpdated(opts *bind.FilterOpts, oldValidator []common.Address, newValidator []common.Address) (*CrossChainBridgeValidatorUpdatedIterator, error) {
+
+	var oldValidatorRule []interface{}
+	for _, oldValidatorItem := range oldValidator {
+		oldValidatorRule = append(oldValidatorRule, oldValidatorItem)
+	}
+	var newValidatorRule []interface{}
+	for _, newValidatorItem := range newValidator {
+		newValidatorRule = append(newValidatorRule, newValidatorItem)
+	}
+
+	logs, sub, err := _CrossChainBridge.contract.FilterLogs(opts, "ValidatorUpdated", oldValidatorRule, newValidatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return &CrossChainBridgeValidatorUpdatedIterator{contract: _CrossChainBridge.contract, event: "ValidatorUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchValidatorUpdated is a free log subscription operation binding the contract event 0xcfac5dc75b8d9a7e074162f59d9adcd33da59f0fe8dfb21580db298fc0fdad0d.
+//
+// Solidity: event ValidatorUpdated(address indexed oldValidator, address indexed newValidator)
+func (_CrossChainBridge *CrossChainBridgeFilterer) WatchValidatorUpdated(opts *bind.WatchOpts, sink chan<- *CrossChainBridgeValidatorUpdated, oldValidator []common.Address, newValidator []common.Address) (event.Subscription, error) {
+
+	var oldValidatorRule []interface{}
+	for _, oldValidatorItem := range oldValidator {
+		oldValidatorRule = append(oldValidatorRule, oldValidatorItem)
+	}
+	var newValidatorRule []interface{}
+	for _, newValidatorItem := range newValidator {
+		newValidatorRule = append(newValidatorRule, newValidatorItem)
+	}
+
+	logs, sub, err := _CrossChainBridge.contract.WatchLogs(opts, "ValidatorUpdated", oldValidatorRule, newValidatorRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(CrossChainBridgeValidatorUpdated)
+				if err := _CrossChainBridge.contract.UnpackLog(event, "ValidatorUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseValidatorUpdated is a log parse operation binding the contract event 0xcfac5dc75b8d9a7e074162f59d9adcd33da59f0fe8dfb21580db298fc0fdad0d.
+//
+// Solidity: event ValidatorUpdated(address indexed oldValidator, address indexed newValidator)
+func (_CrossChainBridge *CrossChainBridgeFilterer) ParseValidatorUpdated(log types.Log) (*CrossChainBridgeValidatorUpdated, error) {
+	event := new(CrossChainBridgeValidatorUpdated)
+	if err := _CrossChainBridge.contract.UnpackLog(event, "ValidatorUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,1030 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package chain
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// SimpleStorageMetaData contains all meta data concerning the SimpleStorage contract.
+var SimpleStorageMetaData = &bind.MetaData{
+	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"string[]\",\"name\":\"messages\",\"type\":\"string[]\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"tokenAmount\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"timestamp\",\"type\":\"uint256\"}],\"name\":\"DataStored\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"message\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"timestamp\",\"type\":\"uint256\"}],\"name\":\"MessageAdded\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"user\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"oldAmount\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"newAmount\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"timestamp\",\"type\":\"uint256\"}],\"name\":\"TokenAmountUpdated\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"_message\",\"type\":\"string\"}],\"name\":\"addMessage\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"allUsers\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getAllUsers\",\"outputs\":[{\"internalType\":\"address[]\",\"name\":\"\",\"type\":\"address[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_user\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_index\",\"type\":\"uint256\"}],\"name\":\"getMessage\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_user\",\"type\":\"address\"}],\"name\":\"getMessageCount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getMyData\",\"outputs\":[{\"internalType\":\"string[]\",\"name\":\"messages\",\"type\":\"string[]\"},{\"internalType\":\"uint256\",\"name\":\"tokenAmount\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"timestamp\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getUserCount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_user\",\"type\":\"address\"}],\"name\":\"getUserData\",\"outputs\":[{\"internalType\":\"string[]\",\"name\":\"messages\",\"type\":\"string[]\"},{\"internalType\":\"uint256\",\"name\":\"tokenAmount\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"userAddress\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"timestamp\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string[]\",\"name\":\"_messages\",\"type\":\"string[]\"},{\"internalType\":\"uint256\",\"name\":\"_tokenAmount\",\"type\":\"uint256\"}],\"name\":\"storeData\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_newAmount\",\"type\":\"uint256\"}],\"name\":\"updateTokenAmount\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"userData\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"tokenAmount\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"userAddress\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"timestamp\",\"type\":\"uint256\"},{\"internalType\":\"bool\",\"name\":\"exists\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_user\",\"type\":\"address\"}],\"name\":\"userExists\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+}
+
+// SimpleStorageABI is the input ABI used to generate the binding from.
+// Deprecated: Use SimpleStorageMetaData.ABI instead.
+var SimpleStorageABI = SimpleStorageMetaData.ABI
+
+// SimpleStorage is an auto generated Go binding around an Ethereum contract.
+type SimpleStorage struct {
+	SimpleStorageCaller     // Read-only binding to the contract
+	SimpleStorageTransactor // Write-only binding to the contract
+	SimpleStorageFilterer   // Log filterer for contract events
+}
+
+// SimpleStorageCaller is an auto generated read-only Go binding around an Ethereum contract.
+type SimpleStorageCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// SimpleStorageTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type SimpleStorageTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// SimpleStorageFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type SimpleStorageFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// SimpleStorageSession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type SimpleStorageSession struct {
+	Contract     *SimpleStorage    // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// SimpleStorageCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type SimpleStorageCallerSession struct {
+	Contract *SimpleStorageCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts        // Call options to use throughout this session
+}
+
+// SimpleStorageTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type SimpleStorageTransactorSession struct {
+	Contract     *SimpleStorageTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts        // Transaction auth options to use throughout this session
+}
+
+// SimpleStorageRaw is an auto generated low-level Go binding around an Ethereum contract.
+type SimpleStorageRaw struct {
+	Contract *SimpleStorage // Generic contract binding to access the raw methods on
+}
+
+// SimpleStorageCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type SimpleStorageCallerRaw struct {
+	Contract *SimpleStorageCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// SimpleStorageTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type SimpleStorageTransactorRaw struct {
+	Contract *SimpleStorageTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewSimpleStorage creates a new instance of SimpleStorage, bound to a specific deployed contract.
+func NewSimpleStorage(address common.Address, backend bind.ContractBackend) (*SimpleStorage, error) {
+	contract, err := bindSimpleStorage(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &SimpleStorage{SimpleStorageCaller: SimpleStorageCaller{contract: contract}, SimpleStorageTransactor: SimpleStorageTransactor{contract: contract}, SimpleStorageFilterer: SimpleStorageFilterer{contract: contract}}, nil
+}
+
+// NewSimpleStorageCaller creates a new read-only instance of SimpleStorage, bound to a specific deployed contract.
+func NewSimpleStorageCaller(address common.Address, caller bind.ContractCaller) (*SimpleStorageCaller, error) {
+	contract, err := bindSimpleStorage(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &SimpleStorageCaller{contract: contract}, nil
+}
+
+// NewSimpleStorageTransactor creates a new write-only instance of SimpleStorage, bound to a specific deployed contract.
+func NewSimpleStorageTransactor(address common.Address, transactor bind.ContractTransactor) (*SimpleStorageTransactor, error) {
+	contract, err := bindSimpleStorage(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &SimpleStorageTransactor{contract: contract}, nil
+}
+
+// NewSimpleStorageFilterer creates a new log filterer instance of SimpleStorage, bound to a specific deployed contract.
+func NewSimpleStorageFilterer(address common.Address, filterer bind.ContractFilterer) (*SimpleStorageFilterer, error) {
+	contract, err := bindSimpleStorage(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &SimpleStorageFilterer{contract: contract}, nil
+}
+
+// bindSimpleStorage binds a generic wrapper to an already deployed contract.
+func bindSimpleStorage(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := SimpleStorageMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_SimpleStorage *SimpleStorageRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _SimpleStorage.Contract.SimpleStorageCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_SimpleStorage *SimpleStorageRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _SimpleStorage.Contract.SimpleStorageTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_SimpleStorage *SimpleStorageRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _SimpleStorage.Contract.SimpleStorageTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_SimpleStorage *SimpleStorageCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _SimpleStorage.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_SimpleStorage *SimpleStorageTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _SimpleStorage.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_SimpleStorage *SimpleStorageTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _SimpleStorage.Contract.contract.Transact(opts, method, params...)
+}
+
+// AllUsers is a free data retrieval call binding the contract method 0xa2bdedf4.
+//
+// Solidity: function allUsers(uint256 ) view returns(address)
+func (_SimpleStorage *SimpleStorageCaller) AllUsers(opts *bind.CallOpts, arg0 *big.Int) (common.Address, error) {
+	var out []interface{}
+	err := _SimpleStorage.contract.Call(opts, &out, "allUsers", arg0)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// AllUsers is a free data retrieval call binding the contract method 0xa2bdedf4.
+//
+// Solidity: function allUsers(uint256 ) view returns(address)
+func (_SimpleStorage *SimpleStorageSession) AllUsers(arg0 *big.Int) (common.Address, error) {
+	return _SimpleStorage.Contract.AllUsers(&_SimpleStorage.CallOpts, arg0)
+}
+
+// AllUsers is a free data retrieval call binding the contract method 0xa2bdedf4.
+//
+// Solidity: function allUsers(uint256 ) view returns(address)
+func (_SimpleStorage *SimpleStorageCallerSession) AllUsers(arg0 *big.Int) (common.Address, error) {
+	return _SimpleStorage.Contract.AllUsers(&_SimpleStorage.CallOpts, arg0)
+}
+
+// GetAllUsers is a free data retrieval call binding the contract method 0xe2842d79.
+//
+// Solidity: function getAllUsers() view returns(address[])
+func (_SimpleStorage *SimpleStorageCaller) GetAllUsers(opts *bind.CallOpts) ([]common.Address, error) {
+	var out []interface{}
+	err := _SimpleStorage.contract.Call(opts, &out, "getAllUsers")
+
+	if err != nil {
+		return *new([]common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address)
+
+	return out0, err
+
+}
+
+// GetAllUsers is a free data retrieval call binding the contract method 0xe2842d79.
+//
+// Solidity: function getAllUsers() view returns(address[])
+func (_SimpleStorage *SimpleStorageSession) GetAllUsers() ([]common.Address, error) {
+	return _SimpleStorage.Contract.GetAllUsers(&_SimpleStorage.CallOpts)
+}
+
+// GetAllUsers is a free data retrieval call binding the contract method 0xe2842d79.
+//
+// Solidity: function getAllUsers() view returns(address[])
+func (_SimpleStorage *SimpleStorageCallerSession) GetAllUsers() ([]common.Address, error) {
+	return _SimpleStorage.Contract.GetAllUsers(&_SimpleStorage.CallOpts)
+}
+
+// GetMessage is a free data retrieval call binding the contract method 0x570c537e.
+//
+// Solidity: function getMessage(address _user, uint256 _index) view returns(string)
+func (_SimpleStorage *SimpleStorageCaller) GetMessage(opts *bind.CallOpts, _user common.Address, _index *big.Int) (string, error) {
+	var out []interface{}
+	err := _SimpleStorage.contract.Call(opts, &out, "getMessage", _user, _index)
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// GetMessage is a free data retrieval call binding the contract method 0x570c537e.
+//
+// Solidity: function getMessage(address _user, uint256 _index) view returns(string)
+func (_SimpleStorage *SimpleStorageSession) GetMessage(_user common.Address, _index *big.Int) (string, error) {
+	return _SimpleStorage.Contract.GetMessage(&_SimpleStorage.CallOpts, _user, _index)
+}
+
+// GetMessage is a free data retrieval call binding the contract method 0x570c537e.
+//
+// Solidity: function getMessage(address _user, uint256 _index) view returns(string)
+func (_SimpleStorage *SimpleStorageCallerSession) GetMessage(_user common.Address, _index *big.Int) (string, error) {
+	return _SimpleStorage.Contract.GetMessage(&_SimpleStorage.CallOpts, _user, _index)
+}
+
+// GetMessageCount is a free data retrieval call binding the contract method 0xd7363ce7.
+//
+// Solidity: function getMessageCount(address _user) view returns(uint256)
+func (_SimpleStorage *SimpleStorageCaller) GetMessageCount(opts *bind.CallOpts, _user common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _SimpleStorage.contract.Call(opts, &out, "getMessageCount", _user)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetMessageCount is a free data retrieval call binding the contract method 0xd7363ce7.
+//
+// Solidity: function getMessageCount(address _user) view returns(uint256)
+func (_SimpleStorage *SimpleStorageSession) GetMessageCount(_user common.Address) (*big.Int, error) {
+	return _SimpleStorage.Contract.GetMessageCount(&_SimpleStorage.CallOpts, _user)
+}
+
+// GetMessageCount is a free data retrieval call binding the contract method 0xd7363ce7.
+//
+// Solidity: function getMessageCount(address _user) view returns(uint256)
+func (_SimpleStorage *SimpleStorageCallerSession) GetMessageCount(_user common.Address) (*big.Int, error) {
+	return _SimpleStorage.Contract.GetMessageCount(&_SimpleStorage.CallOpts, _user)
+}
+
+// GetMyData is a free data retrieval call binding the contract method 0xd9c8a38b.
+//
+// Solidity: function getMyData() view returns(string[] messages, uint256 tokenAmount, uint256 timestamp)
+func (_SimpleStorage *SimpleStorageCaller) GetMyData(opts *bind.CallOpts) (struct {
+	Messages    []string
+	TokenAmount *big.Int
+	Timestamp   *big.Int
+}, error) {
+	var out []interface{}
+	err := _SimpleStorage.contract.Call(opts, &out, "getMyData")
+
+	outstruct := new(struct {
+		Messages    []string
+		TokenAmount *big.Int
+		Timestamp   *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Messages = *abi.ConvertType(out[0], new([]string)).(*[]string)
+	outstruct.TokenAmount = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.Timestamp = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// GetMyData is a free data retrieval call binding the contract method 0xd9c8a38b.
+//
+// Solidity: function getMyData() view returns(string[] messages, uint256 tokenAmount, uint256 timestamp)
+func (_SimpleStorage *SimpleStorageSession) GetMyData() (struct {
+	Messages    []string
+	TokenAmount *big.Int
+	Timestamp   *big.Int
+}, error) {
+	return _SimpleStorage.Contract.GetMyData(&_SimpleStorage.CallOpts)
+}
+
+// GetMyData is a free data retrieval call binding the contract method 0xd9c8a38b.
+//
+// Solidity: function getMyData() view returns(string[] messages, uint256 tokenAmount, uint256 timestamp)
+func (_SimpleStorage *SimpleStorageCallerSession) GetMyData() (struct {
+	Messages    []string
+	TokenAmount *big.Int
+	Timestamp   *big.Int
+}, error) {
+	return _SimpleStorage.Contract.GetMyData(&_SimpleStorage.CallOpts)
+}
+
+// GetUserCount is a free data retrieval call binding the contract method 0xb5cb15f7.
+//
+// Solidity: function getUserCount() view returns(uint256)
+func (_SimpleStorage *SimpleStorageCaller) GetUserCount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _SimpleStorage.contract.Call(opts, &out, "getUserCount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// GetUserCount is a free data retrieval call binding the contract method 0xb5cb15f7.
+//
+// Solidity: function getUserCount() view returns(uint256)
+func (_SimpleStorage *SimpleStorageSession) GetUserCount() (*big.Int, error) {
+	return _SimpleStorage.Contract.GetUserCount(&_SimpleStorage.CallOpts)
+}
+
+// GetUserCount is a free data retrieval call binding the contract method 0xb5cb15f7.
+//
+// Solidity: function getUserCount() view returns(uint256)
+func (_SimpleStorage *SimpleStorageCallerSession) GetUserCount() (*big.Int, error) {
+	return _SimpleStorage.Contract.GetUserCount(&_SimpleStorage.CallOpts)
+}
+
+// GetUserData is a free data retrieval call binding the contract method 0xffc9896b.
+//
+// Solidity: function getUserData(address _user) view returns(string[] messages, uint256 tokenAmount, address userAddress, uint256 timestamp)
+func (_SimpleStorage *SimpleStorageCaller) GetUserData(opts *bind.CallOpts, _user common.Address) (struct {
+	Messages    []string
+	TokenAmount *big.Int
+	UserAddress common.Address
+	Timestamp   *big.Int
+}, error) {
+	var out []interface{}
+	err := _SimpleStorage.contract.Call(opts, &out, "getUserData", _user)
+
+	outstruct := new(struct {
+		Messages    []string
+		TokenAmount *big.Int
+		UserAddress common.Address
+		Timestamp   *big.Int
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Messages = *abi.ConvertType(out[0], new([]string)).(*[]string)
+	outstruct.TokenAmount = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.UserAddress = *abi.ConvertType(out[2], new(common.Address)).(*common.Address)
+	outstruct.Timestamp = *abi.ConvertType(out[3], new(*big.Int)).(**big.Int)
+
+	return *outstruct, err
+
+}
+
+// GetUserData is a free data retrieval call binding the contract method 0xffc9896b.
+//
+// Solidity: function getUserData(address _user) view returns(string[] messages, uint256 tokenAmount, address userAddress, uint256 timestamp)
+func (_SimpleStorage *SimpleStorageSession) GetUserData(_user common.Address) (struct {
+	Messages    []string
+	TokenAmount *big.Int
+	UserAddress common.Address
+	Timestamp   *big.Int
+}, error) {
+	return _SimpleStorage.Contract.GetUserData(&_SimpleStorage.CallOpts, _user)
+}
+
+// GetUserData is a free data retrieval call binding the contract method 0xffc9896b.
+//
+// Solidity: function getUserData(address _user) view returns(string[] messages, uint256 tokenAmount, address userAddress, uint256 timestamp)
+func (_SimpleStorage *SimpleStorageCallerSession) GetUserData(_user common.Address) (struct {
+	Messages    []string
+	TokenAmount *big.Int
+	UserAddress common.Address
+	Timestamp   *big.Int
+}, error) {
+	return _SimpleStorage.Contract.GetUserData(&_SimpleStorage.CallOpts, _user)
+}
+
+// UserData is a free data retrieval call binding the contract method 0xc8910913.
+//
+// Solidity: function userData(address ) view returns(uint256 tokenAmount, address userAddress, uint256 timestamp, bool exists)
+func (_SimpleStorage *SimpleStorageCaller) UserData(opts *bind.CallOpts, arg0 common.Address) (struct {
+	TokenAmount *big.Int
+	UserAddress common.Address
+	Timestamp   *big.Int
+	Exists      bool
+}, error) {
+	var out []interface{}
+	err := _SimpleStorage.contract.Call(opts, &out, "userData", arg0)
+
+	outstruct := new(struct {
+		TokenAmount *big.Int
+		UserAddress common.Address
+		Timestamp   *big.Int
+		Exists      bool
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.TokenAmount = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.UserAddress = *abi.ConvertType(out[1], new(common.Address)).(*common.Address)
+	outstruct.Timestamp = *abi.ConvertType(out[2], new(*big.Int)).(**big.Int)
+	outstruct.Exists = *abi.ConvertType(out[3], new(bool)).(*bool)
+
+	return *outstruct, err
+
+}
+
+// UserData is a free data retrieval call binding the contract method 0xc8910913.
+//
+// Solidity: function userData(address ) view returns(uint256 tokenAmount, address userAddress, uint256 timestamp, bool exists)
+func (_SimpleStorage *SimpleStorageSession) UserData(arg0 common.Address) (struct {
+	TokenAmount *big.Int
+	UserAddress common.Address
+	Timestamp   *big.Int
+	Exists      bool
+}, error) {
+	return _SimpleStorage.Contract.UserData(&_SimpleStorage.CallOpts, arg0)
+}
+
+// UserData is a free data retrieval call binding the contract method 0xc8910913.
+//
+// Solidity: function userData(address ) view returns(uint256 tokenAmount, address userAddress, uint256 timestamp, bool exists)
+func (_SimpleStorage *SimpleStorageCallerSession) UserData(arg0 common.Address) (struct {
+	TokenAmount *big.Int
+	UserAddress common.Address
+	Timestamp   *big.Int
+	Exists      bool
+}, error) {
+	return _SimpleStorage.Contract.UserData(&_SimpleStorage.CallOpts, arg0)
+}
+
+// UserExists is a free data retrieval call binding the contract method 0x0e666e49.
+//
+// Solidity: function userExists(address _user) view returns(bool)
+func (_SimpleStorage *SimpleStorageCaller) UserExists(opts *bind.CallOpts, _user common.Address) (bool, error) {
+	var out []interface{}
+	err := _SimpleStorage.contract.Call(opts, &out, "userExists", _user)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// UserExists is a free data retrieval call binding the contract method 0x0e666e49.
+//
+// Solidity: function userExists(address _user) view returns(bool)
+func (_SimpleStorage *SimpleStorageSession) UserExists(_user common.Address) (bool, error) {
+	return _SimpleStorage.Contract.UserExists(&_SimpleStorage.CallOpts, _user)
+}
+
+// UserExists is a free data retrieval call binding the contract method 0x0e666e49.
+//
+// Solidity: function userExists(address _user) view returns(bool)
+func (_SimpleStorage *SimpleStorageCallerSession) UserExists(_user common.Address) (bool, error) {
+	return _SimpleStorage.Contract.UserExists(&_SimpleStorage.CallOpts, _user)
+}
+
+// AddMessage is a paid mutator transaction binding the contract method 0x6a226a49.
+//
+// Solidity: function addMessage(string _message) returns()
+func (_SimpleStorage *SimpleStorageTransactor) AddMessage(opts *bind.TransactOpts, _message string) (*types.Transaction, error) {
+	return _SimpleStorage.contract.Transact(opts, "addMessage", _message)
+}
+
+// AddMessage is a paid mutator transaction binding the contract method 0x6a226a49.
+//
+// Solidity: function addMessage(string _message) returns()
+func (_SimpleStorage *SimpleStorageSession) AddMessage(_message string) (*types.Transaction, error) {
+	return _SimpleStorage.Contract.AddMessage(&_SimpleStorage.TransactOpts, _message)
+}
+
+// AddMessage is a paid mutator transaction binding the contract method 0x6a226a49.
+//
+// Solidity: function addMessage(string _message) returns()
+func (_SimpleStorage *SimpleStorageTransactorSession) AddMessage(_message string) (*types.Transaction, error) {
+	return _SimpleStorage.Contract.AddMessage(&_SimpleStorage.TransactOpts, _message)
+}
+
+// StoreData is a paid mutator transaction binding the contract method 0x8e025bac.
+//
+// Solidity: function storeData(string[] _messages, uint256 _tokenAmount) returns()
+func (_SimpleStorage *SimpleStorageTransactor) StoreData(opts *bind.TransactOpts, _messages []string, _tokenAmount *big.Int) (*types.Transaction, error) {
+	return _SimpleStorage.contract.Transact(opts, "storeData", _messages, _tokenAmount)
+}
+
+// StoreData is a paid mutator transaction binding the contract method 0x8e025bac.
+//
+// Solidity: function storeData(string[] _messages, uint256 _tokenAmount) returns()
+func (_SimpleStorage *SimpleStorageSession) StoreData(_messages []string, _tokenAmount *big.Int) (*types.Transaction, error) {
+	return _SimpleStorage.Contract.StoreData(&_SimpleStorage.TransactOpts, _messages, _tokenAmount)
+}
+
+// StoreData is a paid mutator transaction binding the contract method 0x8e025bac.
+//
+// Solidity: function storeData(string[] _messages, uint256 _tokenAmount) returns()
+func (_SimpleStorage *SimpleStorageTransactorSession) StoreData(_messages []string, _tokenAmount *big.Int) (*types.Transaction, error) {
+	return _SimpleStorage.Contract.StoreData(&_SimpleStorage.TransactOpts, _messages, _tokenAmount)
+}
+
+// UpdateTokenAmount is a paid mutator transaction binding the contract method 0x61a5c4bd.
+//
+// Solidity: function updateTokenAmount(uint256 _newAmount) returns()
+func (_SimpleStorage *SimpleStorageTransactor) UpdateTokenAmount(opts *bind.TransactOpts, _newAmount *big.Int) (*types.Transaction, error) {
+	return _SimpleStorage.contract.Transact(opts, "updateTokenAmount", _newAmount)
+}
+
+// UpdateTokenAmount is a paid mutator transaction binding the contract method 0x61a5c4bd.
+//
+// Solidity: function updateTokenAmount(uint256 _newAmount) returns()
+func (_SimpleStorage *SimpleStorageSession) UpdateTokenAmount(_newAmount *big.Int) (*types.Transaction, error) {
+	return _SimpleStorage.Contract.UpdateTokenAmount(&_SimpleStorage.TransactOpts, _newAmount)
+}
+
+// UpdateTokenAmount is a paid mutator transaction binding the contract method 0x61a5c4bd.
+//
+// Solidity: function updateTokenAmount(uint256 _newAmount) returns()
+func (_SimpleStorage *SimpleStorageTransactorSession) UpdateTokenAmount(_newAmount *big.Int) (*types.Transaction, error) {
+	return _SimpleStorage.Contract.UpdateTokenAmount(&_SimpleStorage.TransactOpts, _newAmount)
+}
+
+// SimpleStorageDataStoredIterator is returned from FilterDataStored and is used to iterate over the raw logs and unpacked data for DataStored events raised by the SimpleStorage contract.
+type SimpleStorageDataStoredIterator struct {
+	Event *SimpleStorageDataStored // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *SimpleStorageDataStoredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(SimpleStorageDataStored)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(SimpleStorageDataStored)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *SimpleStorageDataStoredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *SimpleStorageDataStoredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// SimpleStorageDataStored represents a DataStored event raised by the SimpleStorage contract.
+type SimpleStorageDataStored struct {
+	User        common.Address
+	Messages    []string
+	TokenAmount *big.Int
+	Timestamp   *big.Int
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterDataStored is a free log retrieval operation binding the contract event 0xf39082d36b02f0fbdf3cad1871ca053244ee7b8391680b58d79b219930590056.
+//
+// Solidity: event DataStored(address indexed user, string[] messages, uint256 tokenAmount, uint256 timestamp)
+func (_SimpleStorage *SimpleStorageFilterer) FilterDataStored(opts *bind.FilterOpts, user []common.Address) (*SimpleStorageDataStoredIterator, error) {
+
+	var userRule []interface{}
+	for _, userItem := range user {
+		userRule = append(userRule, userItem)
+	}
+
+	logs, sub, err := _SimpleStorage.contract.FilterLogs(opts, "DataStored", userRule)
+	if err != nil {
+		return nil, err
+	}
+	return &SimpleStorageDataStoredIterator{contract: _SimpleStorage.contract, event: "DataStored", logs: logs, sub: sub}, nil
+}
+
+// WatchDataStored is a free log subscription operation binding the contract event 0xf39082d36b02f0fbdf3cad1871ca053244ee7b8391680b58d79b219930590056.
+//
+// Solidity: event DataStored(address indexed user, string[] messages, uint256 tokenAmount, uint256 timestamp)
+func (_SimpleStorage *SimpleStorageFilterer) WatchDataStored(opts *bind.WatchOpts, sink chan<- *SimpleStorageDataStored, user []common.Address) (event.Subscription, error) {
+
+	var userRule []interface{}
+	for _, userItem := range user {
+		userRule = append(userRule, userItem)
+	}
+
+	logs, sub, err := _SimpleStorage.contract.WatchLogs(opts, "DataStored", userRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(SimpleStorageDataStored)
+				if err := _SimpleStorage.contract.UnpackLog(event, "DataStored", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDataStored is a log parse operation binding the contract event 0xf39082d36b02f0fbdf3cad1871ca053244ee7b8391680b58d79b219930590056.
+//
+// Solidity: event DataStored(address indexed user, string[] messages, uint256 tokenAmount, uint256 timestamp)
+func (_SimpleStorage *SimpleStorageFilterer) ParseDataStored(log types.Log) (*SimpleStorageDataStored, error) {
+	event := new(SimpleStorageDataStored)
+	if err := _SimpleStorage.contract.UnpackLog(event, "DataStored", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// SimpleStorageMessageAddedIterator is returned from FilterMessageAdded and is used to iterate over the raw logs and unpacked data for MessageAdded events raised by the SimpleStorage contract.
+type SimpleStorageMessageAddedIterator struct {
+	Event *SimpleStorageMessageAdded // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *SimpleStorageMessageAddedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(SimpleStorageMessageAdded)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(SimpleStorageMessageAdded)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *SimpleStorageMessageAddedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *SimpleStorageMessageAddedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// SimpleStorageMessageAdded represents a MessageAdded event raised by the SimpleStorage contract.
+type SimpleStorageMessageAdded struct {
+	User      common.Address
+	Message   string
+	Timestamp *big.Int
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterMessageAdded is a free log retrieval operation binding the contract event 0x94bdb93b50aad3705cb01458762a1c8d39d1e0927a128a68db537b848c925dbe.
+//
+// Solidity: event MessageAdded(address indexed user, string message, uint256 timestamp)
+func (_SimpleStorage *SimpleStorageFilterer) FilterMessageAdded(opts *bind.FilterOpts, user []common.Address) (*SimpleStorageMessageAddedIterator, error) {
+
+	var userRule []interface{}
+	for _, userItem := range user {
+		userRule = append(userRule, userItem)
+	}
+
+	logs, sub, err := _SimpleStorage.contract.FilterLogs(opts, "MessageAdded", userRule)
+	if err != nil {
+		return nil, err
+	}
+	return &SimpleStorageMessageAddedIterator{contract: _SimpleStorage.contract, event: "MessageAdded", logs: logs, sub: sub}, nil
+}
+
+// WatchMessageAdded is a free log subscription operation binding the contract event 0x94bdb93b50aad3705cb01458762a1c8d39d1e0927a128a68db537b848c925dbe.
+//
+// Solidity: event MessageAdded(address indexed user, string message, uint256 timestamp)
+func (_SimpleStorage *SimpleStorageFilterer) WatchMessageAdded(opts *bind.WatchOpts, sink chan<- *SimpleStorageMessageAdded, user []common.Address) (event.Subscription, error) {
+
+	var userRule []interface{}
+	for _, userItem := range user {
+		userRule = append(userRule, userItem)
+	}
+
+	logs, sub, err := _SimpleStorage.contract.WatchLogs(opts, "MessageAdded", userRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(SimpleStorageMessageAdded)
+				if err := _SimpleStorage.contract.UnpackLog(event, "MessageAdded", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseMessageAdded is a log parse operation binding the contract event 0x94bdb93b50aad3705cb01458762a1c8d39d1e0927a128a68db537b848c925dbe.
+//
+// Solidity: event MessageAdded(address indexed user, string message, uint256 timestamp)
+func (_SimpleStorage *SimpleStorageFilterer) ParseMessageAdded(log types.Log) (*SimpleStorageMessageAdded, error) {
+	event := new(SimpleStorageMessageAdded)
+	if err := _SimpleStorage.contract.UnpackLog(event, "MessageAdded", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// SimpleStorageTokenAmountUpdatedIterator is returned from FilterTokenAmountUpdated and is used to iterate over the raw logs and unpacked data for TokenAmountUpdated events raised by the SimpleStorage contract.
+type SimpleStorageTokenAmountUpdatedIterator struct {
+	Event *SimpleStorageTokenAmountUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *SimpleStorageTokenAmountUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(SimpleStorageTokenAmountUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(SimpleStorageTokenAmountUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *SimpleStorageTokenAmountUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *SimpleStorageTokenAmountUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// SimpleStorageTokenAmountUpdated represents a TokenAmountUpdated event raised by the SimpleStorage contract.
+type SimpleStorageTokenAmountUpdated struct {
+	User      common.Address
+	OldAmount *big.Int
+	NewAmount *big.Int
+	Timestamp *big.Int
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterTokenAmountUpdated is a free log retrieval operation binding the contract event 0xb1ed35a269b471d4400b62d3cf3e11b9d216aa55dc61fa19e7016dbbc1f118ff.
+//
+// Solidity: event TokenAmountUpdated(address indexed user, uint256 oldAmount, uint256 newAmount, uint256 timestamp)
+func (_SimpleStorage *SimpleStorageFilterer) FilterTokenAmountUpdated(opts *bind.FilterOpts, user []common.Address) (*SimpleStorageTokenAmountUpdatedIterator, error) {
+
+	var userRule []interface{}
+	for _, userItem := range user {
+		userRule = append(userRule, userItem)
+	}
+
+	logs, sub, err := _SimpleStorage.contract.FilterLogs(opts, "TokenAmountUpdated", userRule)
+	if err != nil {
+		return nil, err
+	}
+	return &SimpleStorageTokenAmountUpdatedIterator{contract: _SimpleStorage.contract, event: "TokenAmountUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchTokenAmountUpdated is a free log subscription operation binding the contract event 0xb1ed35a269b471d4400b62d3cf3e11b9d216aa55dc61fa19e7016dbbc1f118ff.
+//
+// Solidity: event TokenAmountUpdated(address indexed user, uint256 oldAmount, uint256 newAmount, uint256 timestamp)
+func (_SimpleStorage *SimpleStorageFilterer) WatchTokenAmountUpdated(opts *bind.WatchOpts, sink chan<- *SimpleStorageTokenAmountUpdated, user []common.Address) (event.Subscription, error) {
+
+	var userRule []interface{}
+	for _, userItem := range user {
+		userRule = append(userRule, userItem)
+	}
+
+	logs, sub, err := _SimpleStorage.contract.WatchLogs(opts, "TokenAmountUpdated", userRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(SimpleStorageTokenAmountUpdated)
+				if err := _SimpleStorage.contract.UnpackLog(event, "TokenAmountUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTokenAmountUpdated is a log parse operation binding the contract event 0xb1ed35a269b471d4400b62d3cf3e11b9d216aa55dc61fa19e7016dbbc1f118ff.
+//
+// Solidity: event TokenAmountUpdated(address indexed user, uint256 oldAmount, uint256 newAmount, uint256 timestamp)
+func (_SimpleStorage *SimpleStorageFilterer) ParseTokenAmountUpdated(log types.Log) (*SimpleStorageTokenAmountUpdated, error) {
+	event := new(SimpleStorageTokenAmountUpdated)
+	if err := _SimpleStorage.contract.UnpackLog(event, "TokenAmountUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
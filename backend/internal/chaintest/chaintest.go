@@ -0,0 +1,323 @@
+// Package chaintest gives tests a local dev chain with the contracts
+// deployed from their Hardhat artifacts.
+//
+// Tests that need one call New, which skips the test unless
+// TEST_CHAIN_RPC_URL points at a Hardhat or anvil node, e.g. one started with
+// `npx hardhat node`. Tests that relay between two chains call Destination
+// as well, which connects to a second node at TEST_DESTINATION_CHAIN_RPC_URL
+// and skips the test when there is none. Each test takes an evm_snapshot when it starts and
+// reverts to it when it finishes. Contracts are deployed from the artifacts
+// in TEST_CONTRACT_ARTIFACTS (default ../../../smart-contracts/artifacts, as
+// seen from a package under internal/); tests that deploy contracts are
+// skipped until `npx hardhat compile` has been run.
+package chaintest
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"aa-sharing-backend/internal/chain"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// hardhatKey is the first of the well-known development accounts that
+// Hardhat and anvil fund at startup. TEST_CHAIN_PRIVATE_KEY overrides it.
+const hardhatKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+// Chain is a dev chain for one test.
+type Chain struct {
+	Client *chain.Client
+	// Deployer is a funded account that deploys contracts and funds the
+	// accounts created by Account.
+	Deployer *ecdsa.PrivateKey
+}
+
+// Snapshot is a point of the chain a test can go back to with Revert.
+type Snapshot string
+
+// New connects to the dev chain and reverts it when the test finishes.
+func New(t testing.TB) *Chain {
+	t.Helper()
+	url := os.Getenv("TEST_CHAIN_RPC_URL")
+	if url == "" {
+		t.Skip("TEST_CHAIN_RPC_URL is not set")
+	}
+	return dial(t, url)
+}
+
+// Destination connects to a second dev chain for tests of cross-chain
+// relaying and reverts it when the test finishes. The test is skipped unless
+// TEST_DESTINATION_CHAIN_RPC_URL names a node other than the one of New: a
+// relay within one chain would not exercise the cross-chain path.
+func Destination(t testing.TB) *Chain {
+	t.Helper()
+	url := os.Getenv("TEST_DESTINATION_CHAIN_RPC_URL")
+	if url == "" {
+		t.Skip("TEST_DESTINATION_CHAIN_RPC_URL is not set")
+	}
+	if url == os.Getenv("TEST_CHAIN_RPC_URL") {
+		t.Skip("TEST_DESTINATION_CHAIN_RPC_URL is the source chain")
+	}
+	return dial(t, url)
+}
+
+func dial(t testing.TB, url string) *Chain {
+	t.Helper()
+	client, err := chain.Dial(context.Background(), url)
+	if err != nil {
+		t.Fatalf("connect to test chain: %v", err)
+	}
+	hexKey := os.Getenv("TEST_CHAIN_PRIVATE_KEY")
+	if hexKey == "" {
+		hexKey = hardhatKey
+	}
+	deployer, err := crypto.HexToECDSA(strings.TrimPrefix(hexKey, "0x"))
+	if err != nil {
+		t.Fatalf("TEST_CHAIN_PRIVATE_KEY: %v", err)
+	}
+
+	c := &Chain{Client: client, Deployer: deployer}
+	start := c.Snapshot(t)
+	t.Cleanup(func() {
+		c.Revert(t, start)
+		client.Close()
+	})
+	return c
+}
+
+// Mine adds a block.
+func (c *Chain) Mine(t testing.TB) {
+	t.Helper()
+	if err := c.rpc("evm_mine", nil); err != nil {
+		t.Fatalf("evm_mine: %v", err)
+	}
+}
+
+// MineBlocks adds n blocks.
+func (c *Chain) MineBlocks(t testing.TB, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		c.Mine(t)
+	}
+}
+
+// Mined fails the test if tx could not be sent or reverted, and returns its
+// receipt otherwise. It is meant to wrap binding calls:
+//
+//	receipt := c.Mined(t)(contract.DepositFunds(opts, id, amount))
+func (c *Chain) Mined(t testing.TB) func(*types.Transaction, error) *types.Receipt {
+	return func(tx *types.Transaction, err error) *types.Receipt {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("send transaction: %v", err)
+		}
+		receipt := c.Receipt(t, tx.Hash())
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			t.Fatalf("transaction %s reverted", tx.Hash().Hex())
+		}
+		return receipt
+	}
+}
+
+// Receipt waits for the receipt of hash. Dev nodes mine transactions as they
+// arrive, so it is normally there at once.
+func (c *Chain) Receipt(t testing.TB, hash common.Hash) *types.Receipt {
+	t.Helper()
+	ctx := context.Background()
+	for deadline := time.Now().Add(10 * time.Second); ; {
+		receipt, err := c.Client.TransactionReceipt(ctx, hash)
+		if err == nil {
+			return receipt
+		}
+		if !errors.Is(err, ethereum.NotFound) || time.Now().After(deadline) {
+			t.Fatalf("receipt of %s: %v", hash.Hex(), err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// Snapshot records the current state of the chain.
+func (c *Chain) Snapshot(t testing.TB) Snapshot {
+	t.Helper()
+	var id string
+	if err := c.rpc("evm_snapshot", &id); err != nil {
+		t.Fatalf("evm_snapshot: %v", err)
+	}
+	return Snapshot(id)
+}
+
+// Revert drops every block mined since s was taken. A snapshot can be
+// reverted to once. Blocks mined afterwards form a new branch, so reverting
+// and then mining more blocks than were dropped is a chain reorganization
+// as far as the node's clients can tell.
+func (c *Chain) Revert(t testing.TB, s Snapshot) {
+	t.Helper()
+	var ok bool
+	if err := c.rpc("evm_revert", &ok, string(s)); err != nil || !ok {
+		t.Fatalf("evm_revert %s: %v", s, err)
+	}
+}
+
+func (c *Chain) rpc(method string, result interface{}, args ...interface{}) error {
+	return c.Client.Client.Client().CallContext(context.Background(), result, method, args...)
+}
+
+// Transactor returns options that send transactions from key.
+func (c *Chain) Transactor(t testing.TB, key *ecdsa.PrivateKey) *bind.TransactOpts {
+	t.Helper()
+	opts, err := c.Client.Transactor(context.Background(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return opts
+}
+
+// Account returns a new account funded with 100 ETH for gas.
+func (c *Chain) Account(t testing.TB) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Fund(t, crypto.PubkeyToAddress(key.PublicKey), new(big.Int).Mul(big.NewInt(100), big.NewInt(params.Ether)))
+	return key
+}
+
+// Fund sends amount wei from the deployer to address.
+func (c *Chain) Fund(t testing.TB, address common.Address, amount *big.Int) {
+	t.Helper()
+	ctx := context.Background()
+	from := crypto.PubkeyToAddress(c.Deployer.PublicKey)
+	nonce, err := c.Client.PendingNonceAt(ctx, from)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tip, err := c.Client.SuggestGasTipCap(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	head, err := c.Client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainID, _ := c.Client.ChainID(ctx)
+	tx, err := types.SignNewTx(c.Deployer, types.LatestSignerForChainID(chainID), &types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     nonce,
+		GasTipCap: tip,
+		GasFeeCap: new(big.Int).Add(tip, new(big.Int).Mul(head.BaseFee, big.NewInt(2))),
+		Gas:       params.TxGas,
+		To:        &address,
+		Value:     amount,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Mined(t)(tx, c.Client.SendTransaction(ctx, tx))
+}
+
+// Deploy deploys the contract name from its Hardhat artifact, signed by the
+// deployer, and returns its address and a generic binding.
+func (c *Chain) Deploy(t testing.TB, name string, args ...interface{}) (common.Address, *bind.BoundContract) {
+	t.Helper()
+	parsed, bytecode := artifact(t, name)
+	address, tx, contract, err := bind.DeployContract(c.Transactor(t, c.Deployer), parsed, bytecode, c.Client, args...)
+	c.Mined(t)(tx, err)
+	return address, contract
+}
+
+// artifact reads the ABI and bytecode of a contract compiled by Hardhat.
+func artifact(t testing.TB, name string) (abi.ABI, []byte) {
+	t.Helper()
+	dir := os.Getenv("TEST_CONTRACT_ARTIFACTS")
+	if dir == "" {
+		dir = filepath.Join("..", "..", "..", "smart-contracts", "artifacts")
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "contracts", name+".sol", name+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		t.Skipf("no Hardhat artifact for %s in %s; run `npx hardhat compile` in smart-contracts", name, dir)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var a struct {
+		ABI      json.RawMessage `json:"abi"`
+		Bytecode string          `json:"bytecode"`
+	}
+	if err := json.Unmarshal(data, &a); err != nil {
+		t.Fatalf("parse %s artifact: %v", name, err)
+	}
+	parsed, err := abi.JSON(strings.NewReader(string(a.ABI)))
+	if err != nil {
+		t.Fatalf("parse %s ABI: %v", name, err)
+	}
+	bytecode, err := hexutil.Decode(a.Bytecode)
+	if err != nil {
+		t.Fatalf("parse %s bytecode: %v", name, err)
+	}
+	return parsed, bytecode
+}
+
+// AASharing is an AASharing contract deployed with a MockUSDC token.
+type AASharing struct {
+	*chain.AASharing
+	Address common.Address
+	USDC    common.Address
+	Token   *bind.BoundContract
+
+	chain *Chain
+}
+
+// DeployAASharing deploys a 6-decimal MockUSDC and an AASharing contract
+// that holds it.
+func (c *Chain) DeployAASharing(t testing.TB) *AASharing {
+	t.Helper()
+	usdc, token := c.Deploy(t, "MockUSDC", "USD Coin", "USDC", uint8(6))
+	address, _ := c.Deploy(t, "AASharing", usdc)
+	contract, err := chain.NewAASharing(address, c.Client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &AASharing{AASharing: contract, Address: address, USDC: usdc, Token: token, chain: c}
+}
+
+// Fund mints amount USDC base units to key's account and approves the
+// AASharing contract to spend them.
+func (a *AASharing) Fund(t testing.TB, key *ecdsa.PrivateKey, amount int64) {
+	t.Helper()
+	owner := crypto.PubkeyToAddress(key.PublicKey)
+	a.chain.Mined(t)(a.Token.Transact(a.chain.Transactor(t, a.chain.Deployer), "mint", owner, big.NewInt(amount)))
+	a.chain.Mined(t)(a.Token.Transact(a.chain.Transactor(t, key), "approve", a.Address, big.NewInt(amount)))
+}
+
+// CreatePartnership creates a partnership between the accounts of partner1
+// and partner2 and returns its on-chain ID.
+func (a *AASharing) CreatePartnership(t testing.TB, partner1, partner2 *ecdsa.PrivateKey) *big.Int {
+	t.Helper()
+	receipt := a.chain.Mined(t)(a.AASharing.CreatePartnership(a.chain.Transactor(t, partner1),
+		crypto.PubkeyToAddress(partner2.PublicKey), "Alice", "Bob"))
+	for _, l := range receipt.Logs {
+		if event, err := a.ParsePartnershipCreated(*l); err == nil {
+			return event.PartnershipId
+		}
+	}
+	t.Fatal("no PartnershipCreated event")
+	return nil
+}
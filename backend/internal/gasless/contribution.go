@@ -0,0 +1,156 @@
+package gasless
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Name and version of the EIP712 domain of the AASharing contract.
+const (
+	DomainName    = "AASharing"
+	DomainVersion = "1"
+)
+
+var ErrInvalidSignature = errors.New("invalid contribution signature")
+
+var (
+	domainTypeHash       = crypto.Keccak256Hash([]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"))
+	contributionTypeHash = crypto.Keccak256Hash([]byte("Contribution(uint256 partnershipId,address contributor,uint256 amount,uint256 nonce,uint256 deadline)"))
+)
+
+// Domain is an EIP-712 domain without a salt, the kind used by the AASharing
+// contract and by EIP-2612 tokens.
+type Domain struct {
+	Name              string
+	Version           string
+	ChainID           *big.Int
+	VerifyingContract common.Address
+}
+
+// Separator returns the domain separator, as computed by OpenZeppelin's
+// EIP712 and returned by DOMAIN_SEPARATOR.
+func (d *Domain) Separator() common.Hash {
+	return crypto.Keccak256Hash(
+		domainTypeHash.Bytes(),
+		crypto.Keccak256([]byte(d.Name)),
+		crypto.Keccak256([]byte(d.Version)),
+		math.U256Bytes(new(big.Int).Set(d.ChainID)),
+		common.LeftPadBytes(d.VerifyingContract.Bytes(), 32),
+	)
+}
+
+// hash returns the digest that is signed for a message with structHash.
+func (d *Domain) hash(structHash common.Hash) common.Hash {
+	return crypto.Keccak256Hash([]byte("\x19\x01"), d.Separator().Bytes(), structHash.Bytes())
+}
+
+// typedData returns an eth_signTypedData_v4 request for a message of
+// primaryType in the domain.
+func (d *Domain) typedData(primaryType string, fields []map[string]string, message map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"types": map[string]interface{}{
+			"EIP712Domain": []map[string]string{
+				{"name": "name", "type": "string"},
+				{"name": "version", "type": "string"},
+				{"name": "chainId", "type": "uint256"},
+				{"name": "verifyingContract", "type": "address"},
+			},
+			primaryType: fields,
+		},
+		"primaryType": primaryType,
+		"domain": map[string]interface{}{
+			"name":              d.Name,
+			"version":           d.Version,
+			"chainId":           d.ChainID.String(),
+			"verifyingContract": d.VerifyingContract.Hex(),
+		},
+		"message": message,
+	}
+}
+
+// contractDomain returns the domain of the AASharing contract deployed at
+// verifyingContract on chainID.
+func contractDomain(chainID *big.Int, verifyingContract common.Address) *Domain {
+	return &Domain{Name: DomainName, Version: DomainVersion, ChainID: chainID, VerifyingContract: verifyingContract}
+}
+
+// Contribution is the EIP-712 message a partner signs to let the relayer
+// deposit on their behalf through depositFundsWithSignature.
+type Contribution struct {
+	PartnershipID *big.Int
+	Contributor   common.Address
+	Amount        *big.Int
+	Nonce         *big.Int
+	Deadline      *big.Int // unix seconds
+}
+
+// Digest returns the EIP-712 hash of the contribution for the AASharing
+// contract deployed at verifyingContract on chainID, as computed by
+// _hashTypedDataV4.
+func (c *Contribution) Digest(chainID *big.Int, verifyingContract common.Address) common.Hash {
+	return contractDomain(chainID, verifyingContract).hash(crypto.Keccak256Hash(
+		contributionTypeHash.Bytes(),
+		math.U256Bytes(new(big.Int).Set(c.PartnershipID)),
+		common.LeftPadBytes(c.Contributor.Bytes(), 32),
+		math.U256Bytes(new(big.Int).Set(c.Amount)),
+		math.U256Bytes(new(big.Int).Set(c.Nonce)),
+		math.U256Bytes(new(big.Int).Set(c.Deadline)),
+	))
+}
+
+// Signer recovers the address that signed the contribution.
+func (c *Contribution) Signer(chainID *big.Int, verifyingContract common.Address, signature []byte) (common.Address, error) {
+	signer, ok := recoverSigner(c.Digest(chainID, verifyingContract), signature)
+	if !ok {
+		return common.Address{}, ErrInvalidSignature
+	}
+	return signer, nil
+}
+
+// TypedData returns the contribution in the eth_signTypedData_v4 format,
+// ready for a wallet to sign. Numbers are decimal strings.
+func (c *Contribution) TypedData(chainID *big.Int, verifyingContract common.Address) map[string]interface{} {
+	return contractDomain(chainID, verifyingContract).typedData("Contribution", []map[string]string{
+		{"name": "partnershipId", "type": "uint256"},
+		{"name": "contributor", "type": "address"},
+		{"name": "amount", "type": "uint256"},
+		{"name": "nonce", "type": "uint256"},
+		{"name": "deadline", "type": "uint256"},
+	}, map[string]interface{}{
+		"partnershipId": c.PartnershipID.String(),
+		"contributor":   c.Contributor.Hex(),
+		"amount":        c.Amount.String(),
+		"nonce":         c.Nonce.String(),
+		"deadline":      c.Deadline.String(),
+	})
+}
+
+// recoverSigner recovers the address that signed digest. Wallets produce
+// signatures with a V of 27 or 28; 0 and 1 are accepted as well. Signatures
+// with a high S value are rejected, like OpenZeppelin's ECDSA.recover does.
+func recoverSigner(digest common.Hash, signature []byte) (common.Address, bool) {
+	if len(signature) != crypto.SignatureLength {
+		return common.Address{}, false
+	}
+	sig := make([]byte, crypto.SignatureLength)
+	copy(sig, signature)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	r := new(big.Int).SetBytes(sig[:32])
+	s := new(big.Int).SetBytes(sig[32:64])
+	if !crypto.ValidateSignatureValues(sig[crypto.RecoveryIDOffset], r, s, true) {
+		return common.Address{}, false
+	}
+
+	pub, err := crypto.SigToPub(digest.Bytes(), sig)
+	if err != nil {
+		return common.Address{}, false
+	}
+	return crypto.PubkeyToAddress(*pub), true
+}
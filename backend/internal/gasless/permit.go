@@ -0,0 +1,79 @@
+package gasless
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var ErrInvalidPermit = errors.New("invalid permit signature")
+
+var permitTypeHash = crypto.Keccak256Hash([]byte("Permit(address owner,address spender,uint256 value,uint256 nonce,uint256 deadline)"))
+
+// Permit is the EIP-2612 message a partner signs along with a contribution,
+// so that depositFundsWithSignature can approve its own transfer of the
+// contributor's USDC.
+type Permit struct {
+	Owner    common.Address
+	Spender  common.Address
+	Value    *big.Int
+	Nonce    *big.Int // nonces of the owner in the token
+	Deadline *big.Int // unix seconds
+}
+
+// Digest returns the EIP-712 hash of the permit in the token's domain.
+func (p *Permit) Digest(domain *Domain) common.Hash {
+	return domain.hash(crypto.Keccak256Hash(
+		permitTypeHash.Bytes(),
+		common.LeftPadBytes(p.Owner.Bytes(), 32),
+		common.LeftPadBytes(p.Spender.Bytes(), 32),
+		math.U256Bytes(new(big.Int).Set(p.Value)),
+		math.U256Bytes(new(big.Int).Set(p.Nonce)),
+		math.U256Bytes(new(big.Int).Set(p.Deadline)),
+	))
+}
+
+// Signer recovers the address that signed the permit.
+func (p *Permit) Signer(domain *Domain, signature []byte) (common.Address, error) {
+	signer, ok := recoverSigner(p.Digest(domain), signature)
+	if !ok {
+		return common.Address{}, ErrInvalidPermit
+	}
+	return signer, nil
+}
+
+// TypedData returns the permit in the eth_signTypedData_v4 format.
+func (p *Permit) TypedData(domain *Domain) map[string]interface{} {
+	return domain.typedData("Permit", []map[string]string{
+		{"name": "owner", "type": "address"},
+		{"name": "spender", "type": "address"},
+		{"name": "value", "type": "uint256"},
+		{"name": "nonce", "type": "uint256"},
+		{"name": "deadline", "type": "uint256"},
+	}, map[string]interface{}{
+		"owner":    p.Owner.Hex(),
+		"spender":  p.Spender.Hex(),
+		"value":    p.Value.String(),
+		"nonce":    p.Nonce.String(),
+		"deadline": p.Deadline.String(),
+	})
+}
+
+// splitSignature returns the V, R and S of a 65-byte signature as the token's
+// permit takes them, with V as 27 or 28.
+func splitSignature(signature []byte) (uint8, [32]byte, [32]byte, error) {
+	var r, s [32]byte
+	if len(signature) != crypto.SignatureLength {
+		return 0, r, s, ErrInvalidPermit
+	}
+	copy(r[:], signature[:32])
+	copy(s[:], signature[32:64])
+	v := signature[crypto.RecoveryIDOffset]
+	if v < 27 {
+		v += 27
+	}
+	return v, r, s, nil
+}
@@ -0,0 +1,87 @@
+package gasless
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	"aa-sharing-backend/internal/chain"
+	"aa-sharing-backend/internal/chaintest"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func sign(t *testing.T, key *ecdsa.PrivateKey, digest common.Hash) []byte {
+	t.Helper()
+	signature, err := crypto.Sign(digest.Bytes(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+	return signature
+}
+
+func TestDepositWithPermitNeedsNoApproval(t *testing.T) {
+	c := chaintest.New(t)
+	aa := c.DeployAASharing(t)
+	alice, bob, relayer := c.Account(t), c.Account(t), c.Account(t)
+	contributor := crypto.PubkeyToAddress(alice.PublicKey)
+
+	id := aa.CreatePartnership(t, alice, bob)
+	c.Mined(t)(aa.Token.Transact(c.Transactor(t, c.Deployer), "mint", contributor, big.NewInt(7_000_000)))
+
+	token, err := chain.NewUSDCCaller(aa.USDC, c.Client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	eip712, err := token.Eip712Domain(&bind.CallOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	domain := &Domain{Name: eip712.Name, Version: eip712.Version, ChainID: eip712.ChainId, VerifyingContract: aa.USDC}
+	if separator, err := token.DOMAINSEPARATOR(&bind.CallOpts{}); err != nil || separator != domain.Separator() {
+		t.Fatalf("DOMAIN_SEPARATOR %x (%v), want %x", separator, err, domain.Separator())
+	}
+
+	deadline := big.NewInt(time.Now().Add(time.Hour).Unix())
+	contribution := &Contribution{
+		PartnershipID: id,
+		Contributor:   contributor,
+		Amount:        big.NewInt(7_000_000),
+		Nonce:         big.NewInt(0),
+		Deadline:      deadline,
+	}
+	permit := &Permit{
+		Owner:    contributor,
+		Spender:  aa.Address,
+		Value:    big.NewInt(7_000_000),
+		Nonce:    big.NewInt(0),
+		Deadline: deadline,
+	}
+	signature := sign(t, alice, contribution.Digest(eip712.ChainId, aa.Address))
+	permitSignature := sign(t, alice, permit.Digest(domain))
+	if signer, err := permit.Signer(domain, permitSignature); err != nil || signer != contributor {
+		t.Fatalf("permit signer %s (%v), want %s", signer.Hex(), err, contributor.Hex())
+	}
+
+	v, r, s, err := splitSignature(permitSignature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Mined(t)(aa.DepositFundsWithSignature(c.Transactor(t, relayer),
+		id, contributor, contribution.Amount, contribution.Nonce, deadline, signature, v, r, s))
+
+	partnership, err := aa.GetPartnership(&bind.CallOpts{}, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if partnership.TotalBalance.Cmp(big.NewInt(7_000_000)) != 0 {
+		t.Errorf("partnership balance %s, want 7000000", partnership.TotalBalance)
+	}
+	if nonce, err := token.Nonces(&bind.CallOpts{}, contributor); err != nil || nonce.Int64() != 1 {
+		t.Errorf("permit nonce %v (%v), want 1", nonce, err)
+	}
+}
@@ -0,0 +1,613 @@
+// Package gasless relays contributions that partners signed as EIP-712
+// intents, so that they can deposit USDC without holding the chain's gas
+// token. The relayer account submits depositFundsWithSignature and pays the
+// gas. Along with the contribution, the partner signs an EIP-2612 permit of
+// the amount, which the contract redeems before pulling the USDC, so no
+// approve transaction is needed either.
+package gasless
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	"aa-sharing-backend/internal/chain"
+	"aa-sharing-backend/internal/models"
+	"aa-sharing-backend/internal/services"
+	"aa-sharing-backend/internal/txqueue"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"gorm.io/gorm"
+)
+
+// minDeadline is how far in the future an intent's deadline must be when it
+// is submitted, to leave the relayer time to get it mined.
+const minDeadline = time.Minute
+
+var (
+	ErrNoWallet      = errors.New("user has no wallet address")
+	ErrNotLinked     = errors.New("partnership is not linked to an on-chain partnership")
+	ErrIntentExpired = errors.New("contribution intent deadline is too close or has passed")
+	ErrInvalidNonce  = errors.New("contribution intent nonce is not the next nonce")
+)
+
+// Client is the JSON-RPC API used by the relayer. It is satisfied by
+// *ethclient.Client.
+type Client interface {
+	txqueue.Client
+	bind.ContractCaller
+}
+
+type Config struct {
+	Contract     common.Address
+	PollInterval time.Duration // wait between polls
+}
+
+// Relayer relays signed contributions through a transaction queue, whose
+// sender pays the gas.
+type Relayer struct {
+	db            *gorm.DB
+	client        Client
+	queue         *txqueue.Queue
+	walletService *services.WalletService
+	cfg           Config
+
+	aaSharing *chain.AASharingCaller
+	events    *chain.AASharingFilterer
+	// deposits only packs calls for the queue, which sends them
+	deposits *chain.AASharingTransactor
+
+	mu     sync.Mutex
+	token  *chain.USDCCaller
+	domain *Domain // EIP-712 domain of the token's permits
+}
+
+func New(db *gorm.DB, client Client, queue *txqueue.Queue, walletService *services.WalletService, cfg Config) (*Relayer, error) {
+	aaSharing, err := chain.NewAASharingCaller(cfg.Contract, client)
+	if err != nil {
+		return nil, err
+	}
+	events, err := chain.NewAASharingFilterer(cfg.Contract, nil)
+	if err != nil {
+		return nil, err
+	}
+	deposits, err := chain.NewAASharingTransactor(cfg.Contract, nil)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = 15 * time.Second
+	}
+	return &Relayer{
+		db:            db,
+		client:        client,
+		queue:         queue,
+		walletService: walletService,
+		cfg:           cfg,
+		aaSharing:     aaSharing,
+		events:        events,
+		deposits:      deposits,
+	}, nil
+}
+
+// Sender returns the address of the relayer account.
+func (r *Relayer) Sender() common.Address {
+	return r.queue.Sender()
+}
+
+// Intent is a signed contribution submitted by a partner.
+type Intent struct {
+	Amount          models.Money
+	Nonce           uint64
+	Deadline        time.Time
+	Signature       []byte
+	PermitSignature []byte // signs the permit Prepare returned with the contribution
+	Description     string
+}
+
+// Prepare returns the contribution and the USDC permit user should sign for
+// a deposit of amount into partnership, with the next free nonces and the
+// given deadline.
+func (r *Relayer) Prepare(ctx context.Context, user *models.User, partnership *models.Partnership, amount models.Money, deadline time.Time) (map[string]interface{}, error) {
+	contribution, err := r.contribution(user, partnership, amount, 0, deadline)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := r.NextNonce(ctx, contribution.Contributor)
+	if err != nil {
+		return nil, err
+	}
+	contribution.Nonce = new(big.Int).SetUint64(nonce)
+
+	domain, permit, err := r.permit(ctx, contribution)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"contribution": contribution.TypedData(r.queue.ChainID(), r.cfg.Contract),
+		"permit":       permit.TypedData(domain),
+	}, nil
+}
+
+// Submit verifies a signed intent against the user's wallet and queues it
+// for relaying. The returned transaction stays pending, with its relay
+// status tracking the relayer, until the deposit is confirmed on chain.
+func (r *Relayer) Submit(ctx context.Context, user *models.User, partnership *models.Partnership, intent Intent) (*models.Transaction, error) {
+	if time.Until(intent.Deadline) < minDeadline {
+		return nil, ErrIntentExpired
+	}
+	contribution, err := r.contribution(user, partnership, intent.Amount, intent.Nonce, intent.Deadline)
+	if err != nil {
+		return nil, err
+	}
+
+	chainID := r.queue.ChainID()
+	signer, err := contribution.Signer(chainID, r.cfg.Contract, intent.Signature)
+	if err != nil {
+		return nil, err
+	}
+	if signer != contribution.Contributor {
+		return nil, ErrInvalidSignature
+	}
+
+	next, err := r.NextNonce(ctx, contribution.Contributor)
+	if err != nil {
+		return nil, err
+	}
+	if intent.Nonce != next {
+		return nil, fmt.Errorf("%w: expected %d", ErrInvalidNonce, next)
+	}
+
+	domain, permit, err := r.permit(ctx, contribution)
+	if err != nil {
+		return nil, err
+	}
+	permitSigner, err := permit.Signer(domain, intent.PermitSignature)
+	if err != nil {
+		return nil, err
+	}
+	if permitSigner != contribution.Contributor {
+		return nil, ErrInvalidPermit
+	}
+
+	description := intent.Description
+	if description == "" {
+		description = "Gasless contribution"
+	}
+	transaction := models.Transaction{
+		UserID:        user.ID,
+		PartnershipID: partnership.ID,
+		Type:          "contribution",
+		Amount:        intent.Amount,
+		Description:   description,
+		Status:        "pending",
+		RelayStatus:   models.RelayQueued,
+	}
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&transaction).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.GaslessContribution{
+			TransactionID:      transaction.ID,
+			ChainID:            chainID.Uint64(),
+			Contributor:        contribution.Contributor.Hex(),
+			ChainPartnershipID: *partnership.ChainPartnershipID,
+			Amount:             intent.Amount,
+			Nonce:              intent.Nonce,
+			Deadline:           intent.Deadline,
+			Signature:          hexutil.Encode(intent.Signature),
+			PermitSignature:    hexutil.Encode(intent.PermitSignature),
+			Status:             models.RelayQueued,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+// NextNonce returns the nonce the contributor's next intent must use: the
+// contract's nonce, or the one after the latest intent still being relayed.
+func (r *Relayer) NextNonce(ctx context.Context, contributor common.Address) (uint64, error) {
+	onChain, err := r.contributionNonce(ctx, contributor)
+	if err != nil {
+		return 0, err
+	}
+
+	var inFlight []uint64
+	if err := r.db.Model(&models.GaslessContribution{}).
+		Where("chain_id = ? AND contributor = ? AND status IN ?", r.queue.ChainID().Uint64(), contributor.Hex(),
+			[]string{models.RelayQueued, models.RelaySubmitted}).
+		Pluck("nonce", &inFlight).Error; err != nil {
+		return 0, err
+	}
+	next := onChain
+	for _, nonce := range inFlight {
+		if nonce >= next {
+			next = nonce + 1
+		}
+	}
+	return next, nil
+}
+
+func (r *Relayer) contribution(user *models.User, partnership *models.Partnership, amount models.Money, nonce uint64, deadline time.Time) (*Contribution, error) {
+	if !common.IsHexAddress(user.WalletAddress) {
+		return nil, ErrNoWallet
+	}
+	if partnership.ChainPartnershipID == nil {
+		return nil, ErrNotLinked
+	}
+	return &Contribution{
+		PartnershipID: new(big.Int).SetUint64(*partnership.ChainPartnershipID),
+		Contributor:   common.HexToAddress(user.WalletAddress),
+		Amount:        amount.BigInt(),
+		Nonce:         new(big.Int).SetUint64(nonce),
+		Deadline:      big.NewInt(deadline.Unix()),
+	}, nil
+}
+
+// permit returns the permit that goes with contribution and the token's
+// EIP-712 domain. Each intent still being relayed redeems one permit before
+// this one, so the permit's nonce is the token's nonce plus the number of
+// the contributor's intents ahead of it on chain.
+func (r *Relayer) permit(ctx context.Context, contribution *Contribution) (*Domain, *Permit, error) {
+	token, domain, err := r.tokenDomain(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	tokenNonce, err := token.Nonces(opts, contribution.Contributor)
+	if err != nil {
+		return nil, nil, err
+	}
+	onChain, err := r.contributionNonce(ctx, contribution.Contributor)
+	if err != nil {
+		return nil, nil, err
+	}
+	ahead := new(big.Int).Sub(contribution.Nonce, new(big.Int).SetUint64(onChain))
+	if ahead.Sign() < 0 {
+		ahead.SetInt64(0)
+	}
+	return domain, &Permit{
+		Owner:    contribution.Contributor,
+		Spender:  r.cfg.Contract,
+		Value:    contribution.Amount,
+		Nonce:    tokenNonce.Add(tokenNonce, ahead),
+		Deadline: contribution.Deadline,
+	}, nil
+}
+
+// tokenDomain returns the USDC token of the contract and the EIP-712 domain
+// of its permits, read once from the token. Tokens that do not implement
+// eip712Domain, like Circle's USDC, are asked for their name and version.
+// The domain is checked against the token's DOMAIN_SEPARATOR.
+func (r *Relayer) tokenDomain(ctx context.Context) (*chain.USDCCaller, *Domain, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.domain != nil {
+		return r.token, r.domain, nil
+	}
+
+	opts := &bind.CallOpts{Context: ctx}
+	address, err := r.aaSharing.Usdc(opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	token, err := chain.NewUSDCCaller(address, r.client)
+	if err != nil {
+		return nil, nil, err
+	}
+	domain := &Domain{ChainID: r.queue.ChainID(), VerifyingContract: address}
+	if eip712, err := token.Eip712Domain(opts); err == nil {
+		domain.Name, domain.Version = eip712.Name, eip712.Version
+	} else {
+		if domain.Name, err = token.Name(opts); err != nil {
+			return nil, nil, err
+		}
+		if domain.Version, err = token.Version(opts); err != nil {
+			return nil, nil, fmt.Errorf("USDC token %s does not support permits: %w", address.Hex(), err)
+		}
+	}
+	separator, err := token.DOMAINSEPARATOR(opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("USDC token %s does not support permits: %w", address.Hex(), err)
+	}
+	if separator != domain.Separator() {
+		return nil, nil, fmt.Errorf("USDC token %s has an unexpected permit domain", address.Hex())
+	}
+
+	r.token, r.domain = token, domain
+	return token, domain, nil
+}
+
+// Run relays queued contributions until ctx is cancelled.
+func (r *Relayer) Run(ctx context.Context) error {
+	for {
+		if err := r.Sync(ctx); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return err
+			}
+			log.Println("Gasless relayer sync failed:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(r.cfg.PollInterval):
+		}
+	}
+}
+
+// Sync advances the relay transactions in the queue, then every contribution
+// that is not yet confirmed or failed, in the order they were submitted.
+func (r *Relayer) Sync(ctx context.Context) error {
+	if err := r.queue.Sync(ctx); err != nil {
+		return err
+	}
+
+	var contributions []models.GaslessContribution
+	if err := r.db.Where("chain_id = ? AND status IN ?", r.queue.ChainID().Uint64(),
+		[]string{models.RelayQueued, models.RelaySubmitted, models.RelayMined}).
+		Order("id").
+		Find(&contributions).Error; err != nil {
+		return err
+	}
+	for i := range contributions {
+		if err := r.advance(ctx, &contributions[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Relayer) advance(ctx context.Context, contribution *models.GaslessContribution) error {
+	switch contribution.Status {
+	case models.RelayQueued:
+		return r.submit(ctx, contribution)
+	case models.RelaySubmitted:
+		return r.checkSubmitted(ctx, contribution)
+	case models.RelayMined:
+		return r.checkMined(ctx, contribution)
+	}
+	return nil
+}
+
+// submit queues the relay transaction of a contribution. The contribution
+// is tied to the queued transaction in the same database transaction, so the
+// indexer can match the deposit log to it from the first broadcast on.
+func (r *Relayer) submit(ctx context.Context, contribution *models.GaslessContribution) error {
+	if time.Now().After(contribution.Deadline) {
+		return r.fail(contribution, "contribution intent expired")
+	}
+
+	contributor := common.HexToAddress(contribution.Contributor)
+	onChain, err := r.contributionNonce(ctx, contributor)
+	if err != nil {
+		return err
+	}
+	switch {
+	case onChain > contribution.Nonce:
+		return r.fail(contribution, "contribution nonce already used")
+	case onChain < contribution.Nonce:
+		return nil // an earlier intent of the contributor is not mined yet
+	}
+
+	signature, err := hexutil.Decode(contribution.Signature)
+	if err != nil {
+		return r.fail(contribution, "invalid signature encoding")
+	}
+	permitSignature, err := hexutil.Decode(contribution.PermitSignature)
+	if err != nil {
+		return r.fail(contribution, "invalid permit signature encoding")
+	}
+	v, rs, ss, err := splitSignature(permitSignature)
+	if err != nil {
+		return r.fail(contribution, err.Error())
+	}
+	call, err := r.deposits.DepositFundsWithSignature(chain.PackOpts(),
+		new(big.Int).SetUint64(contribution.ChainPartnershipID),
+		contributor,
+		contribution.Amount.BigInt(),
+		new(big.Int).SetUint64(contribution.Nonce),
+		big.NewInt(contribution.Deadline.Unix()),
+		signature,
+		v, rs, ss,
+	)
+	if err != nil {
+		return err
+	}
+
+	var submission *models.ChainSubmission
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		submission, err = r.queue.Enqueue(ctx, tx, txqueue.Request{
+			To:        r.cfg.Contract,
+			Data:      call.Data(),
+			Reference: fmt.Sprintf("gasless_contribution:%d", contribution.ID),
+		})
+		if err != nil {
+			return err
+		}
+		return r.updateWith(tx, contribution, map[string]interface{}{
+			"status":        models.RelaySubmitted,
+			"submission_id": submission.ID,
+			"attempts":      contribution.Attempts + 1,
+		}, map[string]interface{}{
+			"relay_status": models.RelaySubmitted,
+			"tx_hash":      submission.TxHash,
+		})
+	})
+	if errors.Is(err, txqueue.ErrEstimateGas) {
+		// A deposit that would revert, e.g. because the permit was spent
+		// elsewhere and there is no allowance, is not sent
+		return r.fail(contribution, err.Error())
+	}
+	if err != nil {
+		return err
+	}
+
+	return r.queue.Broadcast(ctx, submission)
+}
+
+// checkSubmitted follows the relay transaction of a contribution through
+// the queue. A relay transaction that was dropped is queued again.
+func (r *Relayer) checkSubmitted(ctx context.Context, contribution *models.GaslessContribution) error {
+	submission, err := r.queue.Get(*contribution.SubmissionID)
+	if err != nil {
+		return err
+	}
+
+	switch submission.Status {
+	case models.SubmissionPending:
+		// Follow replacements at a higher gas price
+		return r.db.Model(&models.Transaction{}).
+			Where("id = ? AND log_index IS NULL AND tx_hash <> ?", contribution.TransactionID, submission.TxHash).
+			Update("tx_hash", submission.TxHash).Error
+	case models.SubmissionDropped:
+		log.Printf("Gasless relayer: transaction %s was dropped, queueing contribution %d again",
+			submission.TxHash, contribution.ID)
+		return r.update(contribution, map[string]interface{}{
+			"status":        models.RelayQueued,
+			"submission_id": nil,
+			"last_error":    "relay transaction dropped",
+		}, map[string]interface{}{
+			"relay_status": models.RelayQueued,
+		})
+	}
+
+	if !submission.Succeeded {
+		return r.fail(contribution, "relay transaction reverted")
+	}
+	receipt, err := r.client.TransactionReceipt(ctx, common.HexToHash(submission.TxHash))
+	if errors.Is(err, ethereum.NotFound) {
+		return nil // reorganized out of the chain; the queue will notice
+	}
+	if err != nil {
+		return err
+	}
+	return r.mined(ctx, contribution, receipt)
+}
+
+// mined ties the contribution's transaction to the FundsDeposited log its
+// relay transaction produced.
+func (r *Relayer) mined(ctx context.Context, contribution *models.GaslessContribution, receipt *types.Receipt) error {
+	logIndex, ok := r.depositLog(contribution, receipt)
+	if !ok {
+		return r.fail(contribution, "relay transaction did not deposit the contribution")
+	}
+	if _, err := r.walletService.AttachChainLog(contribution.TransactionID, receipt.TxHash.Hex(), logIndex); err != nil {
+		return err
+	}
+	if err := r.update(contribution, map[string]interface{}{"status": models.RelayMined},
+		map[string]interface{}{"relay_status": models.RelayMined}); err != nil {
+		return err
+	}
+	return r.checkMined(ctx, contribution)
+}
+
+// checkMined confirms a mined contribution once the queue has confirmed its
+// relay transaction. The indexer may have confirmed it already.
+func (r *Relayer) checkMined(ctx context.Context, contribution *models.GaslessContribution) error {
+	var transaction models.Transaction
+	if err := r.db.First(&transaction, contribution.TransactionID).Error; err != nil {
+		return err
+	}
+	switch transaction.Status {
+	case "confirmed":
+		return r.update(contribution, map[string]interface{}{"status": models.RelayConfirmed},
+			map[string]interface{}{"relay_status": models.RelayConfirmed})
+	case "failed":
+		return r.fail(contribution, "deposit reverted by chain reorganization")
+	}
+
+	submission, err := r.queue.Get(*contribution.SubmissionID)
+	if err != nil {
+		return err
+	}
+	switch submission.Status {
+	case models.SubmissionPending:
+		// Reorganized out of the chain; wait for it to be mined again
+		return r.update(contribution, map[string]interface{}{"status": models.RelaySubmitted},
+			map[string]interface{}{"relay_status": models.RelaySubmitted})
+	case models.SubmissionReverted:
+		return r.fail(contribution, "relay transaction reverted")
+	case models.SubmissionConfirmed:
+		if transaction.LogIndex == nil {
+			return nil
+		}
+		if err := r.walletService.ConfirmChainTransactions(submission.TxHash, *transaction.LogIndex); err != nil {
+			return err
+		}
+		return r.update(contribution, map[string]interface{}{"status": models.RelayConfirmed},
+			map[string]interface{}{"relay_status": models.RelayConfirmed})
+	}
+	return nil
+}
+
+// depositLog finds the FundsDeposited log of the contribution in receipt.
+func (r *Relayer) depositLog(contribution *models.GaslessContribution, receipt *types.Receipt) (uint, bool) {
+	contributor := common.HexToAddress(contribution.Contributor)
+	partnershipID := new(big.Int).SetUint64(contribution.ChainPartnershipID)
+	for _, l := range receipt.Logs {
+		if l.Address != r.cfg.Contract {
+			continue
+		}
+		deposit, err := r.events.ParseFundsDeposited(*l)
+		if err != nil {
+			continue // another AASharing event
+		}
+		if deposit.PartnershipId.Cmp(partnershipID) == 0 && deposit.Depositor == contributor {
+			return l.Index, true
+		}
+	}
+	return 0, false
+}
+
+// fail marks the contribution and its transaction failed.
+func (r *Relayer) fail(contribution *models.GaslessContribution, reason string) error {
+	log.Printf("Gasless relayer: contribution %d failed: %s", contribution.ID, reason)
+	return r.update(contribution, map[string]interface{}{
+		"status":     models.RelayFailed,
+		"last_error": reason,
+	}, map[string]interface{}{
+		"status":         "failed",
+		"failure_reason": reason,
+		"relay_status":   models.RelayFailed,
+	})
+}
+
+// update applies changes to the contribution and to its transaction in one
+// database transaction.
+func (r *Relayer) update(contribution *models.GaslessContribution, changes, transactionChanges map[string]interface{}) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return r.updateWith(tx, contribution, changes, transactionChanges)
+	})
+}
+
+func (r *Relayer) updateWith(tx *gorm.DB, contribution *models.GaslessContribution, changes, transactionChanges map[string]interface{}) error {
+	if err := tx.Model(contribution).Updates(changes).Error; err != nil {
+		return err
+	}
+	return tx.Model(&models.Transaction{}).Where("id = ?", contribution.TransactionID).
+		Updates(transactionChanges).Error
+}
+
+// contributionNonce reads the contributor's nonce from the contract.
+func (r *Relayer) contributionNonce(ctx context.Context, contributor common.Address) (uint64, error) {
+	nonce, err := r.aaSharing.ContributionNonces(&bind.CallOpts{Context: ctx}, contributor)
+	if err != nil {
+		return 0, err
+	}
+	if !nonce.IsUint64() {
+		return 0, fmt.Errorf("contribution nonce %s out of range", nonce)
+	}
+	return nonce.Uint64(), nil
+}
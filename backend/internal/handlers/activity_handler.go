@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"aa-sharing-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultActivityLimit = 50
+	maxActivityLimit     = 200
+)
+
+type ActivityHandler struct {
+	activityService *services.ActivityService
+}
+
+func NewActivityHandler(activityService *services.ActivityService) *ActivityHandler {
+	return &ActivityHandler{activityService: activityService}
+}
+
+// GetActivity returns the partnership's activity feed, newest first. Older
+// pages are fetched by passing the ID of the last event seen as before.
+func (h *ActivityHandler) GetActivity(c *gin.Context) {
+	partnershipID, err := strconv.ParseUint(c.Param("partnershipId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid partnership ID"})
+		return
+	}
+
+	var before uint64
+	if value := c.Query("before"); value != "" {
+		if before, err = strconv.ParseUint(value, 10, 32); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid before event ID"})
+			return
+		}
+	}
+	limit := defaultActivityLimit
+	if value := c.Query("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil || limit < 1 || limit > maxActivityLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 200"})
+			return
+		}
+	}
+
+	events, err := h.activityService.GetActivity(uint(partnershipID), uint(before), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
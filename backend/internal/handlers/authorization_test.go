@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"aa-sharing-backend/internal/middleware"
+	"aa-sharing-backend/internal/models"
+	"aa-sharing-backend/internal/services"
+	"aa-sharing-backend/internal/testdb"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// authorizationRouter wires the partnership-scoped routes the same way
+// cmd/main.go does.
+func authorizationRouter(db *gorm.DB, userService *services.UserService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	walletService := services.NewWalletService(db)
+	partnershipService := services.NewPartnershipService(db, walletService)
+	walletHandler := NewWalletHandler(walletService, partnershipService, nil)
+	gratitudeHandler := NewGratitudeHandler(services.NewGratitudeService(db), partnershipService)
+	partnershipHandler := NewPartnershipHandler(partnershipService)
+	withdrawalHandler := NewWithdrawalHandler(services.NewWithdrawalService(db, walletService, time.Hour), partnershipService)
+	expenseHandler := NewExpenseHandler(services.NewExpenseService(db), partnershipService)
+	activityHandler := NewActivityHandler(services.NewActivityService(db))
+
+	r := gin.New()
+	protected := r.Group("")
+	protected.Use(middleware.AuthRequired(userService))
+	requirePartner := middleware.RequirePartner(partnershipService, "partnershipId")
+
+	protected.GET("/partnerships/:partnershipId", requirePartner, partnershipHandler.GetPartnership)
+	protected.PUT("/partnerships/:partnershipId/status", partnershipHandler.UpdateStatus)
+	protected.POST("/gratitude", gratitudeHandler.CreateGratitude)
+	protected.GET("/gratitude/user/:userId", middleware.RequireSelf("userId"), gratitudeHandler.GetUserGratitude)
+	protected.GET("/gratitude/partnership/:partnershipId", requirePartner, gratitudeHandler.GetPartnershipGratitude)
+	protected.GET("/wallet/:partnershipId", requirePartner, walletHandler.GetWalletBalance)
+	protected.POST("/wallet/contribute", walletHandler.Contribute)
+	protected.GET("/wallet/transactions/:partnershipId", requirePartner, walletHandler.GetTransactions)
+	protected.POST("/wallet/:partnershipId/split", requirePartner, walletHandler.SplitFunds)
+	protected.GET("/wallet/ledger/:partnershipId", requirePartner, walletHandler.GetLedger)
+	protected.POST("/goals", walletHandler.CreateGoal)
+	protected.GET("/goals/:partnershipId", requirePartner, walletHandler.GetGoals)
+	protected.PUT("/goals/:id", walletHandler.UpdateGoal)
+	protected.POST("/goals/:partnershipId/allocate", walletHandler.ReallocateFunds)
+	protected.POST("/withdrawals", withdrawalHandler.RequestWithdrawal)
+	protected.GET("/withdrawals/:partnershipId", requirePartner, withdrawalHandler.GetWithdrawals)
+	protected.POST("/withdrawals/:id/approve", withdrawalHandler.ApproveWithdrawal)
+	protected.POST("/withdrawals/:id/reject", withdrawalHandler.RejectWithdrawal)
+	protected.POST("/expenses", expenseHandler.CreateExpense)
+	protected.GET("/expenses/:partnershipId", requirePartner, expenseHandler.GetExpenses)
+	protected.DELETE("/expenses/:id", expenseHandler.DeleteExpense)
+	protected.GET("/expenses/:partnershipId/balances", requirePartner, expenseHandler.GetBalances)
+	protected.POST("/expenses/:partnershipId/settle", requirePartner, expenseHandler.SettleUp)
+	protected.GET("/activity/:partnershipId", requirePartner, activityHandler.GetActivity)
+	return r
+}
+
+func TestPartnershipRoutesRejectOutsiders(t *testing.T) {
+	db := testdb.Open(t)
+	userService := services.NewUserService(db, "test-secret")
+	r := authorizationRouter(db, userService)
+
+	partnership := testdb.Partnership(t, db)
+	outsider := testdb.User(t, db)
+
+	goal := models.Goal{PartnershipID: partnership.ID, Name: "Trip", TargetAmount: models.USDC(100_000_000), Status: "active"}
+	if err := db.Create(&goal).Error; err != nil {
+		t.Fatal(err)
+	}
+	withdrawal := models.WithdrawalRequest{
+		PartnershipID: partnership.ID,
+		RequestedByID: partnership.UserAID,
+		Amount:        models.USDC(1_000_000),
+		Purpose:       "Dinner",
+		Status:        models.WithdrawalPending,
+		ExpiresAt:     time.Now().Add(time.Hour),
+	}
+	if err := db.Create(&withdrawal).Error; err != nil {
+		t.Fatal(err)
+	}
+	expense := models.Expense{
+		PartnershipID: partnership.ID,
+		PaidByID:      partnership.UserAID,
+		Description:   "Groceries",
+		Amount:        models.USDC(2_000_000),
+		SplitMethod:   models.SplitEqual,
+		SpentAt:       time.Now(),
+	}
+	if err := db.Create(&expense).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	pid := partnership.ID
+	requests := []struct{ method, path, body string }{
+		{"GET", fmt.Sprintf("/partnerships/%d", pid), ""},
+		{"PUT", fmt.Sprintf("/partnerships/%d/status", pid), `{"status":"inactive"}`},
+		{"POST", "/gratitude", fmt.Sprintf(`{"partnership_id":%d,"content":"Thanks"}`, pid)},
+		{"GET", fmt.Sprintf("/gratitude/user/%d", partnership.UserAID), ""},
+		{"GET", fmt.Sprintf("/gratitude/partnership/%d", pid), ""},
+		{"GET", fmt.Sprintf("/wallet/%d", pid), ""},
+		{"POST", "/wallet/contribute", fmt.Sprintf(`{"partnership_id":%d,"amount":"1","type":"contribution"}`, pid)},
+		{"GET", fmt.Sprintf("/wallet/transactions/%d", pid), ""},
+		{"POST", fmt.Sprintf("/wallet/%d/split", pid), `{"dry_run":true}`},
+		{"GET", fmt.Sprintf("/wallet/ledger/%d", pid), ""},
+		{"POST", "/goals", fmt.Sprintf(`{"partnership_id":%d,"name":"Car","target_amount":"10"}`, pid)},
+		{"GET", fmt.Sprintf("/goals/%d", pid), ""},
+		{"PUT", fmt.Sprintf("/goals/%d", goal.ID), `{"name":"Stolen"}`},
+		{"POST", fmt.Sprintf("/goals/%d/allocate", pid), fmt.Sprintf(`{"to_goal_id":%d,"amount":"1"}`, goal.ID)},
+		{"POST", "/withdrawals", fmt.Sprintf(`{"partnership_id":%d,"amount":"1","purpose":"Shoes"}`, pid)},
+		{"GET", fmt.Sprintf("/withdrawals/%d", pid), ""},
+		{"POST", fmt.Sprintf("/withdrawals/%d/approve", withdrawal.ID), ""},
+		{"POST", fmt.Sprintf("/withdrawals/%d/reject", withdrawal.ID), `{"reason":"no"}`},
+		{"POST", "/expenses", fmt.Sprintf(`{"partnership_id":%d,"paid_by_id":%d,"description":"Taxi","amount":"5"}`, pid, partnership.UserAID)},
+		{"GET", fmt.Sprintf("/expenses/%d", pid), ""},
+		{"DELETE", fmt.Sprintf("/expenses/%d", expense.ID), ""},
+		{"GET", fmt.Sprintf("/expenses/%d/balances", pid), ""},
+		{"POST", fmt.Sprintf("/expenses/%d/settle", pid), ""},
+		{"GET", fmt.Sprintf("/activity/%d", pid), ""},
+	}
+
+	token, err := userService.GenerateJWT(outsider.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, req := range requests {
+		t.Run(req.method+" "+req.path, func(t *testing.T) {
+			w := serve(r, req.method, req.path, req.body, token)
+			if w.Code != http.StatusForbidden {
+				t.Fatalf("outsider got %d, want 403: %s", w.Code, w.Body)
+			}
+		})
+	}
+
+	// Nothing the outsider asked for may have happened
+	var count int64
+	db.Model(&models.Transaction{}).Where("partnership_id = ?", pid).Count(&count)
+	if count != 0 {
+		t.Errorf("outsider created %d transactions", count)
+	}
+	db.Model(&models.Goal{}).Where("partnership_id = ?", pid).Count(&count)
+	if count != 1 {
+		t.Errorf("partnership has %d goals, want 1", count)
+	}
+	if err := db.First(&expense, expense.ID).Error; err != nil {
+		t.Errorf("outsider deleted the expense: %v", err)
+	}
+	if err := db.First(&withdrawal, withdrawal.ID).Error; err != nil || withdrawal.Status != models.WithdrawalPending {
+		t.Errorf("outsider reviewed the withdrawal: status %q, %v", withdrawal.Status, err)
+	}
+}
+
+func TestPartnershipRoutesAllowPartners(t *testing.T) {
+	db := testdb.Open(t)
+	userService := services.NewUserService(db, "test-secret")
+	r := authorizationRouter(db, userService)
+
+	partnership := testdb.Partnership(t, db)
+	pid := partnership.ID
+	paths := []string{
+		fmt.Sprintf("/partnerships/%d", pid),
+		fmt.Sprintf("/gratitude/user/%d", partnership.UserBID),
+		fmt.Sprintf("/gratitude/partnership/%d", pid),
+		fmt.Sprintf("/wallet/%d", pid),
+		fmt.Sprintf("/wallet/transactions/%d", pid),
+		fmt.Sprintf("/wallet/ledger/%d", pid),
+		fmt.Sprintf("/goals/%d", pid),
+		fmt.Sprintf("/withdrawals/%d", pid),
+		fmt.Sprintf("/expenses/%d", pid),
+		fmt.Sprintf("/expenses/%d/balances", pid),
+		fmt.Sprintf("/activity/%d", pid),
+	}
+
+	token, err := userService.GenerateJWT(partnership.UserBID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range paths {
+		if w := serve(r, "GET", path, "", token); w.Code != http.StatusOK {
+			t.Errorf("GET %s: partner got %d, want 200: %s", path, w.Code, w.Body)
+		}
+	}
+}
+
+func TestPartnershipRoutesRequireToken(t *testing.T) {
+	db := testdb.Open(t)
+	userService := services.NewUserService(db, "test-secret")
+	r := authorizationRouter(db, userService)
+	partnership := testdb.Partnership(t, db)
+
+	forged, err := services.NewUserService(db, "another-secret").GenerateJWT(partnership.UserAID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, token := range []string{"", "not-a-token", forged} {
+		if w := serve(r, "GET", fmt.Sprintf("/wallet/%d", partnership.ID), "", token); w.Code != http.StatusUnauthorized {
+			t.Errorf("token %q: got %d, want 401", token, w.Code)
+		}
+	}
+}
+
+func serve(r http.Handler, method, path, body, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
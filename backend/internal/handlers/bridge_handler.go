@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"aa-sharing-backend/internal/middleware"
+	"aa-sharing-backend/internal/models"
+	"aa-sharing-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type BridgeHandler struct {
+	bridgeService *services.BridgeService
+	quoteService  *services.BridgeQuoteService
+}
+
+func NewBridgeHandler(bridgeService *services.BridgeService, quoteService *services.BridgeQuoteService) *BridgeHandler {
+	return &BridgeHandler{bridgeService: bridgeService, quoteService: quoteService}
+}
+
+// GetQuote returns the fee, net amount, deposit limits and estimated
+// settlement time of a cross-chain deposit, so the amount can be checked
+// before the user signs initiateCrossChainDeposit. An amount outside the
+// limits is rejected with 422 along with the quote.
+func (h *BridgeHandler) GetQuote(c *gin.Context) {
+	amount, err := models.ParseMoney(c.Query("amount"), "")
+	if err != nil || !amount.IsPositive() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Amount must be greater than 0"})
+		return
+	}
+	source, destination := c.Query("source"), c.Query("destination")
+	if source == "" || destination == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "source and destination are required"})
+		return
+	}
+
+	quote, err := h.quoteService.Quote(c.Request.Context(), amount, source, destination)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrAmountOutsideLimits):
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error(), "quote": quote})
+		case errors.Is(err, services.ErrUnknownChain), errors.Is(err, services.ErrSameChain):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrBridgeNotDeployed):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, quote)
+}
+
+// GetDeposits lists the cross-chain deposits of the authenticated user, with
+// their status history.
+func (h *BridgeHandler) GetDeposits(c *gin.Context) {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	deposits, err := h.bridgeService.GetUserDeposits(user)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, deposits)
+}
+
+func (h *BridgeHandler) GetPartnershipDeposits(c *gin.Context) {
+	partnershipID, err := strconv.ParseUint(c.Param("partnershipId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid partnership ID"})
+		return
+	}
+
+	deposits, err := h.bridgeService.GetPartnershipDeposits(uint(partnershipID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, deposits)
+}
+
+func (h *BridgeHandler) GetDeposit(c *gin.Context) {
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	deposit, err := h.bridgeService.GetDeposit(user, c.Param("messageId"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Deposit not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, deposit)
+}
@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"net/http"
+
+	"aa-sharing-backend/internal/registry"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ChainHandler struct {
+	registry *registry.Registry
+}
+
+func NewChainHandler(registry *registry.Registry) *ChainHandler {
+	return &ChainHandler{registry: registry}
+}
+
+// GetChains lists the supported networks with their contract deployments.
+func (h *ChainHandler) GetChains(c *gin.Context) {
+	c.JSON(http.StatusOK, h.registry.Networks())
+}
@@ -0,0 +1,269 @@
+package handlers
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"aa-sharing-backend/internal/middleware"
+	"aa-sharing-backend/internal/models"
+	"aa-sharing-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ExpenseHandler struct {
+	expenseService     *services.ExpenseService
+	partnershipService *services.PartnershipService
+}
+
+func NewExpenseHandler(expenseService *services.ExpenseService, partnershipService *services.PartnershipService) *ExpenseHandler {
+	return &ExpenseHandler{expenseService: expenseService, partnershipService: partnershipService}
+}
+
+// CreateExpense records a bill paid by one partner and how it is split.
+// Each participant gives the value their split method needs: amount for
+// exact, percent for percentage and shares for shares. An equal split with
+// no participants is shared by both partners.
+func (h *ExpenseHandler) CreateExpense(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req struct {
+		PartnershipID uint         `json:"partnership_id" binding:"required"`
+		PaidByID      uint         `json:"paid_by_id"` // defaults to the caller
+		Amount        models.Money `json:"amount"`
+		Description   string       `json:"description" binding:"required"`
+		Category      string       `json:"category"`
+		ReceiptNote   string       `json:"receipt_note"`
+		SpentAt       *time.Time   `json:"spent_at"`
+		SplitMethod   string       `json:"split_method"` // equal (default), exact, percentage or shares
+		Participants  []struct {
+			UserID  uint          `json:"user_id" binding:"required"`
+			Amount  *models.Money `json:"amount"`
+			Percent *float64      `json:"percent"`
+			Shares  *int64        `json:"shares"`
+		} `json:"participants"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !req.Amount.IsPositive() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Amount must be greater than 0"})
+		return
+	}
+	if req.SplitMethod == "" {
+		req.SplitMethod = models.SplitEqual
+	}
+
+	partnership, ok := middleware.ActivePartnership(c, h.partnershipService, req.PartnershipID)
+	if !ok {
+		return
+	}
+
+	participants := make([]services.ExpenseParticipant, 0, len(req.Participants))
+	for _, p := range req.Participants {
+		participant := services.ExpenseParticipant{UserID: p.UserID, Weight: 1}
+		switch req.SplitMethod {
+		case models.SplitExact:
+			if p.Amount == nil || p.Amount.Code() != req.Amount.Code() {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Each participant needs an amount in the expense's currency"})
+				return
+			}
+			participant.Weight = p.Amount.Units
+		case models.SplitPercentage:
+			if p.Percent == nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Each participant needs a percent"})
+				return
+			}
+			participant.Weight = int64(math.Round(*p.Percent * 100)) // basis points
+		case models.SplitShares:
+			if p.Shares == nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Each participant needs a number of shares"})
+				return
+			}
+			participant.Weight = *p.Shares
+		}
+		participants = append(participants, participant)
+	}
+	if len(participants) == 0 && req.SplitMethod == models.SplitEqual {
+		participants = []services.ExpenseParticipant{
+			{UserID: partnership.UserAID, Weight: 1},
+			{UserID: partnership.UserBID, Weight: 1},
+		}
+	}
+
+	expense := models.Expense{
+		PaidByID:    req.PaidByID,
+		CreatedByID: userID,
+		Amount:      req.Amount,
+		Description: req.Description,
+		Category:    strings.ToLower(strings.TrimSpace(req.Category)),
+		SplitMethod: req.SplitMethod,
+		ReceiptNote: req.ReceiptNote,
+		SpentAt:     time.Now(),
+	}
+	if expense.PaidByID == 0 {
+		expense.PaidByID = userID
+	}
+	if expense.Category == "" {
+		expense.Category = "other"
+	}
+	if req.SpentAt != nil {
+		expense.SpentAt = *req.SpentAt
+	}
+
+	if err := h.expenseService.CreateExpense(partnership, &expense, participants); err != nil {
+		h.error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, expense)
+}
+
+// GetExpenses lists a partnership's expenses, optionally filtered with the
+// category query parameter.
+func (h *ExpenseHandler) GetExpenses(c *gin.Context) {
+	partnershipID, err := strconv.ParseUint(c.Param("partnershipId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid partnership ID"})
+		return
+	}
+
+	expenses, err := h.expenseService.GetExpenses(uint(partnershipID), strings.ToLower(c.Query("category")))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, expenses)
+}
+
+// DeleteExpense deletes an expense the caller paid or recorded.
+func (h *ExpenseHandler) DeleteExpense(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid expense ID"})
+		return
+	}
+
+	expense, err := h.expenseService.GetExpense(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Expense not found"})
+		return
+	}
+
+	if !middleware.AuthorizePartner(c, h.partnershipService, expense.PartnershipID) {
+		return
+	}
+
+	if err := h.expenseService.DeleteExpense(expense, userID); err != nil {
+		h.error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Expense deleted successfully"})
+}
+
+// GetBalances returns what each partner has paid and owes, and who owes
+// whom.
+func (h *ExpenseHandler) GetBalances(c *gin.Context) {
+	partnershipID, err := strconv.ParseUint(c.Param("partnershipId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid partnership ID"})
+		return
+	}
+
+	partnership, err := h.partnershipService.GetPartnership(uint(partnershipID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Partnership not found"})
+		return
+	}
+
+	balances, err := h.expenseService.GetBalances(partnership)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, balances)
+}
+
+// SettleUp records that the caller paid their partner what they owe for
+// expenses. Without an amount the whole debt is settled.
+func (h *ExpenseHandler) SettleUp(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	partnershipID, err := strconv.ParseUint(c.Param("partnershipId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid partnership ID"})
+		return
+	}
+
+	var req struct {
+		Amount *models.Money `json:"amount"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	amount := models.NewMoney(0, models.DefaultCurrency)
+	if req.Amount != nil {
+		if !req.Amount.IsPositive() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Amount must be greater than 0"})
+			return
+		}
+		amount = *req.Amount
+	}
+
+	partnership, ok := middleware.ActivePartnership(c, h.partnershipService, uint(partnershipID))
+	if !ok {
+		return
+	}
+
+	transaction, err := h.expenseService.SettleUp(partnership, userID, amount)
+	if err != nil {
+		h.error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, transaction)
+}
+
+func (h *ExpenseHandler) error(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, services.ErrNotPartner):
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Payer and participants must be partners in this partnership"})
+	case errors.Is(err, services.ErrInvalidSplit):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrNotExpenseOwner):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrNothingToSettle), errors.Is(err, services.ErrSettlementTooBig):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Partnership not found"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
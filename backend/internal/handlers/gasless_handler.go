@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"aa-sharing-backend/internal/gasless"
+	"aa-sharing-backend/internal/middleware"
+	"aa-sharing-backend/internal/models"
+	"aa-sharing-backend/internal/services"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gin-gonic/gin"
+)
+
+// intentTTL is the deadline given to contributions prepared for signing.
+const intentTTL = 15 * time.Minute
+
+type GaslessHandler struct {
+	relayer            *gasless.Relayer
+	partnershipService *services.PartnershipService
+}
+
+// NewGaslessHandler creates a GaslessHandler. relayer is nil when no relayer
+// account is configured; gasless contributions are then unavailable.
+func NewGaslessHandler(relayer *gasless.Relayer, partnershipService *services.PartnershipService) *GaslessHandler {
+	return &GaslessHandler{relayer: relayer, partnershipService: partnershipService}
+}
+
+// PrepareContribution returns the EIP-712 typed data the caller signs to
+// contribute amount to a partnership without paying gas: the contribution,
+// and the USDC permit that lets the contract pull the amount.
+func (h *GaslessHandler) PrepareContribution(c *gin.Context) {
+	if h.relayer == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Gasless contributions are not available"})
+		return
+	}
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	partnershipID, err := strconv.ParseUint(c.Query("partnership_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid partnership ID"})
+		return
+	}
+	amount, err := models.ParseMoney(c.Query("amount"), c.Query("currency"))
+	if err != nil || !amount.IsPositive() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Amount must be greater than 0"})
+		return
+	}
+
+	partnership, ok := middleware.ActivePartnership(c, h.partnershipService, uint(partnershipID))
+	if !ok {
+		return
+	}
+
+	typedData, err := h.relayer.Prepare(c.Request.Context(), user, partnership, amount, time.Now().Add(intentTTL))
+	if err != nil {
+		h.error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, typedData)
+}
+
+// ContributeGasless queues a signed contribution intent for the relayer. The
+// returned transaction is pending; its relay_status follows the relay
+// transaction until the deposit is confirmed.
+func (h *GaslessHandler) ContributeGasless(c *gin.Context) {
+	if h.relayer == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Gasless contributions are not available"})
+		return
+	}
+	user, ok := middleware.CurrentUser(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req struct {
+		PartnershipID   uint         `json:"partnership_id" binding:"required"`
+		Amount          models.Money `json:"amount"`
+		Nonce           *uint64      `json:"nonce" binding:"required"`
+		Deadline        int64        `json:"deadline" binding:"required"` // unix seconds
+		Signature       string       `json:"signature" binding:"required"`
+		PermitSignature string       `json:"permit_signature" binding:"required"`
+		Description     string       `json:"description"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !req.Amount.IsPositive() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Amount must be greater than 0"})
+		return
+	}
+	signature, err := hexutil.Decode(req.Signature)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid signature encoding"})
+		return
+	}
+	permitSignature, err := hexutil.Decode(req.PermitSignature)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid permit signature encoding"})
+		return
+	}
+
+	partnership, ok := middleware.ActivePartnership(c, h.partnershipService, req.PartnershipID)
+	if !ok {
+		return
+	}
+
+	transaction, err := h.relayer.Submit(c.Request.Context(), user, partnership, gasless.Intent{
+		Amount:          req.Amount,
+		Nonce:           *req.Nonce,
+		Deadline:        time.Unix(req.Deadline, 0),
+		Signature:       signature,
+		PermitSignature: permitSignature,
+		Description:     req.Description,
+	})
+	if err != nil {
+		h.error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, transaction)
+}
+
+func (h *GaslessHandler) error(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gasless.ErrInvalidSignature),
+		errors.Is(err, gasless.ErrInvalidPermit):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, gasless.ErrInvalidNonce):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, gasless.ErrNoWallet),
+		errors.Is(err, gasless.ErrNotLinked),
+		errors.Is(err, gasless.ErrIntentExpired):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
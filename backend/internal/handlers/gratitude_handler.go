@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 
+	"aa-sharing-backend/internal/middleware"
 	"aa-sharing-backend/internal/models"
 	"aa-sharing-backend/internal/services"
 
@@ -11,19 +12,25 @@ import (
 )
 
 type GratitudeHandler struct {
-	gratitudeService *services.GratitudeService
+	gratitudeService   *services.GratitudeService
+	partnershipService *services.PartnershipService
 }
 
-func NewGratitudeHandler(gratitudeService *services.GratitudeService) *GratitudeHandler {
-	return &GratitudeHandler{gratitudeService: gratitudeService}
+func NewGratitudeHandler(gratitudeService *services.GratitudeService, partnershipService *services.PartnershipService) *GratitudeHandler {
+	return &GratitudeHandler{gratitudeService: gratitudeService, partnershipService: partnershipService}
 }
 
 func (h *GratitudeHandler) CreateGratitude(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
 	var req struct {
-		UserID        uint    `json:"user_id" binding:"required"`
-		PartnershipID uint    `json:"partnership_id" binding:"required"`
-		Content       string  `json:"content" binding:"required"`
-		Amount        float64 `json:"amount"`
+		PartnershipID uint         `json:"partnership_id" binding:"required"`
+		Content       string       `json:"content" binding:"required"`
+		Amount        models.Money `json:"amount"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -31,8 +38,17 @@ func (h *GratitudeHandler) CreateGratitude(c *gin.Context) {
 		return
 	}
 
+	if req.Amount.IsNegative() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Amount cannot be negative"})
+		return
+	}
+
+	if !middleware.AuthorizeActivePartner(c, h.partnershipService, req.PartnershipID) {
+		return
+	}
+
 	gratitude := models.GratitudeEntry{
-		UserID:        req.UserID,
+		UserID:        userID,
 		PartnershipID: req.PartnershipID,
 		Content:       req.Content,
 		Amount:        req.Amount,
@@ -46,6 +62,8 @@ func (h *GratitudeHandler) CreateGratitude(c *gin.Context) {
 	c.JSON(http.StatusCreated, gratitude)
 }
 
+// GetUserGratitude lists the authenticated user's own gratitude entries; the
+// route is guarded by middleware.RequireSelf.
 func (h *GratitudeHandler) GetUserGratitude(c *gin.Context) {
 	userID, err := strconv.ParseUint(c.Param("userId"), 10, 32)
 	if err != nil {
@@ -76,4 +94,4 @@ func (h *GratitudeHandler) GetPartnershipGratitude(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, gratitudes)
-}
\ No newline at end of file
+}
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"aa-sharing-backend/internal/middleware"
+	"aa-sharing-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type PartnershipHandler struct {
+	partnershipService *services.PartnershipService
+}
+
+func NewPartnershipHandler(partnershipService *services.PartnershipService) *PartnershipHandler {
+	return &PartnershipHandler{partnershipService: partnershipService}
+}
+
+func (h *PartnershipHandler) CreatePartnership(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req struct {
+		PartnerEmail         string `json:"partner_email"`
+		PartnerWalletAddress string `json:"partner_wallet_address"`
+		NicknameA            string `json:"nickname_a" binding:"required"`
+		NicknameB            string `json:"nickname_b" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	partnership, err := h.partnershipService.CreatePartnership(userID, req.PartnerEmail, req.PartnerWalletAddress, req.NicknameA, req.NicknameB)
+	if err != nil {
+		respondPartnershipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, partnership)
+}
+
+func (h *PartnershipHandler) GetPartnerships(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	partnerships, err := h.partnershipService.GetUserPartnerships(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, partnerships)
+}
+
+func (h *PartnershipHandler) GetPartnership(c *gin.Context) {
+	partnershipID, err := strconv.ParseUint(c.Param("partnershipId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid partnership ID"})
+		return
+	}
+
+	partnership, err := h.partnershipService.GetPartnership(uint(partnershipID))
+	if err != nil {
+		respondPartnershipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, partnership)
+}
+
+func (h *PartnershipHandler) AcceptPartnership(c *gin.Context) {
+	h.respondToInvitation(c, true)
+}
+
+func (h *PartnershipHandler) DeclinePartnership(c *gin.Context) {
+	h.respondToInvitation(c, false)
+}
+
+func (h *PartnershipHandler) respondToInvitation(c *gin.Context, accept bool) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	partnershipID, err := strconv.ParseUint(c.Param("partnershipId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid partnership ID"})
+		return
+	}
+
+	partnership, err := h.partnershipService.RespondToInvitation(uint(partnershipID), userID, accept)
+	if err != nil {
+		respondPartnershipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, partnership)
+}
+
+func (h *PartnershipHandler) UpdateStatus(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	partnershipID, err := strconv.ParseUint(c.Param("partnershipId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid partnership ID"})
+		return
+	}
+
+	var req struct {
+		Status string `json:"status" binding:"required"` // active, inactive, split
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	partnership, err := h.partnershipService.UpdateStatus(uint(partnershipID), userID, req.Status)
+	if err != nil {
+		respondPartnershipError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, partnership)
+}
+
+func respondPartnershipError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Partnership not found"})
+	case errors.Is(err, services.ErrPartnerNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrNotPartner):
+		c.JSON(http.StatusForbidden, gin.H{"error": "Not a partner in this partnership"})
+	case errors.Is(err, services.ErrNotInvitee):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrSelfPartnership),
+		errors.Is(err, services.ErrNicknamesRequired),
+		errors.Is(err, services.ErrPartnerIdentifier):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInvalidStatus),
+		errors.Is(err, services.ErrPartnershipHasFunds):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"aa-sharing-backend/internal/middleware"
+	"aa-sharing-backend/internal/models"
+	"aa-sharing-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ReconciliationHandler struct {
+	reconciliationService *services.ReconciliationService
+}
+
+func NewReconciliationHandler(reconciliationService *services.ReconciliationService) *ReconciliationHandler {
+	return &ReconciliationHandler{reconciliationService: reconciliationService}
+}
+
+// GetReconciliation lists recorded discrepancies. They can be filtered with
+// the status and severity query parameters; status defaults to open and
+// status=all lists every discrepancy.
+func (h *ReconciliationHandler) GetReconciliation(c *gin.Context) {
+	status := c.DefaultQuery("status", models.DiscrepancyOpen)
+	if status == "all" {
+		status = ""
+	}
+
+	discrepancies, err := h.reconciliationService.GetDiscrepancies(status, c.Query("severity"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, discrepancies)
+}
+
+// RunReconciliation reconciles every partnership now and returns the open
+// discrepancies.
+func (h *ReconciliationHandler) RunReconciliation(c *gin.Context) {
+	if err := h.reconciliationService.Reconcile(c.Request.Context()); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	discrepancies, err := h.reconciliationService.GetDiscrepancies(models.DiscrepancyOpen, "")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, discrepancies)
+}
+
+func (h *ReconciliationHandler) ApproveDiscrepancy(c *gin.Context) {
+	h.review(c, func(id, adminID uint) (*models.ReconciliationDiscrepancy, error) {
+		return h.reconciliationService.Approve(c.Request.Context(), id, adminID)
+	})
+}
+
+func (h *ReconciliationHandler) DismissDiscrepancy(c *gin.Context) {
+	h.review(c, h.reconciliationService.Dismiss)
+}
+
+func (h *ReconciliationHandler) review(c *gin.Context, action func(id, adminID uint) (*models.ReconciliationDiscrepancy, error)) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid discrepancy ID"})
+		return
+	}
+
+	adminID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	discrepancy, err := action(uint(id), adminID)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Discrepancy not found"})
+		case errors.Is(err, services.ErrDiscrepancyClosed), errors.Is(err, services.ErrDiscrepancyChanged):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, discrepancy)
+}
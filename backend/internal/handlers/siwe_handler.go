@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"aa-sharing-backend/internal/middleware"
+	"aa-sharing-backend/internal/services"
+	"aa-sharing-backend/internal/siwe"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type SIWEHandler struct {
+	siweService *services.SIWEService
+	userService *services.UserService
+}
+
+func NewSIWEHandler(siweService *services.SIWEService, userService *services.UserService) *SIWEHandler {
+	return &SIWEHandler{siweService: siweService, userService: userService}
+}
+
+func (h *SIWEHandler) Nonce(c *gin.Context) {
+	nonce, err := h.siweService.IssueNonce()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate nonce"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"nonce":      nonce.Nonce,
+		"expires_at": nonce.ExpiresAt,
+	})
+}
+
+func (h *SIWEHandler) Verify(c *gin.Context) {
+	var req struct {
+		Message   string `json:"message" binding:"required"`
+		Signature string `json:"signature" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.siweService.Verify(req.Message, req.Signature)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "No user registered for this wallet"})
+			return
+		}
+		respondSIWEError(c, err)
+		return
+	}
+
+	token, err := h.userService.GenerateJWT(user.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user":  user,
+		"token": token,
+	})
+}
+
+// Link binds the wallet that signed a SIWE message to the authenticated
+// user. This is the only way to set a user's wallet address.
+func (h *SIWEHandler) Link(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req struct {
+		Message   string `json:"message" binding:"required"`
+		Signature string `json:"signature" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.siweService.Link(userID, req.Message, req.Signature)
+	if err != nil {
+		if errors.Is(err, services.ErrWalletTaken) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		respondSIWEError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+func respondSIWEError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, siwe.ErrMalformedMessage):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, siwe.ErrInvalidSignature),
+		errors.Is(err, siwe.ErrExpired),
+		errors.Is(err, siwe.ErrNotYetValid),
+		errors.Is(err, services.ErrInvalidNonce),
+		errors.Is(err, services.ErrDomainMismatch),
+		errors.Is(err, services.ErrURIMismatch),
+		errors.Is(err, services.ErrChainIDMismatch):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
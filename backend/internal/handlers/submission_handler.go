@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"aa-sharing-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type SubmissionHandler struct {
+	submissionService *services.SubmissionService
+}
+
+func NewSubmissionHandler(submissionService *services.SubmissionService) *SubmissionHandler {
+	return &SubmissionHandler{submissionService: submissionService}
+}
+
+// GetSubmissions lists the transactions sent by the backend. They can be
+// filtered with the status and sender query parameters.
+func (h *SubmissionHandler) GetSubmissions(c *gin.Context) {
+	submissions, err := h.submissionService.GetSubmissions(c.Query("status"), c.Query("sender"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, submissions)
+}
+
+func (h *SubmissionHandler) GetSubmission(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid submission ID"})
+		return
+	}
+
+	submission, err := h.submissionService.GetSubmission(uint(id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Submission not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, submission)
+}
+
+// GetSenderNonces lists the next nonce of every account the backend sends
+// transactions from.
+func (h *SubmissionHandler) GetSenderNonces(c *gin.Context) {
+	nonces, err := h.submissionService.GetSenderNonces()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, nonces)
+}
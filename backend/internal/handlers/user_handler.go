@@ -18,13 +18,20 @@ func NewUserHandler(userService *services.UserService) *UserHandler {
 	return &UserHandler{userService: userService}
 }
 
+// CreateUser creates a user on behalf of an admin. Users sign themselves up
+// through Register or SIWE.
 func (h *UserHandler) CreateUser(c *gin.Context) {
-	var user models.User
-	if err := c.ShouldBindJSON(&user); err != nil {
+	var req struct {
+		Email string `json:"email" binding:"required,email"`
+		Name  string `json:"name" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	user := models.User{Email: req.Email, Name: req.Name}
 	if err := h.userService.CreateUser(&user); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -49,6 +56,8 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 	c.JSON(http.StatusOK, user)
 }
 
+// UpdateUser updates the authenticated user's own account; the route is
+// guarded by middleware.RequireSelf.
 func (h *UserHandler) UpdateUser(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
 	if err != nil {
@@ -56,12 +65,30 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		return
 	}
 
-	var updates map[string]interface{}
-	if err := c.ShouldBindJSON(&updates); err != nil {
+	var req struct {
+		Email *string `json:"email" binding:"omitempty,email"`
+		Name  *string `json:"name"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	// The wallet address is left out on purpose: it is only set by
+	// SIWEHandler.Link once the user has proven they control it
+	updates := map[string]interface{}{}
+	if req.Email != nil {
+		updates["email"] = *req.Email
+	}
+	if req.Name != nil {
+		updates["name"] = *req.Name
+	}
+	if len(updates) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Nothing to update"})
+		return
+	}
+
 	if err := h.userService.UpdateUser(uint(id), updates); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -70,11 +97,12 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "User updated successfully"})
 }
 
+// Register signs up a user by email. A wallet is linked afterwards by
+// signing a SIWE message, see SIWEHandler.Link.
 func (h *UserHandler) Register(c *gin.Context) {
 	var req struct {
-		Email         string `json:"email" binding:"required,email"`
-		Name          string `json:"name" binding:"required"`
-		WalletAddress string `json:"wallet_address"`
+		Email string `json:"email" binding:"required,email"`
+		Name  string `json:"name" binding:"required"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -83,9 +111,8 @@ func (h *UserHandler) Register(c *gin.Context) {
 	}
 
 	user := models.User{
-		Email:         req.Email,
-		Name:          req.Name,
-		WalletAddress: req.WalletAddress,
+		Email: req.Email,
+		Name:  req.Name,
 	}
 
 	if err := h.userService.CreateUser(&user); err != nil {
@@ -104,31 +131,3 @@ func (h *UserHandler) Register(c *gin.Context) {
 		"token": token,
 	})
 }
-
-func (h *UserHandler) Login(c *gin.Context) {
-	var req struct {
-		Email string `json:"email" binding:"required,email"`
-	}
-
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-
-	user, err := h.userService.GetUserByEmail(req.Email)
-	if err != nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
-		return
-	}
-
-	token, err := h.userService.GenerateJWT(user.ID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
-		return
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"user":  user,
-		"token": token,
-	})
-}
\ No newline at end of file
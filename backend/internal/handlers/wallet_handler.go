@@ -1,21 +1,36 @@
 package handlers
 
 import (
+	"encoding/hex"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	"aa-sharing-backend/internal/middleware"
 	"aa-sharing-backend/internal/models"
 	"aa-sharing-backend/internal/services"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type WalletHandler struct {
-	walletService *services.WalletService
+	walletService       *services.WalletService
+	partnershipService  *services.PartnershipService
+	verificationService *services.DepositVerificationService
 }
 
-func NewWalletHandler(walletService *services.WalletService) *WalletHandler {
-	return &WalletHandler{walletService: walletService}
+// NewWalletHandler creates a WalletHandler. verificationService is nil when
+// no chain is configured; contributions are then recorded without an
+// on-chain deposit.
+func NewWalletHandler(walletService *services.WalletService, partnershipService *services.PartnershipService, verificationService *services.DepositVerificationService) *WalletHandler {
+	return &WalletHandler{
+		walletService:       walletService,
+		partnershipService:  partnershipService,
+		verificationService: verificationService,
+	}
 }
 
 func (h *WalletHandler) GetWalletBalance(c *gin.Context) {
@@ -34,13 +49,43 @@ func (h *WalletHandler) GetWalletBalance(c *gin.Context) {
 	c.JSON(http.StatusOK, balance)
 }
 
+func (h *WalletHandler) GetLedger(c *gin.Context) {
+	partnershipID, err := strconv.ParseUint(c.Param("partnershipId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid partnership ID"})
+		return
+	}
+
+	accounts, entries, err := h.walletService.GetLedger(uint(partnershipID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"accounts": accounts,
+		"entries":  entries,
+	})
+}
+
+// Contribute records a contribution or gratitude tip. When a chain is
+// configured the client must send the hash of its depositFunds or
+// addGratitude transaction, and the contribution is only confirmed once the
+// deposit has been verified on chain.
 func (h *WalletHandler) Contribute(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
 	var req struct {
-		UserID        uint    `json:"user_id" binding:"required"`
-		PartnershipID uint    `json:"partnership_id" binding:"required"`
-		Amount        float64 `json:"amount" binding:"required"`
-		Description   string  `json:"description"`
-		Type          string  `json:"type" binding:"required"` // gratitude or contribution
+		PartnershipID uint         `json:"partnership_id" binding:"required"`
+		Amount        models.Money `json:"amount"`
+		Description   string       `json:"description"`
+		Type          string       `json:"type" binding:"required"` // gratitude or contribution
+		TxHash        string       `json:"tx_hash"`
+		GoalID        *uint        `json:"goal_id"` // allocate the contribution to this goal
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -48,21 +93,89 @@ func (h *WalletHandler) Contribute(c *gin.Context) {
 		return
 	}
 
+	// Other types, such as withdrawals and settlements, move money out and
+	// have their own endpoints
+	if req.Type != "contribution" && req.Type != "gratitude" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Type must be contribution or gratitude"})
+		return
+	}
+
+	if !req.Amount.IsPositive() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Amount must be greater than 0"})
+		return
+	}
+
+	if h.verificationService != nil && !isTxHash(req.TxHash) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A valid tx_hash is required"})
+		return
+	}
+
+	if !middleware.AuthorizeActivePartner(c, h.partnershipService, req.PartnershipID) {
+		return
+	}
+
+	if req.GoalID != nil {
+		goal, err := h.walletService.GetGoal(*req.GoalID)
+		if err != nil || goal.PartnershipID != req.PartnershipID {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Goal not found"})
+			return
+		}
+		if goal.Status != "active" {
+			c.JSON(http.StatusConflict, gin.H{"error": services.ErrGoalNotActive.Error()})
+			return
+		}
+		if goal.TargetAmount.Code() != req.Amount.Code() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": models.ErrCurrencyMismatch.Error()})
+			return
+		}
+	}
+
 	transaction := models.Transaction{
-		UserID:        req.UserID,
+		UserID:        userID,
 		PartnershipID: req.PartnershipID,
 		Type:          req.Type,
 		Amount:        req.Amount,
 		Description:   req.Description,
 		Status:        "confirmed",
+		GoalID:        req.GoalID,
 	}
 
-	if err := h.walletService.CreateTransaction(&transaction); err != nil {
+	if h.verificationService == nil {
+		if err := h.walletService.CreateTransaction(&transaction); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusCreated, transaction)
+		return
+	}
+
+	transaction.TxHash = req.TxHash
+	if err := h.verificationService.Submit(c.Request.Context(), &transaction); err != nil {
+		if errors.Is(err, services.ErrDepositMismatch) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusCreated, transaction)
+	switch transaction.Status {
+	case "confirmed":
+		c.JSON(http.StatusCreated, transaction)
+	case "failed":
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": transaction.FailureReason, "transaction": transaction})
+	default:
+		c.JSON(http.StatusAccepted, transaction)
+	}
+}
+
+// isTxHash reports whether s is a 0x-prefixed 32-byte hex string.
+func isTxHash(s string) bool {
+	if len(s) != 66 || !strings.HasPrefix(s, "0x") {
+		return false
+	}
+	_, err := hex.DecodeString(s[2:])
+	return err == nil
 }
 
 func (h *WalletHandler) GetTransactions(c *gin.Context) {
@@ -81,12 +194,69 @@ func (h *WalletHandler) GetTransactions(c *gin.Context) {
 	c.JSON(http.StatusOK, transactions)
 }
 
+// SplitFunds records the caller's confirmation of a 50/50 split and performs
+// it once both partners have confirmed. With ?dry_run=true it only returns the
+// payouts the split would produce; a confirmation sends back the balance of
+// that preview, so that partners only ever agree to the payouts they saw.
+func (h *WalletHandler) SplitFunds(c *gin.Context) {
+	partnershipID, err := strconv.ParseUint(c.Param("partnershipId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid partnership ID"})
+		return
+	}
+
+	dryRun := c.Query("dry_run") == "true"
+
+	var preview *services.SplitPreview
+	if dryRun {
+		preview, err = h.walletService.PreviewSplit(uint(partnershipID))
+	} else {
+		// A confirmation is for the balance the partner was shown
+		var req struct {
+			Balance models.Money `json:"balance"`
+		}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if !req.Balance.IsPositive() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "The previewed balance is required"})
+			return
+		}
+
+		userID, _ := middleware.CurrentUserID(c)
+		preview, err = h.walletService.ConfirmSplit(uint(partnershipID), userID, req.Balance)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNothingToSplit),
+			errors.Is(err, services.ErrInvalidStatus),
+			errors.Is(err, services.ErrSplitChanged):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, services.ErrNotPartner):
+			c.JSON(http.StatusForbidden, gin.H{"error": "Not a partner in this partnership"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	status := http.StatusOK
+	if !dryRun && !preview.Executed {
+		status = http.StatusAccepted
+	}
+	c.JSON(status, preview)
+}
+
 func (h *WalletHandler) CreateGoal(c *gin.Context) {
 	var req struct {
-		PartnershipID uint    `json:"partnership_id" binding:"required"`
-		Name          string  `json:"name" binding:"required"`
-		Description   string  `json:"description"`
-		TargetAmount  float64 `json:"target_amount" binding:"required"`
+		PartnershipID uint         `json:"partnership_id" binding:"required"`
+		Name          string       `json:"name" binding:"required"`
+		Description   string       `json:"description"`
+		TargetAmount  models.Money `json:"target_amount"`
+		TargetDate    string       `json:"target_date"` // optional, YYYY-MM-DD or RFC 3339
+		// Milestone amounts; the default percentages of the target when empty
+		Milestones []models.Money `json:"milestones"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -94,16 +264,43 @@ func (h *WalletHandler) CreateGoal(c *gin.Context) {
 		return
 	}
 
+	if !req.TargetAmount.IsPositive() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Target amount must be greater than 0"})
+		return
+	}
+
+	for _, milestone := range req.Milestones {
+		if !milestone.IsPositive() || milestone.Code() != req.TargetAmount.Code() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Milestones must be positive amounts in the target's currency"})
+			return
+		}
+	}
+
+	var targetDate *time.Time
+	if req.TargetDate != "" {
+		date, err := parseTargetDate(req.TargetDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		targetDate = &date
+	}
+
+	if !middleware.AuthorizeActivePartner(c, h.partnershipService, req.PartnershipID) {
+		return
+	}
+
 	goal := models.Goal{
 		PartnershipID: req.PartnershipID,
 		Name:          req.Name,
 		Description:   req.Description,
 		TargetAmount:  req.TargetAmount,
-		CurrentAmount: 0,
+		CurrentAmount: models.NewMoney(0, req.TargetAmount.Code()),
+		TargetDate:    targetDate,
 		Status:        "active",
 	}
 
-	if err := h.walletService.CreateGoal(&goal); err != nil {
+	if err := h.walletService.CreateGoal(&goal, req.Milestones); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -118,11 +315,14 @@ func (h *WalletHandler) GetGoals(c *gin.Context) {
 		return
 	}
 
-	goals, err := h.walletService.GetGoals(uint(partnershipID))
+	goals, err := h.walletService.GetGoalProgress(uint(partnershipID))
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	if goals == nil {
+		goals = []services.GoalProgress{}
+	}
 
 	c.JSON(http.StatusOK, goals)
 }
@@ -134,16 +334,148 @@ func (h *WalletHandler) UpdateGoal(c *gin.Context) {
 		return
 	}
 
-	var updates map[string]interface{}
-	if err := c.ShouldBindJSON(&updates); err != nil {
+	goal, err := h.walletService.GetGoal(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Goal not found"})
+		return
+	}
+
+	if !middleware.AuthorizePartner(c, h.partnershipService, goal.PartnershipID) {
+		return
+	}
+
+	var req struct {
+		Name          *string       `json:"name"`
+		Description   *string       `json:"description"`
+		TargetAmount  *models.Money `json:"target_amount"`
+		CurrentAmount *models.Money `json:"current_amount"`
+		TargetDate    *string       `json:"target_date"` // an empty string clears it
+		Status        *string       `json:"status"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	updates := map[string]interface{}{}
+	if req.Name != nil {
+		updates["name"] = *req.Name
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+	if req.TargetAmount != nil {
+		if !req.TargetAmount.IsPositive() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Target amount must be greater than 0"})
+			return
+		}
+		updates["target_amount_units"] = req.TargetAmount.Units
+		updates["target_amount_currency"] = req.TargetAmount.Code()
+	}
+	if req.CurrentAmount != nil {
+		if req.CurrentAmount.IsNegative() {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Current amount cannot be negative"})
+			return
+		}
+		updates["current_amount_units"] = req.CurrentAmount.Units
+		updates["current_amount_currency"] = req.CurrentAmount.Code()
+	}
+	if req.TargetDate != nil {
+		updates["target_date"] = nil
+		if *req.TargetDate != "" {
+			date, err := parseTargetDate(*req.TargetDate)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			updates["target_date"] = date
+		}
+	}
+	if req.Status != nil {
+		updates["status"] = *req.Status
+	}
+	if len(updates) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No fields to update"})
+		return
+	}
+
 	if err := h.walletService.UpdateGoal(uint(id), updates); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		h.goalError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Goal updated successfully"})
-}
\ No newline at end of file
+}
+
+// ReallocateFunds moves money between the pool and the partnership's goals.
+// Leaving from_goal_id out takes it from the pool; leaving to_goal_id out
+// returns it to the pool.
+func (h *WalletHandler) ReallocateFunds(c *gin.Context) {
+	partnershipID, err := strconv.ParseUint(c.Param("partnershipId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid partnership ID"})
+		return
+	}
+
+	var req struct {
+		FromGoalID *uint        `json:"from_goal_id"`
+		ToGoalID   *uint        `json:"to_goal_id"`
+		Amount     models.Money `json:"amount"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !req.Amount.IsPositive() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Amount must be greater than 0"})
+		return
+	}
+
+	if !middleware.AuthorizeActivePartner(c, h.partnershipService, uint(partnershipID)) {
+		return
+	}
+
+	goals, err := h.walletService.ReallocateFunds(uint(partnershipID), req.FromGoalID, req.ToGoalID, req.Amount)
+	if err != nil {
+		h.goalError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, goals)
+}
+
+// parseTargetDate parses a goal's target date, given as a day (taken as the
+// end of that day in UTC) or an RFC 3339 timestamp. It must be in the future.
+func parseTargetDate(s string) (time.Time, error) {
+	date, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		day, dayErr := time.Parse("2006-01-02", s)
+		if dayErr != nil {
+			return time.Time{}, errors.New("target_date must be YYYY-MM-DD or an RFC 3339 timestamp")
+		}
+		date = day.Add(24*time.Hour - time.Second)
+	}
+	if !date.After(time.Now()) {
+		return time.Time{}, errors.New("target_date must be in the future")
+	}
+	return date, nil
+}
+
+func (h *WalletHandler) goalError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Goal not found"})
+	case errors.Is(err, services.ErrGoalMismatch),
+		errors.Is(err, services.ErrInvalidGoalStatus),
+		errors.Is(err, services.ErrNegativeGoalAmount),
+		errors.Is(err, models.ErrCurrencyMismatch):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrGoalNotActive):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInsufficientFunds):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
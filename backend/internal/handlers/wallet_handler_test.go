@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"aa-sharing-backend/internal/ledger"
+	"aa-sharing-backend/internal/models"
+	"aa-sharing-backend/internal/services"
+	"aa-sharing-backend/internal/testdb"
+)
+
+func TestContributeAcceptsOnlyMoneyComingIn(t *testing.T) {
+	db := testdb.Open(t)
+	userService := services.NewUserService(db, "test-secret")
+	r := authorizationRouter(db, userService)
+	partnership := testdb.Partnership(t, db)
+
+	token, err := userService.GenerateJWT(partnership.UserAID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contribute := func(kind string) int {
+		body := fmt.Sprintf(`{"partnership_id":%d,"amount":"2","type":%q}`, partnership.ID, kind)
+		return serve(r, "POST", "/wallet/contribute", body, token).Code
+	}
+
+	for _, kind := range []string{"withdrawal", "settlement", "adjustment", "Contribution"} {
+		if code := contribute(kind); code != http.StatusBadRequest {
+			t.Errorf("type %q: got %d, want 400", kind, code)
+		}
+	}
+	var recorded int64
+	db.Model(&models.Transaction{}).Where("partnership_id = ?", partnership.ID).Count(&recorded)
+	if recorded != 0 {
+		t.Fatalf("%d transactions recorded for rejected types", recorded)
+	}
+
+	for _, kind := range []string{"contribution", "gratitude"} {
+		if code := contribute(kind); code != http.StatusCreated {
+			t.Errorf("type %q: got %d, want 201", kind, code)
+		}
+	}
+	if balance, err := ledger.PartnershipBalance(db, partnership.ID, models.DefaultCurrency); err != nil || balance.Units != 4_000_000 {
+		t.Errorf("balance %s (%v), want 4 USDC", balance, err)
+	}
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"aa-sharing-backend/internal/middleware"
+	"aa-sharing-backend/internal/models"
+	"aa-sharing-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type WithdrawalHandler struct {
+	withdrawalService  *services.WithdrawalService
+	partnershipService *services.PartnershipService
+}
+
+func NewWithdrawalHandler(withdrawalService *services.WithdrawalService, partnershipService *services.PartnershipService) *WithdrawalHandler {
+	return &WithdrawalHandler{withdrawalService: withdrawalService, partnershipService: partnershipService}
+}
+
+// RequestWithdrawal proposes spending money from the pool, or from a goal
+// when goal_id is set. It takes effect once the other partner approves it.
+func (h *WithdrawalHandler) RequestWithdrawal(c *gin.Context) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var req struct {
+		PartnershipID uint         `json:"partnership_id" binding:"required"`
+		GoalID        *uint        `json:"goal_id"`
+		Amount        models.Money `json:"amount"`
+		Purpose       string       `json:"purpose" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !req.Amount.IsPositive() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Amount must be greater than 0"})
+		return
+	}
+
+	partnership, ok := middleware.ActivePartnership(c, h.partnershipService, req.PartnershipID)
+	if !ok {
+		return
+	}
+
+	request, err := h.withdrawalService.RequestWithdrawal(partnership, userID, req.GoalID, req.Amount, req.Purpose)
+	if err != nil {
+		h.error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, request)
+}
+
+// GetWithdrawals lists a partnership's withdrawal requests, optionally
+// filtered with the status query parameter.
+func (h *WithdrawalHandler) GetWithdrawals(c *gin.Context) {
+	partnershipID, err := strconv.ParseUint(c.Param("partnershipId"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid partnership ID"})
+		return
+	}
+
+	requests, err := h.withdrawalService.GetWithdrawals(uint(partnershipID), c.Query("status"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, requests)
+}
+
+// ApproveWithdrawal approves the other partner's request and spends the
+// money.
+func (h *WithdrawalHandler) ApproveWithdrawal(c *gin.Context) {
+	userID, partnership, id, ok := h.reviewTarget(c)
+	if !ok {
+		return
+	}
+
+	request, err := h.withdrawalService.ApproveWithdrawal(partnership, id, userID)
+	if err != nil {
+		h.error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+// RejectWithdrawal rejects the other partner's request, with an optional
+// reason.
+func (h *WithdrawalHandler) RejectWithdrawal(c *gin.Context) {
+	userID, partnership, id, ok := h.reviewTarget(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	request, err := h.withdrawalService.RejectWithdrawal(partnership, id, userID, req.Reason)
+	if err != nil {
+		h.error(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, request)
+}
+
+// reviewTarget loads the request named by the id path parameter and checks
+// that the caller is a partner of its active partnership.
+func (h *WithdrawalHandler) reviewTarget(c *gin.Context) (uint, *models.Partnership, uint, bool) {
+	userID, ok := middleware.CurrentUserID(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return 0, nil, 0, false
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid withdrawal request ID"})
+		return 0, nil, 0, false
+	}
+
+	request, err := h.withdrawalService.GetWithdrawal(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Withdrawal request not found"})
+		return 0, nil, 0, false
+	}
+
+	partnership, ok := middleware.ActivePartnership(c, h.partnershipService, request.PartnershipID)
+	if !ok {
+		return 0, nil, 0, false
+	}
+	return userID, partnership, uint(id), true
+}
+
+func (h *WithdrawalHandler) error(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Goal or withdrawal request not found"})
+	case errors.Is(err, services.ErrGoalMismatch), errors.Is(err, models.ErrCurrencyMismatch):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrOwnWithdrawal):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrWithdrawalNotPending), errors.Is(err, services.ErrWithdrawalExpired):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, services.ErrInsufficientFunds):
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
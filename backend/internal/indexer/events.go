@@ -0,0 +1,367 @@
+package indexer
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"aa-sharing-backend/internal/chain"
+	"aa-sharing-backend/internal/models"
+	"aa-sharing-backend/internal/services"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Events recorded in IndexedEvent.Event. A PartnershipCreated log either
+// creates a partnership or links one created through the API.
+const (
+	eventPartnershipCreated = "PartnershipCreated"
+	eventPartnershipLinked  = "PartnershipLinked"
+	eventGratitudeAdded     = "GratitudeAdded"
+	eventFundsDeposited     = "FundsDeposited"
+	eventFundsWithdrawn     = "FundsWithdrawn"
+	eventGoalCreated        = "GoalCreated"
+)
+
+// handleLog decodes one AASharing log, applies it to the database and
+// records it as an IndexedEvent. Every event is applied idempotently, so logs
+// may be replayed after a crash between processing a batch and saving its
+// checkpoint.
+func (ix *Indexer) handleLog(l types.Log) error {
+	if l.Removed || len(l.Topics) == 0 {
+		return nil
+	}
+	event, err := ix.abi.EventByID(l.Topics[0])
+	if err != nil {
+		return nil // not an event we index
+	}
+
+	indexed, err := ix.applyLog(l, event)
+	if err != nil || indexed == nil {
+		return err
+	}
+	indexed.ChainID = ix.chainID
+	indexed.Contract = ix.contract()
+	indexed.BlockNumber = l.BlockNumber
+	indexed.BlockHash = l.BlockHash.Hex()
+	indexed.TxHash = l.TxHash.Hex()
+	indexed.LogIndex = l.Index
+	return ix.db.Clauses(clause.OnConflict{DoNothing: true}).Create(indexed).Error
+}
+
+// applyLog decodes a log with the AASharing binding and applies it. It
+// returns nil when the log was skipped and there is nothing to roll back.
+func (ix *Indexer) applyLog(l types.Log, event *abi.Event) (*models.IndexedEvent, error) {
+	switch event.Name {
+	case "PartnershipCreated":
+		e, err := ix.events.ParsePartnershipCreated(l)
+		if err != nil {
+			return nil, fmt.Errorf("decode %s: %w", event.Name, err)
+		}
+		return ix.onPartnershipCreated(e)
+	case "GratitudeAdded":
+		e, err := ix.events.ParseGratitudeAdded(l)
+		if err != nil {
+			return nil, fmt.Errorf("decode %s: %w", event.Name, err)
+		}
+		return ix.onGratitudeAdded(e)
+	case "FundsDeposited":
+		e, err := ix.events.ParseFundsDeposited(l)
+		if err != nil {
+			return nil, fmt.Errorf("decode %s: %w", event.Name, err)
+		}
+		return ix.onFundsDeposited(e)
+	case "FundsWithdrawn":
+		e, err := ix.events.ParseFundsWithdrawn(l)
+		if err != nil {
+			return nil, fmt.Errorf("decode %s: %w", event.Name, err)
+		}
+		return ix.onFundsWithdrawn(e)
+	case "GoalCreated":
+		e, err := ix.events.ParseGoalCreated(l)
+		if err != nil {
+			return nil, fmt.Errorf("decode %s: %w", event.Name, err)
+		}
+		return ix.onGoalCreated(e)
+	}
+	return nil, nil
+}
+
+func (ix *Indexer) onPartnershipCreated(e *chain.AASharingPartnershipCreated) (*models.IndexedEvent, error) {
+	if !e.PartnershipId.IsUint64() {
+		return nil, fmt.Errorf("partnership ID %s out of range", e.PartnershipId)
+	}
+	chainID := e.PartnershipId.Uint64()
+
+	var partnership models.Partnership
+	err := ix.db.Where("chain_partnership_id = ?", chainID).First(&partnership).Error
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	partner1, err := ix.userByWallet(e.Partner1)
+	if err != nil {
+		return nil, err
+	}
+	partner2, err := ix.userByWallet(e.Partner2)
+	if err != nil {
+		return nil, err
+	}
+	if partner1 == nil || partner2 == nil {
+		log.Printf("Indexer: skipping partnership %d, partner wallet not registered", chainID)
+		return nil, nil
+	}
+
+	// Link the on-chain partnership to one created through the API
+	err = ix.db.Where("chain_partnership_id IS NULL AND status IN ?", []string{models.PartnershipPending, models.PartnershipActive, models.PartnershipInactive}).
+		Where("(user_a_id = ? AND user_b_id = ?) OR (user_a_id = ? AND user_b_id = ?)", partner1.ID, partner2.ID, partner2.ID, partner1.ID).
+		Order("id").
+		First(&partnership).Error
+	if err == nil {
+		nicknameA, nicknameB := e.Nickname1, e.Nickname2
+		if partnership.UserAID != partner1.ID {
+			nicknameA, nicknameB = e.Nickname2, e.Nickname1
+		}
+		if err := ix.db.Model(&partnership).Updates(map[string]interface{}{
+			"chain_partnership_id": chainID,
+			"nickname_a":           nicknameA,
+			"nickname_b":           nicknameB,
+			"status":               models.PartnershipActive,
+		}).Error; err != nil {
+			return nil, err
+		}
+		return &models.IndexedEvent{Event: eventPartnershipLinked, RecordID: partnership.ID}, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	partnership = models.Partnership{
+		UserAID:            partner1.ID,
+		UserBID:            partner2.ID,
+		NicknameA:          e.Nickname1,
+		NicknameB:          e.Nickname2,
+		Status:             models.PartnershipActive,
+		ChainPartnershipID: &chainID,
+	}
+	if err := ix.db.Create(&partnership).Error; err != nil {
+		return nil, err
+	}
+	return &models.IndexedEvent{Event: eventPartnershipCreated, RecordID: partnership.ID}, nil
+}
+
+func (ix *Indexer) onGratitudeAdded(e *chain.AASharingGratitudeAdded) (*models.IndexedEvent, error) {
+	partnership, err := ix.partnershipByChainID(e.PartnershipId)
+	if err != nil || partnership == nil {
+		return nil, err
+	}
+	contributor, err := ix.userByWallet(e.Contributor)
+	if err != nil {
+		return nil, err
+	}
+	if contributor == nil {
+		log.Printf("Indexer: skipping gratitude in tx %s, contributor wallet not registered", e.Raw.TxHash.Hex())
+		return nil, nil
+	}
+	amount, err := usdcAmount(e.UsdcAmount)
+	if err != nil {
+		return nil, err
+	}
+	if !e.Timestamp.IsInt64() {
+		return nil, fmt.Errorf("timestamp %s out of range", e.Timestamp)
+	}
+
+	logIndex := e.Raw.Index
+	entry := models.GratitudeEntry{
+		UserID:        contributor.ID,
+		PartnershipID: partnership.ID,
+		Content:       e.GratitudeText,
+		Amount:        amount,
+		TxHash:        e.Raw.TxHash.Hex(),
+		LogIndex:      &logIndex,
+		CreatedAt:     time.Unix(e.Timestamp.Int64(), 0),
+	}
+	if err := ix.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&entry).Error; err != nil {
+		return nil, err
+	}
+	return &models.IndexedEvent{Event: eventGratitudeAdded}, nil
+}
+
+func (ix *Indexer) onFundsDeposited(e *chain.AASharingFundsDeposited) (*models.IndexedEvent, error) {
+	l := e.Raw
+	partnership, err := ix.partnershipByChainID(e.PartnershipId)
+	if err != nil || partnership == nil {
+		return nil, err
+	}
+	depositor, err := ix.userByWallet(e.Depositor)
+	if err != nil {
+		return nil, err
+	}
+	if depositor == nil {
+		log.Printf("Indexer: skipping deposit in tx %s, depositor wallet not registered", l.TxHash.Hex())
+		return nil, nil
+	}
+	amount, err := usdcAmount(e.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	indexed := &models.IndexedEvent{Event: eventFundsDeposited}
+
+	var count int64
+	if err := ix.db.Model(&models.Transaction{}).
+		Where("tx_hash = ? AND log_index = ?", l.TxHash.Hex(), l.Index).
+		Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return indexed, nil
+	}
+
+	// A relayed contribution already has its own transaction, unless it
+	// was reverted by a reorganization and the log is now re-included
+	if e.Source == "gasless" {
+		var relayed models.GaslessContribution
+		err := ix.db.Joins("JOIN chain_submissions ON chain_submissions.id = gasless_contributions.submission_id").
+			Where("chain_submissions.tx_hash = ? OR chain_submissions.replaced_tx_hashes LIKE ?",
+				l.TxHash.Hex(), "%"+l.TxHash.Hex()+"%").
+			First(&relayed).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+		if err == nil {
+			attached, err := ix.walletService.AttachChainLog(relayed.TransactionID, l.TxHash.Hex(), l.Index)
+			if err != nil {
+				return nil, err
+			}
+			if attached {
+				return indexed, nil
+			}
+		}
+	}
+
+	// The deposit is credited once it has enough confirmations
+	transactionType := "contribution"
+	if e.Source == "gratitude" {
+		transactionType = "gratitude"
+	}
+	logIndex := l.Index
+	if err := ix.walletService.CreateTransaction(&models.Transaction{
+		UserID:        depositor.ID,
+		PartnershipID: partnership.ID,
+		Type:          transactionType,
+		Amount:        amount,
+		Description:   "On-chain deposit (" + e.Source + ")",
+		TxHash:        l.TxHash.Hex(),
+		LogIndex:      &logIndex,
+		Status:        "pending",
+	}); err != nil {
+		return nil, err
+	}
+	return indexed, nil
+}
+
+func (ix *Indexer) onFundsWithdrawn(e *chain.AASharingFundsWithdrawn) (*models.IndexedEvent, error) {
+	partnership, err := ix.partnershipByChainID(e.PartnershipId)
+	if err != nil || partnership == nil {
+		return nil, err
+	}
+	amountEach, err := usdcAmount(e.AmountEach)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := ix.walletService.RecordChainWithdrawal(partnership.ID, []services.SplitPayout{
+		{UserID: partnership.UserAID, Amount: amountEach},
+		{UserID: partnership.UserBID, Amount: amountEach},
+	}, e.Raw.TxHash.Hex(), e.Raw.Index); err != nil {
+		return nil, err
+	}
+	return &models.IndexedEvent{Event: eventFundsWithdrawn}, nil
+}
+
+// onGoalCreated creates the goal with the default milestones, as goals
+// created through the API get when no custom ones are given.
+func (ix *Indexer) onGoalCreated(e *chain.AASharingGoalCreated) (*models.IndexedEvent, error) {
+	partnership, err := ix.partnershipByChainID(e.PartnershipId)
+	if err != nil || partnership == nil {
+		return nil, err
+	}
+	target, err := usdcAmount(e.TargetAmount)
+	if err != nil {
+		return nil, err
+	}
+	if !e.GoalId.IsUint64() {
+		return nil, fmt.Errorf("goal ID %s out of range", e.GoalId)
+	}
+
+	chainGoalID := e.GoalId.Uint64()
+	goal := models.Goal{
+		PartnershipID: partnership.ID,
+		Name:          e.GoalName,
+		TargetAmount:  target,
+		CurrentAmount: models.USDC(0),
+		Status:        "active",
+		ChainGoalID:   &chainGoalID,
+	}
+	err = ix.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&goal).Error; err != nil {
+			return err
+		}
+		if goal.ID == 0 {
+			return tx.Where("partnership_id = ? AND chain_goal_id = ?", partnership.ID, chainGoalID).
+				First(&goal).Error
+		}
+		milestones := goal.DefaultMilestones()
+		return tx.Create(&milestones).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &models.IndexedEvent{Event: eventGoalCreated, RecordID: goal.ID}, nil
+}
+
+// userByWallet returns the user registered with address, or nil.
+func (ix *Indexer) userByWallet(address common.Address) (*models.User, error) {
+	var user models.User
+	err := ix.db.Where("LOWER(wallet_address) = LOWER(?)", address.Hex()).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// partnershipByChainID returns the partnership linked to an on-chain
+// partnershipId, or nil when it has not been indexed.
+func (ix *Indexer) partnershipByChainID(chainID *big.Int) (*models.Partnership, error) {
+	var partnership models.Partnership
+	err := ix.db.Where("chain_partnership_id = ?", chainID.Uint64()).First(&partnership).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		log.Printf("Indexer: skipping event for unknown partnership %s", chainID)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &partnership, nil
+}
+
+// usdcAmount converts an on-chain USDC amount in base units.
+func usdcAmount(amount *big.Int) (models.Money, error) {
+	if !amount.IsInt64() {
+		return models.Money{}, fmt.Errorf("amount %s out of range", amount)
+	}
+	return models.USDC(amount.Int64()), nil
+}
@@ -0,0 +1,189 @@
+// Package indexer keeps the database in step with the AASharing contract by
+// polling its event logs over JSON-RPC.
+package indexer
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"aa-sharing-backend/internal/chain"
+	"aa-sharing-backend/internal/models"
+	"aa-sharing-backend/internal/services"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Client is the subset of the JSON-RPC API the indexer needs. It is
+// satisfied by *ethclient.Client.
+type Client interface {
+	bind.ContractFilterer
+	ChainID(ctx context.Context) (*big.Int, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+type Config struct {
+	Contract      common.Address
+	StartBlock    uint64        // first block to index when there is no checkpoint
+	BatchSize     uint64        // blocks per eth_getLogs request
+	PollInterval  time.Duration // wait between polls once caught up
+	Confirmations uint64        // blocks on top of a deposit before it is confirmed
+}
+
+type Indexer struct {
+	db            *gorm.DB
+	client        Client
+	walletService *services.WalletService
+	cfg           Config
+	abi           *abi.ABI
+	events        *chain.AASharingFilterer
+	chainID       uint64
+}
+
+func New(db *gorm.DB, client Client, walletService *services.WalletService, cfg Config) (*Indexer, error) {
+	parsed, err := chain.AASharingMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	events, err := chain.NewAASharingFilterer(cfg.Contract, client)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = 1000
+	}
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = 15 * time.Second
+	}
+	return &Indexer{
+		db:            db,
+		client:        client,
+		walletService: walletService,
+		cfg:           cfg,
+		abi:           parsed,
+		events:        events,
+	}, nil
+}
+
+// Run indexes new blocks until ctx is cancelled.
+func (ix *Indexer) Run(ctx context.Context) error {
+	for {
+		if err := ix.Sync(ctx); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return err
+			}
+			log.Println("Indexer sync failed:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(ix.cfg.PollInterval):
+		}
+	}
+}
+
+// Sync indexes every block from the checkpoint up to the current head. Events
+// from blocks that have since been reorganized away are rolled back first,
+// and deposits that have reached the confirmation depth are confirmed last.
+func (ix *Indexer) Sync(ctx context.Context) error {
+	if ix.chainID == 0 {
+		chainID, err := ix.client.ChainID(ctx)
+		if err != nil {
+			return err
+		}
+		ix.chainID = chainID.Uint64()
+	}
+
+	checkpoint, err := ix.loadCheckpoint()
+	if err != nil {
+		return err
+	}
+
+	head, err := ix.client.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := ix.handleReorg(ctx, checkpoint, head); err != nil {
+		return err
+	}
+
+	for from := checkpoint.LastBlock + 1; from <= head; from = checkpoint.LastBlock + 1 {
+		to := from + ix.cfg.BatchSize - 1
+		if to > head {
+			to = head
+		}
+
+		logs, err := ix.client.FilterLogs(ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(to),
+			Addresses: []common.Address{ix.cfg.Contract},
+		})
+		if err != nil {
+			return err
+		}
+
+		blocks := map[uint64]common.Hash{}
+		for _, l := range logs {
+			if err := ix.handleLog(l); err != nil {
+				return err
+			}
+			blocks[l.BlockNumber] = l.BlockHash
+		}
+
+		// Remember the last block of the batch so that a reorganization is
+		// noticed even when it touches no block with logs
+		header, err := ix.client.HeaderByNumber(ctx, new(big.Int).SetUint64(to))
+		if err != nil {
+			return err
+		}
+		blocks[to] = header.Hash()
+		if err := ix.saveBlocks(blocks); err != nil {
+			return err
+		}
+
+		checkpoint.LastBlock = to
+		if err := ix.db.Save(checkpoint).Error; err != nil {
+			return err
+		}
+	}
+
+	return ix.confirmEvents(head)
+}
+
+func (ix *Indexer) loadCheckpoint() (*models.IndexerCheckpoint, error) {
+	checkpoint := models.IndexerCheckpoint{
+		ChainID:  ix.chainID,
+		Contract: ix.contract(),
+	}
+	if ix.cfg.StartBlock > 0 {
+		checkpoint.LastBlock = ix.cfg.StartBlock - 1
+	}
+	if err := ix.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&checkpoint).Error; err != nil {
+		return nil, err
+	}
+	if checkpoint.ID != 0 {
+		return &checkpoint, nil
+	}
+
+	if err := ix.db.Where("chain_id = ? AND contract = ?", checkpoint.ChainID, checkpoint.Contract).
+		First(&checkpoint).Error; err != nil {
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+func (ix *Indexer) contract() string {
+	return strings.ToLower(ix.cfg.Contract.Hex())
+}
@@ -0,0 +1,150 @@
+package indexer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"aa-sharing-backend/internal/chaintest"
+	"aa-sharing-backend/internal/ledger"
+	"aa-sharing-backend/internal/models"
+	"aa-sharing-backend/internal/services"
+	"aa-sharing-backend/internal/testdb"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"gorm.io/gorm"
+)
+
+const testConfirmations = 3
+
+// setup returns an indexer for a fresh AASharing contract and two funded
+// partner accounts whose wallets are registered to users.
+func setup(t *testing.T) (*gorm.DB, *chaintest.Chain, *chaintest.AASharing, *Indexer, [2]*ecdsa.PrivateKey) {
+	t.Helper()
+	db := testdb.Open(t)
+	c := chaintest.New(t)
+	aa := c.DeployAASharing(t)
+
+	var keys [2]*ecdsa.PrivateKey
+	for i := range keys {
+		keys[i] = c.Account(t)
+		user := testdb.User(t, db)
+		if err := db.Model(user).Update("wallet_address", crypto.PubkeyToAddress(keys[i].PublicKey).Hex()).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ix, err := New(db, c.Client, services.NewWalletService(db), Config{
+		Contract:      aa.Address,
+		Confirmations: testConfirmations,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, c, aa, ix, keys
+}
+
+func runSync(t *testing.T, ix *Indexer) {
+	t.Helper()
+	if err := ix.Sync(context.Background()); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+}
+
+func TestIndexerAppliesEvents(t *testing.T) {
+	db, c, aa, ix, keys := setup(t)
+	alice, bob := keys[0], keys[1]
+
+	chainID := aa.CreatePartnership(t, alice, bob)
+	c.Mined(t)(aa.CreateGoal(c.Transactor(t, alice), chainID, "Trip", "Summer trip", big.NewInt(100_000_000)))
+	aa.Fund(t, alice, 10_000_000)
+	aa.Fund(t, bob, 2_000_000)
+	c.Mined(t)(aa.DepositFunds(c.Transactor(t, alice), chainID, big.NewInt(10_000_000)))
+	c.Mined(t)(aa.AddGratitude(c.Transactor(t, bob), chainID, "Thanks for dinner", big.NewInt(2_000_000)))
+
+	runSync(t, ix)
+
+	var partnership models.Partnership
+	if err := db.Where("chain_partnership_id = ?", chainID.Uint64()).First(&partnership).Error; err != nil {
+		t.Fatalf("partnership not indexed: %v", err)
+	}
+	if partnership.Status != models.PartnershipActive || partnership.NicknameA != "Alice" {
+		t.Errorf("partnership %+v", partnership)
+	}
+
+	var goal models.Goal
+	if err := db.Preload("Milestones").Where("partnership_id = ?", partnership.ID).First(&goal).Error; err != nil {
+		t.Fatalf("goal not indexed: %v", err)
+	}
+	if goal.TargetAmount != models.USDC(100_000_000) || len(goal.Milestones) != len(models.DefaultMilestonePercents) {
+		t.Errorf("goal target %s with %d milestones", goal.TargetAmount, len(goal.Milestones))
+	}
+
+	var gratitude int64
+	db.Model(&models.GratitudeEntry{}).Where("partnership_id = ?", partnership.ID).Count(&gratitude)
+	if gratitude != 1 {
+		t.Errorf("%d gratitude entries, want 1", gratitude)
+	}
+
+	// Deposits wait for the confirmation depth before they are credited
+	var transactions []models.Transaction
+	db.Where("partnership_id = ?", partnership.ID).Order("id").Find(&transactions)
+	if len(transactions) != 2 || transactions[0].Status != "pending" || transactions[1].Type != "gratitude" {
+		t.Fatalf("transactions %+v", transactions)
+	}
+	if balance, _ := ledger.PartnershipBalance(db, partnership.ID, models.DefaultCurrency); !balance.IsZero() {
+		t.Errorf("unconfirmed deposits credited: %s", balance)
+	}
+
+	c.MineBlocks(t, testConfirmations)
+	runSync(t, ix)
+
+	var pending int64
+	db.Model(&models.Transaction{}).Where("partnership_id = ? AND status <> ?", partnership.ID, "confirmed").Count(&pending)
+	if pending != 0 {
+		t.Errorf("%d deposits still unconfirmed", pending)
+	}
+	if balance, _ := ledger.PartnershipBalance(db, partnership.ID, models.DefaultCurrency); balance.Units != 12_000_000 {
+		t.Errorf("balance %d, want 12000000", balance.Units)
+	}
+}
+
+func TestIndexerReplayIsIdempotent(t *testing.T) {
+	db, c, aa, ix, keys := setup(t)
+	alice, bob := keys[0], keys[1]
+
+	chainID := aa.CreatePartnership(t, alice, bob)
+	c.Mined(t)(aa.CreateGoal(c.Transactor(t, alice), chainID, "Trip", "", big.NewInt(50_000_000)))
+	aa.Fund(t, bob, 3_000_000)
+	c.Mined(t)(aa.DepositFunds(c.Transactor(t, bob), chainID, big.NewInt(3_000_000)))
+	c.MineBlocks(t, testConfirmations)
+	runSync(t, ix)
+
+	// As after a crash between applying a batch and saving its checkpoint
+	if err := db.Model(&models.IndexerCheckpoint{}).Where("1 = 1").Update("last_block", 0).Error; err != nil {
+		t.Fatal(err)
+	}
+	runSync(t, ix)
+
+	for model, want := range map[interface{}]int64{
+		&models.Partnership{}:   1,
+		&models.Goal{}:          1,
+		&models.GoalMilestone{}: int64(len(models.DefaultMilestonePercents)),
+		&models.Transaction{}:   1,
+	} {
+		var count int64
+		db.Model(model).Count(&count)
+		if count != want {
+			t.Errorf("%T: %d rows after replay, want %d", model, count, want)
+		}
+	}
+	var partnership models.Partnership
+	db.First(&partnership)
+	if balance, _ := ledger.PartnershipBalance(db, partnership.ID, models.DefaultCurrency); balance.Units != 3_000_000 {
+		t.Errorf("balance %d after replay, want 3000000", balance.Units)
+	}
+	if unbalanced, err := ledger.UnbalancedEntries(db); err != nil || len(unbalanced) != 0 {
+		t.Errorf("unbalanced journal entries %v (%v)", unbalanced, err)
+	}
+}
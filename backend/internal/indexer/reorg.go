@@ -0,0 +1,193 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/big"
+
+	"aa-sharing-backend/internal/models"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// reorgMargin is how many blocks beyond the confirmation depth are kept in
+// IndexedBlock and checked for reorganizations.
+const reorgMargin = 64
+
+// saveBlocks records the hashes of indexed blocks.
+func (ix *Indexer) saveBlocks(blocks map[uint64]common.Hash) error {
+	for number, hash := range blocks {
+		block := models.IndexedBlock{
+			ChainID:  ix.chainID,
+			Contract: ix.contract(),
+			Number:   number,
+			Hash:     hash.Hex(),
+		}
+		if err := ix.db.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "chain_id"}, {Name: "contract"}, {Name: "number"}},
+			DoUpdates: clause.AssignmentColumns([]string{"hash"}),
+		}).Create(&block).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handleReorg compares the stored hashes of recent blocks with the chain.
+// When one no longer matches, every event from that block on is rolled back
+// and the checkpoint is rewound to the last block that still matches, so the
+// new branch is indexed by the rest of Sync.
+func (ix *Indexer) handleReorg(ctx context.Context, checkpoint *models.IndexerCheckpoint, head uint64) error {
+	window := ix.cfg.Confirmations + reorgMargin
+
+	var blocks []models.IndexedBlock
+	query := ix.db.Where("chain_id = ? AND contract = ?", ix.chainID, ix.contract())
+	if head > window {
+		query = query.Where("number >= ?", head-window)
+	}
+	if err := query.Order("number").Find(&blocks).Error; err != nil {
+		return err
+	}
+
+	// The lowest mismatching block is where the chains diverged at the latest
+	var ancestor *models.IndexedBlock
+	var forked *models.IndexedBlock
+	for i := range blocks {
+		header, err := ix.client.HeaderByNumber(ctx, new(big.Int).SetUint64(blocks[i].Number))
+		if err != nil && !errors.Is(err, ethereum.NotFound) {
+			return err
+		}
+		if header != nil && header.Hash().Hex() == blocks[i].Hash {
+			ancestor = &blocks[i]
+			continue
+		}
+		forked = &blocks[i]
+		break
+	}
+	if forked == nil {
+		return ix.pruneBlocks(head, window)
+	}
+
+	// Without a matching block to fall back to, rescan the whole window
+	rewindTo := uint64(0)
+	switch {
+	case ancestor != nil:
+		rewindTo = ancestor.Number
+	case forked.Number > window:
+		rewindTo = forked.Number - window
+	}
+	if ix.cfg.StartBlock > 0 && rewindTo < ix.cfg.StartBlock-1 {
+		rewindTo = ix.cfg.StartBlock - 1
+	}
+	log.Printf("Indexer: chain reorganization at block %d, rewinding to block %d", forked.Number, rewindTo)
+
+	if err := ix.rollback(rewindTo); err != nil {
+		return err
+	}
+	if err := ix.db.Where("chain_id = ? AND contract = ? AND number > ?", ix.chainID, ix.contract(), rewindTo).
+		Delete(&models.IndexedBlock{}).Error; err != nil {
+		return err
+	}
+	if checkpoint.LastBlock > rewindTo {
+		checkpoint.LastBlock = rewindTo
+		return ix.db.Save(checkpoint).Error
+	}
+	return nil
+}
+
+// rollback undoes every indexed event after block number, latest first.
+// Each event is deleted once undone, so an interrupted rollback resumes where
+// it stopped.
+func (ix *Indexer) rollback(number uint64) error {
+	var events []models.IndexedEvent
+	if err := ix.db.Where("chain_id = ? AND contract = ? AND block_number > ?", ix.chainID, ix.contract(), number).
+		Order("block_number DESC, log_index DESC").
+		Find(&events).Error; err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		if err := ix.undoEvent(&event); err != nil {
+			return err
+		}
+		if err := ix.db.Delete(&event).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ix *Indexer) undoEvent(event *models.IndexedEvent) error {
+	switch event.Event {
+	case eventPartnershipCreated:
+		return ix.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(&models.Partnership{}).Where("id = ?", event.RecordID).
+				Update("chain_partnership_id", nil).Error; err != nil {
+				return err
+			}
+			return tx.Delete(&models.Partnership{}, event.RecordID).Error
+		})
+	case eventPartnershipLinked:
+		return ix.db.Model(&models.Partnership{}).Where("id = ?", event.RecordID).
+			Update("chain_partnership_id", nil).Error
+	case eventGratitudeAdded:
+		return ix.db.Unscoped().
+			Where("tx_hash = ? AND log_index = ?", event.TxHash, event.LogIndex).
+			Delete(&models.GratitudeEntry{}).Error
+	case eventFundsDeposited, eventFundsWithdrawn:
+		return ix.walletService.RevertChainTransactions(event.TxHash, event.LogIndex)
+	case eventGoalCreated:
+		return ix.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Model(&models.Goal{}).Where("id = ?", event.RecordID).
+				Update("chain_goal_id", nil).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("goal_id = ?", event.RecordID).Delete(&models.GoalMilestone{}).Error; err != nil {
+				return err
+			}
+			return tx.Delete(&models.Goal{}, event.RecordID).Error
+		})
+	}
+	return nil
+}
+
+// confirmEvents confirms the events that are at least Confirmations blocks
+// deep, which moves their transactions from pending to confirmed.
+func (ix *Indexer) confirmEvents(head uint64) error {
+	if head < ix.cfg.Confirmations {
+		return nil
+	}
+
+	var events []models.IndexedEvent
+	if err := ix.db.Where("chain_id = ? AND contract = ? AND confirmed = ? AND block_number <= ?",
+		ix.chainID, ix.contract(), false, head-ix.cfg.Confirmations).
+		Order("block_number, log_index").
+		Find(&events).Error; err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		if event.Event == eventFundsDeposited || event.Event == eventFundsWithdrawn {
+			if err := ix.walletService.ConfirmChainTransactions(event.TxHash, event.LogIndex); err != nil {
+				return err
+			}
+		}
+		if err := ix.db.Model(&event).Update("confirmed", true).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneBlocks forgets block hashes too old to be reorganized.
+func (ix *Indexer) pruneBlocks(head, window uint64) error {
+	if head <= window {
+		return nil
+	}
+	return ix.db.Where("chain_id = ? AND contract = ? AND number < ?", ix.chainID, ix.contract(), head-window).
+		Delete(&models.IndexedBlock{}).Error
+}
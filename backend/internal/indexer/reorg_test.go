@@ -0,0 +1,102 @@
+package indexer
+
+import (
+	"math/big"
+	"testing"
+
+	"aa-sharing-backend/internal/ledger"
+	"aa-sharing-backend/internal/models"
+)
+
+func TestReorgRevertsDroppedDeposit(t *testing.T) {
+	db, c, aa, ix, keys := setup(t)
+	alice, bob := keys[0], keys[1]
+
+	chainID := aa.CreatePartnership(t, alice, bob)
+	c.MineBlocks(t, testConfirmations)
+	runSync(t, ix)
+
+	// Alice's deposit is indexed, then dropped by a reorganization that
+	// includes Bob's deposit instead
+	fork := c.Snapshot(t)
+	aa.Fund(t, alice, 5_000_000)
+	dropped := c.Mined(t)(aa.DepositFunds(c.Transactor(t, alice), chainID, big.NewInt(5_000_000)))
+	runSync(t, ix)
+
+	c.Revert(t, fork)
+	aa.Fund(t, bob, 7_000_000)
+	c.Mined(t)(aa.DepositFunds(c.Transactor(t, bob), chainID, big.NewInt(7_000_000)))
+	c.Mine(t)
+	runSync(t, ix)
+
+	var reverted models.Transaction
+	if err := db.Where("tx_hash = ?", dropped.TxHash.Hex()).First(&reverted).Error; err != nil {
+		t.Fatal(err)
+	}
+	if reverted.Status != "failed" || reverted.LogIndex != nil {
+		t.Errorf("dropped deposit is %s with log index %v, want failed and detached", reverted.Status, reverted.LogIndex)
+	}
+
+	c.MineBlocks(t, testConfirmations)
+	runSync(t, ix)
+
+	var partnership models.Partnership
+	if err := db.Where("chain_partnership_id = ?", chainID.Uint64()).First(&partnership).Error; err != nil {
+		t.Fatal(err)
+	}
+	if balance, _ := ledger.PartnershipBalance(db, partnership.ID, models.DefaultCurrency); balance.Units != 7_000_000 {
+		t.Errorf("balance %d, want only Bob's 7000000", balance.Units)
+	}
+	var wallet models.WalletBalance
+	db.Where("partnership_id = ?", partnership.ID).First(&wallet)
+	if wallet.Balance.Units != 7_000_000 {
+		t.Errorf("stored wallet balance %d, want 7000000", wallet.Balance.Units)
+	}
+	if unbalanced, err := ledger.UnbalancedEntries(db); err != nil || len(unbalanced) != 0 {
+		t.Errorf("unbalanced journal entries %v (%v)", unbalanced, err)
+	}
+}
+
+func TestReorgRollsBackPartnershipAndGoal(t *testing.T) {
+	db, c, aa, ix, keys := setup(t)
+	alice, bob := keys[0], keys[1]
+
+	fork := c.Snapshot(t)
+	chainID := aa.CreatePartnership(t, alice, bob)
+	c.Mined(t)(aa.CreateGoal(c.Transactor(t, alice), chainID, "Trip", "", big.NewInt(40_000_000)))
+	runSync(t, ix)
+
+	var count int64
+	db.Model(&models.Goal{}).Count(&count)
+	if count != 1 {
+		t.Fatalf("%d goals before the reorganization, want 1", count)
+	}
+
+	// On the new branch neither exists
+	c.Revert(t, fork)
+	c.MineBlocks(t, 3)
+	runSync(t, ix)
+
+	for model, name := range map[interface{}]string{
+		&models.Partnership{}:   "partnerships",
+		&models.Goal{}:          "goals",
+		&models.GoalMilestone{}: "milestones",
+		&models.IndexedEvent{}:  "indexed events",
+	} {
+		db.Model(model).Count(&count)
+		if count != 0 {
+			t.Errorf("%d %s left after the reorganization", count, name)
+		}
+	}
+
+	// The same on-chain IDs are then indexed afresh
+	again := aa.CreatePartnership(t, alice, bob)
+	if again.Cmp(chainID) != 0 {
+		t.Fatalf("partnership ID %s on the new branch, want %s", again, chainID)
+	}
+	runSync(t, ix)
+	db.Model(&models.Partnership{}).Where("chain_partnership_id = ?", chainID.Uint64()).Count(&count)
+	if count != 1 {
+		t.Errorf("%d partnerships with chain ID %s, want 1", count, chainID)
+	}
+}
@@ -0,0 +1,110 @@
+package ledger
+
+import (
+	"aa-sharing-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// AccountBalance is the sum of all lines posted to one account.
+type AccountBalance struct {
+	models.LedgerAccount
+	Balance models.Money `json:"balance"`
+}
+
+// Balance sums the lines of a partnership's accounts of the given types.
+func Balance(db *gorm.DB, partnershipID uint, currency string, accountTypes ...string) (models.Money, error) {
+	var units int64
+	err := db.Model(&models.JournalLine{}).
+		Joins("JOIN ledger_accounts ON ledger_accounts.id = journal_lines.account_id").
+		Where("ledger_accounts.partnership_id = ? AND ledger_accounts.type IN ? AND ledger_accounts.currency = ?", partnershipID, accountTypes, currency).
+		Select("COALESCE(SUM(journal_lines.amount_units), 0)").
+		Scan(&units).Error
+	if err != nil {
+		return models.Money{}, err
+	}
+	return models.NewMoney(units, currency), nil
+}
+
+// PartnershipBalance returns the funds a partnership holds across its pool
+// and goals.
+func PartnershipBalance(db *gorm.DB, partnershipID uint, currency string) (models.Money, error) {
+	return Balance(db, partnershipID, currency, HoldingAccounts...)
+}
+
+// GoalBalance returns the funds allocated to a goal.
+func GoalBalance(db *gorm.DB, goal *models.Goal) (models.Money, error) {
+	currency := goal.TargetAmount.Code()
+	var units int64
+	err := db.Model(&models.JournalLine{}).
+		Joins("JOIN ledger_accounts ON ledger_accounts.id = journal_lines.account_id").
+		Where("ledger_accounts.type = ? AND ledger_accounts.goal_id = ? AND ledger_accounts.currency = ?", AccountGoal, goal.ID, currency).
+		Select("COALESCE(SUM(journal_lines.amount_units), 0)").
+		Scan(&units).Error
+	if err != nil {
+		return models.Money{}, err
+	}
+	return models.NewMoney(units, currency), nil
+}
+
+// AccountBalances returns every ledger account of a partnership with its
+// balance.
+func AccountBalances(db *gorm.DB, partnershipID uint) ([]AccountBalance, error) {
+	var accounts []models.LedgerAccount
+	if err := db.Where("partnership_id = ?", partnershipID).Order("id").Find(&accounts).Error; err != nil {
+		return nil, err
+	}
+
+	var sums []struct {
+		AccountID uint
+		Units     int64
+	}
+	if err := db.Model(&models.JournalLine{}).
+		Joins("JOIN ledger_accounts ON ledger_accounts.id = journal_lines.account_id").
+		Where("ledger_accounts.partnership_id = ?", partnershipID).
+		Group("journal_lines.account_id").
+		Select("journal_lines.account_id AS account_id, SUM(journal_lines.amount_units) AS units").
+		Scan(&sums).Error; err != nil {
+		return nil, err
+	}
+	byAccount := make(map[uint]int64, len(sums))
+	for _, sum := range sums {
+		byAccount[sum.AccountID] = sum.Units
+	}
+
+	balances := make([]AccountBalance, 0, len(accounts))
+	for _, account := range accounts {
+		balances = append(balances, AccountBalance{
+			LedgerAccount: account,
+			Balance:       models.NewMoney(byAccount[account.ID], account.Currency),
+		})
+	}
+	return balances, nil
+}
+
+// Entries returns a partnership's journal entries with their lines, newest
+// first.
+func Entries(db *gorm.DB, partnershipID uint) ([]models.JournalEntry, error) {
+	var entries []models.JournalEntry
+	if err := db.Where("partnership_id = ?", partnershipID).
+		Preload("Lines.Account").
+		Order("id DESC").
+		Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// UnbalancedEntries checks the ledger invariant and returns the IDs of any
+// journal entries whose lines do not sum to zero per currency.
+func UnbalancedEntries(db *gorm.DB) ([]uint, error) {
+	var ids []uint
+	err := db.Model(&models.JournalLine{}).
+		Distinct("journal_entry_id").
+		Where("journal_entry_id IN (?)", db.Model(&models.JournalLine{}).
+			Select("journal_entry_id").
+			Group("journal_entry_id, amount_currency").
+			Having("SUM(amount_units) <> 0")).
+		Pluck("journal_entry_id", &ids).Error
+	return ids, err
+}
@@ -0,0 +1,254 @@
+// Package ledger keeps a double-entry record of every movement of
+// partnership funds. Each journal entry moves money between accounts and its
+// lines always sum to zero, so any balance can be traced back to the entries
+// that produced it.
+//
+// A partnership's funds are held in its pool account and its goal accounts.
+// Partner accounts track what each partner put in (negative) and took out
+// (positive); the external account is the counterpart for money arriving
+// from or leaving to the outside world without a known partner.
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"aa-sharing-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Account types.
+const (
+	AccountPool     = "pool"
+	AccountPartner  = "partner"
+	AccountGoal     = "goal"
+	AccountExternal = "external"
+)
+
+// Journal entry kinds.
+const (
+	EntryOpeningBalance = "opening_balance"
+	EntryContribution   = "contribution"
+	EntryGratitude      = "gratitude"
+	EntrySplit          = "split"
+	EntryGoalAllocation = "goal_allocation"
+	EntryReversal       = "reversal"
+	EntryAdjustment     = "adjustment"
+	EntryWithdrawal     = "withdrawal"
+)
+
+// HoldingAccounts are the account types whose sum is the partnership balance.
+var HoldingAccounts = []string{AccountPool, AccountGoal}
+
+var ErrUnbalanced = errors.New("journal entry does not balance")
+
+// Posting is one line of a journal entry before its account is resolved.
+type Posting struct {
+	AccountType string
+	UserID      uint
+	GoalID      uint
+	Amount      models.Money
+}
+
+func Pool(amount models.Money) Posting {
+	return Posting{AccountType: AccountPool, Amount: amount}
+}
+
+func Partner(userID uint, amount models.Money) Posting {
+	return Posting{AccountType: AccountPartner, UserID: userID, Amount: amount}
+}
+
+func Goal(goalID uint, amount models.Money) Posting {
+	return Posting{AccountType: AccountGoal, GoalID: goalID, Amount: amount}
+}
+
+func External(amount models.Money) Posting {
+	return Posting{AccountType: AccountExternal, Amount: amount}
+}
+
+// Post writes a balanced journal entry. Zero-amount postings are dropped. It
+// should be called with the same *gorm.DB transaction as the business change
+// it records.
+func Post(tx *gorm.DB, partnershipID uint, kind, description string, transactionID *uint, postings ...Posting) (*models.JournalEntry, error) {
+	sums := map[string]int64{}
+	var lines []models.JournalLine
+	for _, p := range postings {
+		if p.Amount.IsZero() {
+			continue
+		}
+		sums[p.Amount.Code()] += p.Amount.Units
+
+		account, err := findOrCreateAccount(tx, partnershipID, p.AccountType, p.UserID, p.GoalID, p.Amount.Code())
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, models.JournalLine{
+			AccountID: account.ID,
+			Amount:    models.NewMoney(p.Amount.Units, p.Amount.Code()),
+		})
+	}
+	for currency, sum := range sums {
+		if sum != 0 {
+			return nil, fmt.Errorf("%w: %s lines sum to %d", ErrUnbalanced, currency, sum)
+		}
+	}
+	if len(lines) == 0 {
+		return nil, nil
+	}
+
+	entry := models.JournalEntry{
+		PartnershipID: partnershipID,
+		TransactionID: transactionID,
+		Kind:          kind,
+		Description:   description,
+		Lines:         lines,
+	}
+	if err := tx.Create(&entry).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// PostTransaction records a contribution or gratitude tip: the amount moves
+// from the contributing partner into the pool.
+func PostTransaction(tx *gorm.DB, t *models.Transaction) (*models.JournalEntry, error) {
+	kind := EntryContribution
+	if t.Type == EntryGratitude {
+		kind = EntryGratitude
+	}
+	return Post(tx, t.PartnershipID, kind, t.Description, &t.ID,
+		Partner(t.UserID, t.Amount.Neg()),
+		Pool(t.Amount),
+	)
+}
+
+// PostWithdrawal records money a partner spends from the pool, or from the
+// goal named by the transaction's GoalID.
+func PostWithdrawal(tx *gorm.DB, t *models.Transaction) (*models.JournalEntry, error) {
+	source := Pool(t.Amount.Neg())
+	if t.GoalID != nil {
+		source = Goal(*t.GoalID, t.Amount.Neg())
+	}
+	return Post(tx, t.PartnershipID, EntryWithdrawal, t.Description, &t.ID,
+		source,
+		Partner(t.UserID, t.Amount),
+	)
+}
+
+// PostGoalAllocation earmarks amount of the pool for a goal. A negative
+// amount releases goal money back to the pool.
+func PostGoalAllocation(tx *gorm.DB, goal *models.Goal, amount models.Money, transactionID *uint) (*models.JournalEntry, error) {
+	return Post(tx, goal.PartnershipID, EntryGoalAllocation, "Goal allocation: "+goal.Name, transactionID,
+		Pool(amount.Neg()),
+		Goal(goal.ID, amount),
+	)
+}
+
+// PostGoalTransfer moves amount allocated to one goal over to another.
+func PostGoalTransfer(tx *gorm.DB, from, to *models.Goal, amount models.Money) (*models.JournalEntry, error) {
+	return Post(tx, from.PartnershipID, EntryGoalAllocation, "Goal reallocation: "+from.Name+" to "+to.Name, nil,
+		Goal(from.ID, amount.Neg()),
+		Goal(to.ID, amount),
+	)
+}
+
+// PostAdjustment corrects a partnership's holdings by amount against the
+// external account. A positive amount is added to the pool; a negative one
+// is taken from the pool first and then from the goals.
+func PostAdjustment(tx *gorm.DB, partnershipID uint, amount models.Money, description string, transactionID *uint) (*models.JournalEntry, error) {
+	if !amount.IsNegative() {
+		return Post(tx, partnershipID, EntryAdjustment, description, transactionID,
+			External(amount.Neg()),
+			Pool(amount),
+		)
+	}
+
+	accounts, err := AccountBalances(tx, partnershipID)
+	if err != nil {
+		return nil, err
+	}
+	// Pool accounts sort first so that goals are only touched when needed
+	sort.SliceStable(accounts, func(i, j int) bool {
+		return accounts[i].Type == AccountPool && accounts[j].Type != AccountPool
+	})
+
+	remaining := amount.Neg()
+	postings := []Posting{External(remaining)}
+	for _, account := range accounts {
+		if (account.Type != AccountPool && account.Type != AccountGoal) ||
+			account.Currency != amount.Code() || !account.Balance.IsPositive() || remaining.IsZero() {
+			continue
+		}
+		take := account.Balance
+		if account.Balance.Units > remaining.Units {
+			take = remaining
+		}
+		postings = append(postings, Posting{AccountType: account.Type, GoalID: account.GoalID, Amount: take.Neg()})
+		if remaining, err = remaining.Sub(take); err != nil {
+			return nil, err
+		}
+	}
+	// Whatever the holdings cannot cover leaves the pool negative
+	postings = append(postings, Pool(remaining.Neg()))
+
+	return Post(tx, partnershipID, EntryAdjustment, description, transactionID, postings...)
+}
+
+// ReverseTransaction posts a reversal of every journal entry recorded for a
+// transaction, undoing its effect on all balances.
+func ReverseTransaction(tx *gorm.DB, transactionID uint, description string) error {
+	var entries []models.JournalEntry
+	if err := tx.Where("transaction_id = ? AND kind <> ?", transactionID, EntryReversal).
+		Preload("Lines.Account").
+		Find(&entries).Error; err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		postings := make([]Posting, 0, len(entry.Lines))
+		for _, line := range entry.Lines {
+			postings = append(postings, Posting{
+				AccountType: line.Account.Type,
+				UserID:      line.Account.UserID,
+				GoalID:      line.Account.GoalID,
+				Amount:      line.Amount.Neg(),
+			})
+		}
+		if _, err := Post(tx, entry.PartnershipID, EntryReversal, description, &transactionID, postings...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func findOrCreateAccount(tx *gorm.DB, partnershipID uint, accountType string, userID, goalID uint, currency string) (*models.LedgerAccount, error) {
+	// Insert first and ignore conflicts so that two transactions opening the
+	// same account concurrently both succeed.
+	account := models.LedgerAccount{
+		PartnershipID: partnershipID,
+		Type:          accountType,
+		UserID:        userID,
+		GoalID:        goalID,
+		Currency:      currency,
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&account).Error; err != nil {
+		return nil, err
+	}
+	if account.ID != 0 {
+		return &account, nil
+	}
+
+	if err := tx.Where(map[string]interface{}{
+		"partnership_id": partnershipID,
+		"type":           accountType,
+		"user_id":        userID,
+		"goal_id":        goalID,
+		"currency":       currency,
+	}).First(&account).Error; err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
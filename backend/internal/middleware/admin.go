@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminRequired rejects the request with 403 unless the authenticated user
+// is one of adminUserIDs. It must run after AuthRequired.
+func AdminRequired(adminUserIDs []uint) gin.HandlerFunc {
+	admins := make(map[uint]bool, len(adminUserIDs))
+	for _, id := range adminUserIDs {
+		admins[id] = true
+	}
+
+	return func(c *gin.Context) {
+		userID, ok := CurrentUserID(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			return
+		}
+		if !admins[userID] {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"aa-sharing-backend/internal/models"
+	"aa-sharing-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	userIDKey = "userID"
+	userKey   = "user"
+)
+
+// AuthRequired validates the bearer token on the request and stores the
+// authenticated user in the context. Requests without a valid token are
+// rejected with 401.
+func AuthRequired(userService *services.UserService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		scheme, token, found := strings.Cut(header, " ")
+		if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Missing or malformed authorization header"})
+			return
+		}
+
+		userID, err := userService.ValidateJWT(token)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			return
+		}
+
+		user, err := userService.GetUserByID(userID)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "User not found"})
+			return
+		}
+
+		c.Set(userIDKey, user.ID)
+		c.Set(userKey, user)
+		c.Next()
+	}
+}
+
+// CurrentUserID returns the ID of the authenticated user.
+func CurrentUserID(c *gin.Context) (uint, bool) {
+	userID, ok := c.Get(userIDKey)
+	if !ok {
+		return 0, false
+	}
+	id, ok := userID.(uint)
+	return id, ok
+}
+
+// CurrentUser returns the authenticated user.
+func CurrentUser(c *gin.Context) (*models.User, bool) {
+	user, ok := c.Get(userKey)
+	if !ok {
+		return nil, false
+	}
+	u, ok := user.(*models.User)
+	return u, ok
+}
+
+// RequireSelf rejects the request with 403 unless the given path parameter
+// is the authenticated user's ID, for routes that only a user may use on
+// their own account.
+func RequireSelf(param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := strconv.ParseUint(c.Param(param), 10, 32)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+			return
+		}
+
+		if userID, ok := CurrentUserID(c); !ok || userID != uint(id) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Cannot access another user's account"})
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequireSelf(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		userID interface{}
+		path   string
+		want   int
+	}{
+		{"own account", uint(7), "/users/7", http.StatusOK},
+		{"another account", uint(7), "/users/8", http.StatusForbidden},
+		{"unauthenticated", nil, "/users/7", http.StatusForbidden},
+		{"invalid ID", uint(7), "/users/me", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.GET("/users/:id", func(c *gin.Context) {
+				if tt.userID != nil {
+					c.Set(userIDKey, tt.userID)
+				}
+			}, RequireSelf("id"), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if w.Code != tt.want {
+				t.Errorf("got %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"aa-sharing-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IdempotencyKeyHeader is the request header carrying the client's key.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// Idempotent makes a money-moving route safe to retry. When the request
+// carries an Idempotency-Key header, the first response is stored and
+// replayed for later requests with the same key and payload; a different
+// payload under the same key is rejected with 409. Requests without the
+// header are passed through unchanged. It must run after AuthRequired.
+func Idempotent(idempotencyService *services.IdempotencyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > 255 {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key is too long"})
+			return
+		}
+
+		userID, ok := CurrentUserID(c)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Failed to read request body"})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		record, replay, err := idempotencyService.Begin(userID, key, fingerprint(c, body))
+		if err != nil {
+			switch {
+			case errors.Is(err, services.ErrIdempotencyKeyReused), errors.Is(err, services.ErrIdempotencyKeyInProgress):
+				c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+			default:
+				c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			}
+			return
+		}
+		if replay {
+			c.Header("Idempotent-Replayed", "true")
+			c.Data(record.StatusCode, "application/json; charset=utf-8", record.ResponseBody)
+			c.Abort()
+			return
+		}
+
+		// A panicking handler must not leave the key claimed, or every retry
+		// would be rejected as in progress until the key expires
+		defer func() {
+			if r := recover(); r != nil {
+				if err := idempotencyService.Release(record); err != nil {
+					log.Println("Failed to release idempotency key:", err)
+				}
+				panic(r)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		// Server errors are not stored so that the client can retry them
+		if recorder.Status() >= http.StatusInternalServerError {
+			if err := idempotencyService.Release(record); err != nil {
+				log.Println("Failed to release idempotency key:", err)
+			}
+			return
+		}
+		if err := idempotencyService.Complete(record, recorder.Status(), recorder.body.Bytes()); err != nil {
+			log.Println("Failed to store idempotent response:", err)
+		}
+	}
+}
+
+// fingerprint identifies the request a key was first used for.
+func fingerprint(c *gin.Context, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(c.Request.Method))
+	h.Write([]byte{0})
+	h.Write([]byte(c.Request.URL.RequestURI()))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder keeps a copy of the response body as it is written.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *responseRecorder) WriteString(s string) (int, error) {
+	r.body.WriteString(s)
+	return r.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"aa-sharing-backend/internal/services"
+	"aa-sharing-backend/internal/testdb"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestIdempotentReleasesKeyOnPanic(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := testdb.Open(t)
+	user := testdb.User(t, db)
+	idempotencyService := services.NewIdempotencyService(db, time.Hour)
+
+	calls := 0
+	r := gin.New()
+	r.Use(gin.CustomRecovery(func(c *gin.Context, _ interface{}) {
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}))
+	r.POST("/contribute", func(c *gin.Context) {
+		c.Set(userIDKey, user.ID)
+	}, Idempotent(idempotencyService), func(c *gin.Context) {
+		calls++
+		if calls == 1 {
+			panic("boom")
+		}
+		c.JSON(http.StatusCreated, gin.H{"call": calls})
+	})
+
+	send := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/contribute", strings.NewReader(`{"amount":"1"}`))
+		req.Header.Set(IdempotencyKeyHeader, "retry-after-panic")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := send(); w.Code != http.StatusInternalServerError {
+		t.Fatalf("panicking request: got %d, want 500", w.Code)
+	}
+	if w := send(); w.Code != http.StatusCreated {
+		t.Fatalf("retry after panic: got %d %s, want 201", w.Code, w.Body)
+	}
+	w := send()
+	if w.Code != http.StatusCreated || w.Header().Get("Idempotent-Replayed") != "true" {
+		t.Fatalf("second retry: got %d, replayed %q", w.Code, w.Header().Get("Idempotent-Replayed"))
+	}
+	if calls != 2 {
+		t.Errorf("handler ran %d times, want 2", calls)
+	}
+}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"aa-sharing-backend/internal/models"
+	"aa-sharing-backend/internal/services"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// RequirePartner rejects the request with 403 unless the authenticated user
+// is a partner of the partnership named by the given path parameter.
+func RequirePartner(partnershipService *services.PartnershipService, param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		partnershipID, err := strconv.ParseUint(c.Param(param), 10, 32)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "Invalid partnership ID"})
+			return
+		}
+
+		if !AuthorizePartner(c, partnershipService, uint(partnershipID)) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// AuthorizePartner checks that the authenticated user is a partner of
+// partnershipID. On failure it aborts the request with the matching status
+// and returns false.
+func AuthorizePartner(c *gin.Context, partnershipService *services.PartnershipService, partnershipID uint) bool {
+	_, ok := authorizePartner(c, partnershipService, partnershipID)
+	return ok
+}
+
+// AuthorizeActivePartner is AuthorizePartner for actions that also need the
+// partnership to be active, mirroring the validPartnership modifier.
+func AuthorizeActivePartner(c *gin.Context, partnershipService *services.PartnershipService, partnershipID uint) bool {
+	_, ok := ActivePartnership(c, partnershipService, partnershipID)
+	return ok
+}
+
+// ActivePartnership is AuthorizeActivePartner for handlers that also need
+// the partnership itself.
+func ActivePartnership(c *gin.Context, partnershipService *services.PartnershipService, partnershipID uint) (*models.Partnership, bool) {
+	partnership, ok := authorizePartner(c, partnershipService, partnershipID)
+	if !ok {
+		return nil, false
+	}
+	if partnership.Status != models.PartnershipActive {
+		c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "Partnership not active"})
+		return nil, false
+	}
+	return partnership, true
+}
+
+func authorizePartner(c *gin.Context, partnershipService *services.PartnershipService, partnershipID uint) (*models.Partnership, bool) {
+	userID, ok := CurrentUserID(c)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return nil, false
+	}
+
+	partnership, err := partnershipService.AuthorizePartner(partnershipID, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNotPartner):
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Not a partner in this partnership"})
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": "Partnership not found"})
+		default:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return nil, false
+	}
+	return partnership, true
+}
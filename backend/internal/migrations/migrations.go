@@ -0,0 +1,201 @@
+// Package migrations brings the database schema up to date with the models.
+package migrations
+
+import (
+	"fmt"
+	"time"
+
+	"aa-sharing-backend/internal/ledger"
+	"aa-sharing-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// Run converts legacy columns and then auto-migrates every model.
+func Run(db *gorm.DB) error {
+	if err := convertMoneyColumns(db); err != nil {
+		return fmt.Errorf("convert money columns: %w", err)
+	}
+	if err := dedupeWalletBalances(db); err != nil {
+		return fmt.Errorf("dedupe wallet balances: %w", err)
+	}
+
+	if err := db.AutoMigrate(
+		&models.User{},
+		&models.Partnership{},
+		&models.GratitudeEntry{},
+		&models.Goal{},
+		&models.GoalMilestone{},
+		&models.Transaction{},
+		&models.WalletBalance{},
+		&models.AuthNonce{},
+		&models.SplitConfirmation{},
+		&models.LedgerAccount{},
+		&models.JournalEntry{},
+		&models.JournalLine{},
+		&models.IdempotencyKey{},
+		&models.IndexerCheckpoint{},
+		&models.IndexedBlock{},
+		&models.IndexedEvent{},
+		&models.SenderNonce{},
+		&models.ChainSubmission{},
+		&models.BridgeMessage{},
+		&models.BridgeMessageEvent{},
+		&models.ReconciliationDiscrepancy{},
+		&models.GaslessContribution{},
+		&models.ActivityEvent{},
+		&models.WithdrawalRequest{},
+		&models.Expense{},
+		&models.ExpenseShare{},
+	); err != nil {
+		return err
+	}
+
+	if err := indexUserWallets(db); err != nil {
+		return fmt.Errorf("index user wallets: %w", err)
+	}
+	if err := backfillLedger(db); err != nil {
+		return fmt.Errorf("backfill ledger: %w", err)
+	}
+	if err := backfillGoalMilestones(db); err != nil {
+		return fmt.Errorf("backfill goal milestones: %w", err)
+	}
+	return nil
+}
+
+// moneyColumns lists the float64 amount columns that were replaced by
+// models.Money, together with the prefix of their new columns.
+var moneyColumns = []struct {
+	table  string
+	column string
+	prefix string
+}{
+	{"gratitude_entries", "amount", "amount_"},
+	{"goals", "target_amount", "target_amount_"},
+	{"goals", "current_amount", "current_amount_"},
+	{"transactions", "amount", "amount_"},
+	{"wallet_balances", "balance", "balance_"},
+	{"split_confirmations", "balance", "balance_"},
+}
+
+// convertMoneyColumns rewrites legacy float amounts, which were stored in
+// whole USDC, into integer base units. Tables that are new or already
+// converted are left untouched.
+func convertMoneyColumns(db *gorm.DB) error {
+	return db.Transaction(func(tx *gorm.DB) error {
+		migrator := tx.Migrator()
+		for _, mc := range moneyColumns {
+			if !migrator.HasTable(mc.table) ||
+				!migrator.HasColumn(mc.table, mc.column) ||
+				migrator.HasColumn(mc.table, mc.prefix+"units") {
+				continue
+			}
+
+			statements := []string{
+				fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %sunits bigint NOT NULL DEFAULT 0, ADD COLUMN %scurrency varchar(10) NOT NULL DEFAULT 'USDC'`,
+					mc.table, mc.prefix, mc.prefix),
+				fmt.Sprintf(`UPDATE %s SET %sunits = ROUND(COALESCE(%s, 0) * 1000000)::bigint`,
+					mc.table, mc.prefix, mc.column),
+				fmt.Sprintf(`ALTER TABLE %s DROP COLUMN %s`, mc.table, mc.column),
+			}
+			for _, stmt := range statements {
+				if err := tx.Exec(stmt).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// dedupeWalletBalances removes duplicate wallet rows left behind by racing
+// first contributions, so that partnership_id can be made unique. The
+// balance is derived from the ledger, so the surviving row's cached value is
+// not significant.
+func dedupeWalletBalances(db *gorm.DB) error {
+	if !db.Migrator().HasTable("wallet_balances") {
+		return nil
+	}
+	return db.Exec(`DELETE FROM wallet_balances a USING wallet_balances b
+		WHERE a.partnership_id = b.partnership_id AND a.id > b.id`).Error
+}
+
+// indexUserWallets makes wallet addresses unique regardless of case, so that
+// one wallet cannot sign in to two accounts. Addresses used to be accepted
+// without proof; where several users claimed the same one, it is kept by the
+// user who claimed it first and the others have to link it again over SIWE.
+func indexUserWallets(db *gorm.DB) error {
+	if err := db.Exec(`UPDATE users SET wallet_address = '' WHERE id IN (
+		SELECT id FROM (
+			SELECT id, ROW_NUMBER() OVER (PARTITION BY LOWER(wallet_address) ORDER BY id) AS n
+			FROM users WHERE wallet_address <> '' AND deleted_at IS NULL
+		) claims WHERE n > 1)`).Error; err != nil {
+		return err
+	}
+	return db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_users_wallet_address
+		ON users (LOWER(wallet_address)) WHERE wallet_address <> '' AND deleted_at IS NULL`).Error
+}
+
+// backfillLedger posts an opening balance for wallets that predate the
+// ledger, so that balances derived from the ledger match the stored ones.
+// Goal amounts are carved out of the pool.
+func backfillLedger(db *gorm.DB) error {
+	var balances []models.WalletBalance
+	if err := db.Where("partnership_id NOT IN (?)", db.Model(&models.JournalEntry{}).Select("partnership_id")).
+		Find(&balances).Error; err != nil {
+		return err
+	}
+
+	for _, balance := range balances {
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var goals []models.Goal
+			if err := tx.Where("partnership_id = ?", balance.PartnershipID).Find(&goals).Error; err != nil {
+				return err
+			}
+
+			pool := balance.Balance
+			postings := []ledger.Posting{ledger.External(balance.Balance.Neg())}
+			for _, goal := range goals {
+				var err error
+				if pool, err = pool.Sub(goal.CurrentAmount); err != nil {
+					return err
+				}
+				postings = append(postings, ledger.Goal(goal.ID, goal.CurrentAmount))
+			}
+			postings = append(postings, ledger.Pool(pool))
+
+			_, err := ledger.Post(tx, balance.PartnershipID, ledger.EntryOpeningBalance, "Opening balance", nil, postings...)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backfillGoalMilestones gives goals created before milestones existed the
+// default ones. Milestones a goal has already passed are marked reached so
+// that they are not announced long after the fact.
+func backfillGoalMilestones(db *gorm.DB) error {
+	var goals []models.Goal
+	if err := db.Where("id NOT IN (?)", db.Model(&models.GoalMilestone{}).Select("goal_id")).
+		Find(&goals).Error; err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for i := range goals {
+		goal := &goals[i]
+		milestones := goal.DefaultMilestones()
+		for j := range milestones {
+			if goal.CurrentAmount.Units >= milestones[j].Amount.Units {
+				milestones[j].ReachedAt = &now
+			}
+		}
+		if err := db.Create(&milestones).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
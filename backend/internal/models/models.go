@@ -7,65 +7,124 @@ import (
 )
 
 type User struct {
-	ID        uint           `json:"id" gorm:"primaryKey"`
-	Email     string         `json:"email" gorm:"unique;not null"`
-	Name      string         `json:"name"`
-	WalletAddress string     `json:"wallet_address"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+	ID            uint           `json:"id" gorm:"primaryKey"`
+	Email         string         `json:"email" gorm:"unique;not null"`
+	Name          string         `json:"name"`
+	WalletAddress string         `json:"wallet_address"` // set through SIWE only; unique regardless of case
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
+// Partnership statuses. A partnership starts pending until the invited
+// partner (UserB) accepts or declines it.
+const (
+	PartnershipPending  = "pending"
+	PartnershipDeclined = "declined"
+	PartnershipActive   = "active"
+	PartnershipInactive = "inactive"
+	PartnershipSplit    = "split"
+)
+
 type Partnership struct {
-	ID        uint           `json:"id" gorm:"primaryKey"`
-	UserAID   uint           `json:"user_a_id"`
-	UserBID   uint           `json:"user_b_id"`
-	UserA     User           `json:"user_a" gorm:"foreignKey:UserAID"`
-	UserB     User           `json:"user_b" gorm:"foreignKey:UserBID"`
-	Status    string         `json:"status" gorm:"default:'active'"` // active, inactive, split
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+	ID                 uint           `json:"id" gorm:"primaryKey"`
+	UserAID            uint           `json:"user_a_id"`
+	UserBID            uint           `json:"user_b_id"`
+	UserA              User           `json:"user_a" gorm:"foreignKey:UserAID"`
+	UserB              User           `json:"user_b" gorm:"foreignKey:UserBID"`
+	NicknameA          string         `json:"nickname_a"`
+	NicknameB          string         `json:"nickname_b"`
+	Status             string         `json:"status" gorm:"default:'active'"`          // pending, declined, active, inactive, split
+	ChainPartnershipID *uint64        `json:"chain_partnership_id" gorm:"uniqueIndex"` // partnershipId in the AASharing contract
+	CreatedAt          time.Time      `json:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at"`
+	DeletedAt          gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
 type GratitudeEntry struct {
-	ID           uint           `json:"id" gorm:"primaryKey"`
-	UserID       uint           `json:"user_id"`
-	User         User           `json:"user" gorm:"foreignKey:UserID"`
-	PartnershipID uint          `json:"partnership_id"`
-	Partnership  Partnership   `json:"partnership" gorm:"foreignKey:PartnershipID"`
-	Content      string         `json:"content"`
-	Amount       float64        `json:"amount"`
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
-}
-
-type Goal struct {
 	ID            uint           `json:"id" gorm:"primaryKey"`
+	UserID        uint           `json:"user_id"`
+	User          User           `json:"user" gorm:"foreignKey:UserID"`
 	PartnershipID uint           `json:"partnership_id"`
 	Partnership   Partnership    `json:"partnership" gorm:"foreignKey:PartnershipID"`
-	Name          string         `json:"name"`
-	Description   string         `json:"description"`
-	TargetAmount  float64        `json:"target_amount"`
-	CurrentAmount float64        `json:"current_amount"`
-	Status        string         `json:"status" gorm:"default:'active'"` // active, completed, cancelled
+	Content       string         `json:"content"`
+	Amount        Money          `json:"amount" gorm:"embedded;embeddedPrefix:amount_"`
+	TxHash        string         `json:"tx_hash" gorm:"uniqueIndex:idx_gratitude_event"`
+	LogIndex      *uint          `json:"log_index" gorm:"uniqueIndex:idx_gratitude_event"`
 	CreatedAt     time.Time      `json:"created_at"`
 	UpdatedAt     time.Time      `json:"updated_at"`
 	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`
 }
 
+type Goal struct {
+	ID            uint            `json:"id" gorm:"primaryKey"`
+	PartnershipID uint            `json:"partnership_id" gorm:"uniqueIndex:idx_goal_chain_id"`
+	Partnership   Partnership     `json:"partnership" gorm:"foreignKey:PartnershipID"`
+	Name          string          `json:"name"`
+	Description   string          `json:"description"`
+	TargetAmount  Money           `json:"target_amount" gorm:"embedded;embeddedPrefix:target_amount_"`
+	CurrentAmount Money           `json:"current_amount" gorm:"embedded;embeddedPrefix:current_amount_"`
+	TargetDate    *time.Time      `json:"target_date,omitempty"`
+	Status        string          `json:"status" gorm:"default:'active'"` // active, completed, cancelled
+	ChainGoalID   *uint64         `json:"chain_goal_id" gorm:"uniqueIndex:idx_goal_chain_id"`
+	Milestones    []GoalMilestone `json:"milestones,omitempty" gorm:"foreignKey:GoalID"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt  `json:"-" gorm:"index"`
+}
+
+// DefaultMilestonePercents are the milestones a goal gets unless custom
+// amounts are given.
+var DefaultMilestonePercents = []uint{25, 50, 75, 100}
+
+// DefaultMilestones returns the milestones at DefaultMilestonePercents of the
+// goal's target, not yet saved.
+func (g *Goal) DefaultMilestones() []GoalMilestone {
+	milestones := make([]GoalMilestone, 0, len(DefaultMilestonePercents))
+	for _, percent := range DefaultMilestonePercents {
+		percent := percent
+		milestones = append(milestones, GoalMilestone{
+			GoalID:  g.ID,
+			Amount:  g.PercentOfTarget(percent),
+			Percent: &percent,
+		})
+	}
+	return milestones
+}
+
+// PercentOfTarget returns percent of the goal's target amount, rounded down.
+func (g *Goal) PercentOfTarget(percent uint) Money {
+	return NewMoney(g.TargetAmount.Units*int64(percent)/100, g.TargetAmount.Code())
+}
+
+// GoalMilestone is an amount saved towards a goal that is celebrated once,
+// the first time the goal's current amount reaches it. Percent is set for
+// milestones defined as a share of the target; their amount follows the
+// target until they are reached.
+type GoalMilestone struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	GoalID    uint       `json:"goal_id" gorm:"index"`
+	Amount    Money      `json:"amount" gorm:"embedded;embeddedPrefix:amount_"`
+	Percent   *uint      `json:"percent,omitempty"`
+	ReachedAt *time.Time `json:"reached_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
 type Transaction struct {
 	ID            uint           `json:"id" gorm:"primaryKey"`
-	UserID        uint           `json:"user_id"`
+	UserID        uint           `json:"user_id" gorm:"uniqueIndex:idx_transaction_event"`
 	User          User           `json:"user" gorm:"foreignKey:UserID"`
 	PartnershipID uint           `json:"partnership_id"`
 	Partnership   Partnership    `json:"partnership" gorm:"foreignKey:PartnershipID"`
-	Type          string         `json:"type"` // gratitude, contribution, split
-	Amount        float64        `json:"amount"`
+	Type          string         `json:"type"` // gratitude, contribution, split, adjustment, withdrawal, settlement
+	Amount        Money          `json:"amount" gorm:"embedded;embeddedPrefix:amount_"`
+	GoalID        *uint          `json:"goal_id,omitempty" gorm:"index"` // goal a contribution is allocated to or a withdrawal is taken from
 	Description   string         `json:"description"`
-	TxHash        string         `json:"tx_hash"`
+	TxHash        string         `json:"tx_hash" gorm:"uniqueIndex:idx_transaction_event"`
+	LogIndex      *uint          `json:"log_index" gorm:"uniqueIndex:idx_transaction_event"`
 	Status        string         `json:"status" gorm:"default:'pending'"` // pending, confirmed, failed
+	FailureReason string         `json:"failure_reason,omitempty"`
+	RelayStatus   string         `json:"relay_status,omitempty"` // set for gasless contributions: queued, submitted, mined, confirmed, failed
 	CreatedAt     time.Time      `json:"created_at"`
 	UpdatedAt     time.Time      `json:"updated_at"`
 	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`
@@ -73,11 +132,402 @@ type Transaction struct {
 
 type WalletBalance struct {
 	ID            uint           `json:"id" gorm:"primaryKey"`
-	PartnershipID uint           `json:"partnership_id"`
+	PartnershipID uint           `json:"partnership_id" gorm:"uniqueIndex"`
 	Partnership   Partnership    `json:"partnership" gorm:"foreignKey:PartnershipID"`
-	Balance       float64        `json:"balance"`
+	Balance       Money          `json:"balance" gorm:"embedded;embeddedPrefix:balance_"`
 	LastUpdated   time.Time      `json:"last_updated"`
 	CreatedAt     time.Time      `json:"created_at"`
 	UpdatedAt     time.Time      `json:"updated_at"`
 	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`
-}
\ No newline at end of file
+}
+
+// HasPartner reports whether userID is one of the two partners.
+func (p *Partnership) HasPartner(userID uint) bool {
+	return userID != 0 && (p.UserAID == userID || p.UserBID == userID)
+}
+
+// LedgerAccount is an account in a partnership's double-entry ledger. UserID
+// is set for partner accounts and GoalID for goal accounts; both are zero
+// otherwise.
+type LedgerAccount struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	PartnershipID uint      `json:"partnership_id" gorm:"uniqueIndex:idx_ledger_account"`
+	Type          string    `json:"type" gorm:"uniqueIndex:idx_ledger_account"` // pool, partner, goal, external
+	UserID        uint      `json:"user_id" gorm:"uniqueIndex:idx_ledger_account"`
+	GoalID        uint      `json:"goal_id" gorm:"uniqueIndex:idx_ledger_account"`
+	Currency      string    `json:"currency" gorm:"uniqueIndex:idx_ledger_account;size:10"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// JournalEntry is one balanced posting to the ledger: the amounts of its
+// lines always sum to zero.
+type JournalEntry struct {
+	ID            uint          `json:"id" gorm:"primaryKey"`
+	PartnershipID uint          `json:"partnership_id" gorm:"index"`
+	TransactionID *uint         `json:"transaction_id"`
+	Kind          string        `json:"kind"` // opening_balance, contribution, gratitude, split, goal_allocation, reversal, adjustment, withdrawal
+	Description   string        `json:"description"`
+	Lines         []JournalLine `json:"lines"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
+
+// JournalLine moves Amount into (positive) or out of (negative) an account.
+type JournalLine struct {
+	ID             uint          `json:"id" gorm:"primaryKey"`
+	JournalEntryID uint          `json:"journal_entry_id" gorm:"index"`
+	AccountID      uint          `json:"account_id" gorm:"index"`
+	Account        LedgerAccount `json:"account" gorm:"foreignKey:AccountID"`
+	Amount         Money         `json:"amount" gorm:"embedded;embeddedPrefix:amount_"`
+}
+
+// SplitConfirmation records one partner's consent to split the shared
+// balance. The split runs once both partners have confirmed. A confirmation
+// only counts while the balance is still the one the partner saw in the
+// preview and until it expires.
+type SplitConfirmation struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	PartnershipID uint      `json:"partnership_id" gorm:"uniqueIndex:idx_split_confirmation"`
+	UserID        uint      `json:"user_id" gorm:"uniqueIndex:idx_split_confirmation"`
+	Balance       Money     `json:"balance" gorm:"embedded;embeddedPrefix:balance_"`
+	ExpiresAt     time.Time `json:"expires_at"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Withdrawal request statuses.
+const (
+	WithdrawalPending  = "pending"
+	WithdrawalApproved = "approved"
+	WithdrawalRejected = "rejected"
+	WithdrawalExpired  = "expired"
+)
+
+// WithdrawalRequest is a partner's proposal to spend partnership money from
+// the pool, or from a goal when GoalID is set. It takes effect when the other
+// partner approves it before ExpiresAt; TransactionID is then the withdrawal
+// transaction it created.
+type WithdrawalRequest struct {
+	ID              uint       `json:"id" gorm:"primaryKey"`
+	PartnershipID   uint       `json:"partnership_id" gorm:"index"`
+	RequestedByID   uint       `json:"requested_by_id"`
+	RequestedBy     User       `json:"requested_by" gorm:"foreignKey:RequestedByID"`
+	GoalID          *uint      `json:"goal_id,omitempty"`
+	Amount          Money      `json:"amount" gorm:"embedded;embeddedPrefix:amount_"`
+	Purpose         string     `json:"purpose"`
+	Status          string     `json:"status" gorm:"index;default:'pending'"`
+	ReviewedByID    *uint      `json:"reviewed_by_id,omitempty"`
+	ReviewedAt      *time.Time `json:"reviewed_at,omitempty"`
+	RejectionReason string     `json:"rejection_reason,omitempty"`
+	TransactionID   *uint      `json:"transaction_id,omitempty"`
+	ExpiresAt       time.Time  `json:"expires_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// Expense split methods.
+const (
+	SplitEqual      = "equal"
+	SplitExact      = "exact"
+	SplitPercentage = "percentage"
+	SplitShares     = "shares"
+)
+
+// Expense is a bill one partner paid that is shared between the
+// participants in Shares. It is settled between the partners directly and
+// does not touch the partnership's funds.
+type Expense struct {
+	ID            uint           `json:"id" gorm:"primaryKey"`
+	PartnershipID uint           `json:"partnership_id" gorm:"index"`
+	PaidByID      uint           `json:"paid_by_id"`
+	PaidBy        User           `json:"paid_by" gorm:"foreignKey:PaidByID"`
+	CreatedByID   uint           `json:"created_by_id"`
+	Amount        Money          `json:"amount" gorm:"embedded;embeddedPrefix:amount_"`
+	Description   string         `json:"description"`
+	Category      string         `json:"category" gorm:"index"`
+	SplitMethod   string         `json:"split_method"` // equal, exact, percentage, shares
+	ReceiptNote   string         `json:"receipt_note,omitempty"`
+	Shares        []ExpenseShare `json:"shares" gorm:"foreignKey:ExpenseID"`
+	SpentAt       time.Time      `json:"spent_at"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// ExpenseShare is what one participant owes for an expense. Weight is the
+// participant's input to the split method: base units for exact, basis
+// points for percentage, the number of shares for shares, and 1 for equal.
+type ExpenseShare struct {
+	ID        uint  `json:"id" gorm:"primaryKey"`
+	ExpenseID uint  `json:"expense_id" gorm:"index"`
+	UserID    uint  `json:"user_id"`
+	Weight    int64 `json:"weight"`
+	Amount    Money `json:"amount" gorm:"embedded;embeddedPrefix:amount_"`
+}
+
+// IdempotencyKey stores the response to a money-moving request so that a
+// retry carrying the same Idempotency-Key header replays it instead of
+// repeating the side effects.
+type IdempotencyKey struct {
+	ID           uint       `json:"id" gorm:"primaryKey"`
+	UserID       uint       `json:"user_id" gorm:"uniqueIndex:idx_idempotency_key"`
+	Key          string     `json:"key" gorm:"uniqueIndex:idx_idempotency_key;size:255"`
+	Fingerprint  string     `json:"fingerprint" gorm:"size:64"`
+	StatusCode   int        `json:"status_code"`
+	ResponseBody []byte     `json:"-"`
+	CompletedAt  *time.Time `json:"completed_at"`
+	CreatedAt    time.Time  `json:"created_at" gorm:"index"`
+}
+
+// IndexerCheckpoint is the last block whose logs have been indexed for a
+// contract.
+type IndexerCheckpoint struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	ChainID   uint64    `json:"chain_id" gorm:"uniqueIndex:idx_indexer_checkpoint"`
+	Contract  string    `json:"contract" gorm:"uniqueIndex:idx_indexer_checkpoint;size:42"`
+	LastBlock uint64    `json:"last_block"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// IndexedBlock is the hash of a block the indexer has read logs from. The
+// stored hashes are compared with the chain to detect reorganizations.
+type IndexedBlock struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	ChainID   uint64    `json:"chain_id" gorm:"uniqueIndex:idx_indexed_block"`
+	Contract  string    `json:"contract" gorm:"uniqueIndex:idx_indexed_block;size:42"`
+	Number    uint64    `json:"number" gorm:"uniqueIndex:idx_indexed_block"`
+	Hash      string    `json:"hash" gorm:"size:66"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// IndexedEvent is a contract log the indexer has applied, kept so that its
+// effect can be rolled back if the block it came from is reorganized away.
+type IndexedEvent struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	ChainID     uint64    `json:"chain_id" gorm:"uniqueIndex:idx_indexed_event"`
+	Contract    string    `json:"contract" gorm:"index;size:42"`
+	BlockNumber uint64    `json:"block_number" gorm:"index"`
+	BlockHash   string    `json:"block_hash" gorm:"size:66"`
+	TxHash      string    `json:"tx_hash" gorm:"uniqueIndex:idx_indexed_event;size:66"`
+	LogIndex    uint      `json:"log_index" gorm:"uniqueIndex:idx_indexed_event"`
+	Event       string    `json:"event"`
+	RecordID    uint      `json:"record_id"` // partnership or goal the event created
+	Confirmed   bool      `json:"confirmed"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// SenderNonce is the next nonce the backend will use for an account that
+// sends transactions. It is only ever incremented, inside the database
+// transaction that stores the transaction using the nonce.
+type SenderNonce struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	ChainID   uint64    `json:"chain_id" gorm:"uniqueIndex:idx_sender_nonce"`
+	Sender    string    `json:"sender" gorm:"uniqueIndex:idx_sender_nonce;size:42"`
+	NextNonce uint64    `json:"next_nonce"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Chain submission statuses. A submission is pending from the moment its
+// signed transaction is stored until one of its broadcasts is mined; it is
+// confirmed, or reverted, once that block is deep enough. A submission is
+// dropped when its nonce was used by a transaction that is not one of its
+// broadcasts.
+const (
+	SubmissionPending   = "pending"
+	SubmissionMined     = "mined"
+	SubmissionConfirmed = "confirmed"
+	SubmissionReverted  = "reverted"
+	SubmissionDropped   = "dropped"
+)
+
+// ChainSubmission is a transaction the backend signs and sends itself. The
+// signed transaction is stored before it is broadcast and is broadcast again
+// until it is mined, replaced at a higher gas price when it stays unmined.
+type ChainSubmission struct {
+	ID               uint       `json:"id" gorm:"primaryKey"`
+	ChainID          uint64     `json:"chain_id" gorm:"uniqueIndex:idx_chain_submission_nonce"`
+	Sender           string     `json:"sender" gorm:"uniqueIndex:idx_chain_submission_nonce;size:42"`
+	Nonce            uint64     `json:"nonce" gorm:"uniqueIndex:idx_chain_submission_nonce"`
+	To               string     `json:"to" gorm:"size:42"`
+	Data             []byte     `json:"-"`
+	Value            string     `json:"value"` // wei
+	Gas              uint64     `json:"gas"`
+	GasPrice         string     `json:"gas_price"` // wei
+	TxHash           string     `json:"tx_hash" gorm:"index;size:66"`
+	ReplacedTxHashes string     `json:"replaced_tx_hashes"` // space-separated hashes of earlier, underpriced broadcasts
+	RawTransaction   []byte     `json:"-"`
+	Reference        string     `json:"reference" gorm:"index"` // what the transaction is for, e.g. bridge_message:12
+	Status           string     `json:"status" gorm:"index"`    // pending, mined, confirmed, reverted, dropped
+	BlockNumber      *uint64    `json:"block_number"`
+	Succeeded        bool       `json:"succeeded"` // receipt status of the mined transaction
+	Broadcasts       int        `json:"broadcasts"`
+	Bumps            int        `json:"bumps"`
+	LastError        string     `json:"last_error"`
+	SignedAt         time.Time  `json:"signed_at"` // when the current TxHash was signed
+	MinedAt          *time.Time `json:"mined_at"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// Bridge message statuses. A message is submitted once its completion
+// transaction has been queued, and completed once that transaction is
+// confirmed or the message was completed by someone else.
+const (
+	BridgeMessagePending   = "pending"
+	BridgeMessageSubmitted = "submitted"
+	BridgeMessageCompleted = "completed"
+	BridgeMessageFailed    = "failed"
+)
+
+// BridgeMessage is a CrossChainBridge deposit initiated on the source chain
+// that the relayer completes on the destination chain. Amount is the net
+// amount credited on the destination chain; GrossAmount is what the user
+// sent, of which Fee went to the bridge owner.
+type BridgeMessage struct {
+	ID                 uint                 `json:"id" gorm:"primaryKey"`
+	MessageID          string               `json:"message_id" gorm:"uniqueIndex;size:66"`
+	SourceChainID      uint64               `json:"source_chain_id"`
+	DestinationChainID uint64               `json:"destination_chain_id"`
+	UserAddress        string               `json:"user_address" gorm:"size:42"`
+	ChainPartnershipID uint64               `json:"chain_partnership_id"`
+	Amount             Money                `json:"amount" gorm:"embedded;embeddedPrefix:amount_"`
+	GrossAmount        Money                `json:"gross_amount" gorm:"embedded;embeddedPrefix:gross_amount_"`
+	Fee                Money                `json:"fee" gorm:"embedded;embeddedPrefix:fee_"`
+	FeeBasisPoints     uint64               `json:"fee_basis_points"` // bridgeFee when the deposit was initiated
+	Nonce              uint64               `json:"nonce"`
+	SourceTxHash       string               `json:"source_tx_hash" gorm:"size:66"`
+	SourceLogIndex     uint                 `json:"source_log_index"`
+	SourceBlockNumber  uint64               `json:"source_block_number"`
+	Signature          string               `json:"signature"`
+	Status             string               `json:"status" gorm:"index"` // pending, submitted, completed, failed
+	SubmissionID       *uint                `json:"submission_id"`       // ChainSubmission of the completion transaction
+	CompletionTxHash   string               `json:"completion_tx_hash" gorm:"size:66"`
+	Attempts           int                  `json:"attempts"`
+	LastError          string               `json:"last_error"`
+	CompletedAt        *time.Time           `json:"completed_at"`
+	History            []BridgeMessageEvent `json:"history,omitempty" gorm:"foreignKey:BridgeMessageID"`
+	CreatedAt          time.Time            `json:"created_at"`
+	UpdatedAt          time.Time            `json:"updated_at"`
+}
+
+// BridgeMessageEvent is an entry in the status history of a BridgeMessage.
+type BridgeMessageEvent struct {
+	ID              uint      `json:"id" gorm:"primaryKey"`
+	BridgeMessageID uint      `json:"bridge_message_id" gorm:"index"`
+	Status          string    `json:"status"`
+	Detail          string    `json:"detail"`
+	TxHash          string    `json:"tx_hash,omitempty" gorm:"size:66"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// Relay statuses of a gasless contribution. It is submitted once its relay
+// transaction has been queued, and mined once that transaction is in a
+// block.
+const (
+	RelayQueued    = "queued"
+	RelaySubmitted = "submitted"
+	RelayMined     = "mined"
+	RelayConfirmed = "confirmed"
+	RelayFailed    = "failed"
+)
+
+// GaslessContribution is a contribution a partner signed as an EIP-712
+// Contribution intent, which the relayer submits to depositFundsWithSignature
+// and pays the gas for. TransactionID is the Transaction that tracks it.
+type GaslessContribution struct {
+	ID                 uint        `json:"id" gorm:"primaryKey"`
+	TransactionID      uint        `json:"transaction_id" gorm:"uniqueIndex"`
+	Transaction        Transaction `json:"-" gorm:"foreignKey:TransactionID"`
+	ChainID            uint64      `json:"chain_id" gorm:"uniqueIndex:idx_gasless_nonce,where:status <> 'failed'"`
+	Contributor        string      `json:"contributor" gorm:"uniqueIndex:idx_gasless_nonce;size:42"`
+	ChainPartnershipID uint64      `json:"chain_partnership_id"`
+	Amount             Money       `json:"amount" gorm:"embedded;embeddedPrefix:amount_"`
+	Nonce              uint64      `json:"nonce" gorm:"uniqueIndex:idx_gasless_nonce"` // contributionNonces of the contributor
+	Deadline           time.Time   `json:"deadline"`
+	Signature          string      `json:"signature"`
+	PermitSignature    string      `json:"permit_signature"`           // EIP-2612 permit of Amount for the contract
+	Status             string      `json:"status" gorm:"index"`        // queued, submitted, mined, confirmed, failed
+	SubmissionID       *uint       `json:"submission_id" gorm:"index"` // ChainSubmission of the relay transaction
+	Attempts           int         `json:"attempts"`
+	LastError          string      `json:"last_error"`
+	CreatedAt          time.Time   `json:"created_at"`
+	UpdatedAt          time.Time   `json:"updated_at"`
+}
+
+// Reconciliation discrepancy kinds. A wallet_balance discrepancy is a stored
+// WalletBalance that drifted from the ledger; a chain_balance discrepancy is
+// a ledger balance that differs from the partnership's totalBalance in the
+// AASharing contract.
+const (
+	DiscrepancyWalletBalance = "wallet_balance"
+	DiscrepancyChainBalance  = "chain_balance"
+)
+
+// Discrepancy severities, by the size of the difference.
+const (
+	SeverityLow    = "low"
+	SeverityMedium = "medium"
+	SeverityHigh   = "high"
+)
+
+// Discrepancy statuses. An open discrepancy is resolved by itself when a
+// later run no longer sees it, approved when an admin has corrected it and
+// dismissed when an admin decided to leave it.
+const (
+	DiscrepancyOpen      = "open"
+	DiscrepancyResolved  = "resolved"
+	DiscrepancyApproved  = "approved"
+	DiscrepancyDismissed = "dismissed"
+)
+
+// ReconciliationDiscrepancy is a difference between a partnership's ledger
+// balance and the stored or on-chain balance found by the reconciliation
+// job. Difference is Actual minus Expected.
+type ReconciliationDiscrepancy struct {
+	ID                      uint        `json:"id" gorm:"primaryKey"`
+	PartnershipID           uint        `json:"partnership_id" gorm:"index"`
+	Partnership             Partnership `json:"partnership" gorm:"foreignKey:PartnershipID"`
+	Kind                    string      `json:"kind"` // wallet_balance, chain_balance
+	Expected                Money       `json:"expected" gorm:"embedded;embeddedPrefix:expected_"`
+	Actual                  Money       `json:"actual" gorm:"embedded;embeddedPrefix:actual_"`
+	Difference              Money       `json:"difference" gorm:"embedded;embeddedPrefix:difference_"`
+	Severity                string      `json:"severity"`            // low, medium, high
+	Status                  string      `json:"status" gorm:"index"` // open, resolved, approved, dismissed
+	ReviewedBy              *uint       `json:"reviewed_by"`
+	AdjustmentTransactionID *uint       `json:"adjustment_transaction_id"`
+	LastSeenAt              time.Time   `json:"last_seen_at"`
+	ClosedAt                *time.Time  `json:"closed_at"`
+	CreatedAt               time.Time   `json:"created_at"`
+	UpdatedAt               time.Time   `json:"updated_at"`
+}
+
+// AuthNonce is a single-use nonce handed out for Sign-In With Ethereum.
+type AuthNonce struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	Nonce     string     `json:"nonce" gorm:"uniqueIndex;not null"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// Activity event kinds.
+const (
+	ActivityGoalMilestone       = "goal_milestone"
+	ActivityWithdrawalRequested = "withdrawal_requested"
+	ActivityWithdrawalApproved  = "withdrawal_approved"
+	ActivityWithdrawalRejected  = "withdrawal_rejected"
+)
+
+// ActivityEvent is an entry in a partnership's activity feed. Events are
+// written in the same database transaction as the change they describe and
+// delivered to the notification channels afterwards, which sets NotifiedAt.
+type ActivityEvent struct {
+	ID            uint       `json:"id" gorm:"primaryKey"`
+	PartnershipID uint       `json:"partnership_id" gorm:"index"`
+	Kind          string     `json:"kind"`
+	GoalID        *uint      `json:"goal_id,omitempty"`
+	MilestoneID   *uint      `json:"milestone_id,omitempty" gorm:"uniqueIndex"`
+	Message       string     `json:"message"`
+	NotifiedAt    *time.Time `json:"-" gorm:"index"`
+	Attempts      int        `json:"-"`
+	LastError     string     `json:"-"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
@@ -0,0 +1,243 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is the currency used when an amount does not name one. It
+// matches the USDC token held by the AASharing contract.
+const DefaultCurrency = "USDC"
+
+// currencyDecimals maps supported currency codes to the number of decimals
+// of their base unit.
+var currencyDecimals = map[string]int{
+	"USDC": 6,
+}
+
+var (
+	ErrUnknownCurrency  = errors.New("unknown currency")
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	ErrInvalidAmount    = errors.New("invalid amount")
+	ErrAmountOverflow   = errors.New("amount out of range")
+)
+
+// Money is an exact amount in the smallest unit of its currency, e.g.
+// 1 USDC is stored as Units 1000000. Embed it in models with a column prefix:
+//
+//	Amount Money `gorm:"embedded;embeddedPrefix:amount_"`
+type Money struct {
+	Units    int64  `gorm:"not null;default:0"`
+	Currency string `gorm:"size:10;not null;default:'USDC'"`
+}
+
+// NewMoney returns an amount of units in the given currency.
+func NewMoney(units int64, currency string) Money {
+	return Money{Units: units, Currency: currency}
+}
+
+// USDC returns an amount of USDC base units (6 decimals).
+func USDC(units int64) Money {
+	return Money{Units: units, Currency: "USDC"}
+}
+
+// ParseMoney parses a decimal string such as "12.50" into an exact amount.
+// More fractional digits than the currency supports is an error rather than
+// being rounded away.
+func ParseMoney(s, currency string) (Money, error) {
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	decimals, ok := currencyDecimals[currency]
+	if !ok {
+		return Money{}, fmt.Errorf("%w: %s", ErrUnknownCurrency, currency)
+	}
+
+	s = strings.TrimSpace(s)
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	whole, frac, _ := strings.Cut(s, ".")
+	if whole == "" && frac == "" {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	if len(frac) > decimals {
+		return Money{}, fmt.Errorf("%w: %s supports at most %d decimals", ErrInvalidAmount, currency, decimals)
+	}
+	digits := whole + frac + strings.Repeat("0", decimals-len(frac))
+	for _, r := range digits {
+		if r < '0' || r > '9' {
+			return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+		}
+	}
+
+	units, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %q", ErrInvalidAmount, s)
+	}
+	if negative {
+		units = -units
+	}
+	return Money{Units: units, Currency: currency}, nil
+}
+
+// Code returns the currency code, defaulting to DefaultCurrency.
+func (m Money) Code() string {
+	if m.Currency == "" {
+		return DefaultCurrency
+	}
+	return m.Currency
+}
+
+// Decimals returns the number of decimals of the currency's base unit.
+func (m Money) Decimals() int {
+	return currencyDecimals[m.Code()]
+}
+
+func (m Money) IsZero() bool     { return m.Units == 0 }
+func (m Money) IsPositive() bool { return m.Units > 0 }
+func (m Money) IsNegative() bool { return m.Units < 0 }
+
+// Add returns m + o. Both amounts must be in the same currency, and the sum
+// must fit in Units rather than wrap around.
+func (m Money) Add(o Money) (Money, error) {
+	if m.Code() != o.Code() {
+		return Money{}, ErrCurrencyMismatch
+	}
+	sum := m.Units + o.Units
+	if (o.Units > 0 && sum < m.Units) || (o.Units < 0 && sum > m.Units) {
+		return Money{}, ErrAmountOverflow
+	}
+	return Money{Units: sum, Currency: m.Code()}, nil
+}
+
+// Sub returns m - o. Both amounts must be in the same currency.
+func (m Money) Sub(o Money) (Money, error) {
+	if o.Units == math.MinInt64 {
+		// -o does not fit in Units
+		return Money{}, ErrAmountOverflow
+	}
+	return m.Add(o.Neg())
+}
+
+// Neg returns -m.
+func (m Money) Neg() Money {
+	return Money{Units: -m.Units, Currency: m.Code()}
+}
+
+// Cmp compares m and o and returns -1, 0 or +1.
+func (m Money) Cmp(o Money) (int, error) {
+	if m.Code() != o.Code() {
+		return 0, ErrCurrencyMismatch
+	}
+	switch {
+	case m.Units < o.Units:
+		return -1, nil
+	case m.Units > o.Units:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Halve splits m into two equal halves and the base unit left over when m
+// is odd.
+func (m Money) Halve() (half, remainder Money) {
+	return Money{Units: m.Units / 2, Currency: m.Code()}, Money{Units: m.Units % 2, Currency: m.Code()}
+}
+
+// BigInt returns the amount in base units, as used by the contracts.
+func (m Money) BigInt() *big.Int {
+	return big.NewInt(m.Units)
+}
+
+// String formats the amount as a decimal, e.g. "12.500000".
+func (m Money) String() string {
+	decimals := m.Decimals()
+	units := m.Units
+	sign := ""
+	if units < 0 {
+		sign = "-"
+		units = -units
+	}
+	if decimals == 0 {
+		return sign + strconv.FormatInt(units, 10)
+	}
+	digits := fmt.Sprintf("%0*d", decimals+1, units)
+	return sign + digits[:len(digits)-decimals] + "." + digits[len(digits)-decimals:]
+}
+
+type moneyJSON struct {
+	Units    int64  `json:"units"`
+	Currency string `json:"currency"`
+	Decimals int    `json:"decimals"`
+	Value    string `json:"value"`
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{
+		Units:    m.Units,
+		Currency: m.Code(),
+		Decimals: m.Decimals(),
+		Value:    m.String(),
+	})
+}
+
+// UnmarshalJSON accepts an object with units and currency, or a decimal
+// string or number in DefaultCurrency. Numbers are parsed from their literal
+// text, never through float64.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return nil
+	case len(data) > 0 && data[0] == '{':
+		var v struct {
+			Units    *int64 `json:"units"`
+			Value    string `json:"value"`
+			Currency string `json:"currency"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		if v.Units != nil {
+			if v.Currency == "" {
+				v.Currency = DefaultCurrency
+			}
+			if _, ok := currencyDecimals[v.Currency]; !ok {
+				return fmt.Errorf("%w: %s", ErrUnknownCurrency, v.Currency)
+			}
+			*m = Money{Units: *v.Units, Currency: v.Currency}
+			return nil
+		}
+		parsed, err := ParseMoney(v.Value, v.Currency)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	case len(data) > 0 && data[0] == '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		parsed, err := ParseMoney(s, DefaultCurrency)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	default:
+		parsed, err := ParseMoney(string(data), DefaultCurrency)
+		if err != nil {
+			return err
+		}
+		*m = parsed
+		return nil
+	}
+}
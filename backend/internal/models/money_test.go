@@ -0,0 +1,89 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestMoneyAdd(t *testing.T) {
+	tests := []struct {
+		a, b Money
+		want Money
+		err  error
+	}{
+		{USDC(1_500_000), USDC(2_500_000), USDC(4_000_000), nil},
+		{USDC(1), USDC(-3), USDC(-2), nil},
+		{USDC(math.MaxInt64), USDC(0), USDC(math.MaxInt64), nil},
+		{USDC(math.MaxInt64), USDC(1), Money{}, ErrAmountOverflow},
+		{USDC(math.MinInt64), USDC(-1), Money{}, ErrAmountOverflow},
+		{USDC(math.MaxInt64 / 2), USDC(math.MaxInt64/2 + 2), Money{}, ErrAmountOverflow},
+		{USDC(1), NewMoney(1, "EUR"), Money{}, ErrCurrencyMismatch},
+	}
+	for _, tt := range tests {
+		got, err := tt.a.Add(tt.b)
+		if !errors.Is(err, tt.err) || got != tt.want {
+			t.Errorf("%d + %d = %v, %v; want %v, %v", tt.a.Units, tt.b.Units, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestMoneySub(t *testing.T) {
+	if got, err := USDC(5).Sub(USDC(7)); err != nil || got.Units != -2 {
+		t.Errorf("5 - 7 = %v, %v", got, err)
+	}
+	if _, err := USDC(0).Sub(USDC(math.MinInt64)); !errors.Is(err, ErrAmountOverflow) {
+		t.Errorf("0 - MinInt64: got %v, want ErrAmountOverflow", err)
+	}
+	if _, err := USDC(math.MinInt64).Sub(USDC(1)); !errors.Is(err, ErrAmountOverflow) {
+		t.Errorf("MinInt64 - 1: got %v, want ErrAmountOverflow", err)
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		in    string
+		units int64
+		err   error
+	}{
+		{"12.5", 12_500_000, nil},
+		{"0.000001", 1, nil},
+		{"-3", -3_000_000, nil},
+		{".25", 250_000, nil},
+		{"0.0000001", 0, ErrInvalidAmount},
+		{"1e6", 0, ErrInvalidAmount},
+		{"", 0, ErrInvalidAmount},
+		{"99999999999999.999999", 0, ErrInvalidAmount},
+	}
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.in, "")
+		if !errors.Is(err, tt.err) || (err == nil && got.Units != tt.units) {
+			t.Errorf("ParseMoney(%q) = %v, %v; want %d, %v", tt.in, got.Units, err, tt.units, tt.err)
+		}
+	}
+	if _, err := ParseMoney("1", "DOGE"); !errors.Is(err, ErrUnknownCurrency) {
+		t.Errorf("unknown currency: got %v", err)
+	}
+}
+
+func TestMoneyJSON(t *testing.T) {
+	data, err := json.Marshal(USDC(12_500_000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"units":12500000,"currency":"USDC","decimals":6,"value":"12.500000"}`; string(data) != want {
+		t.Errorf("marshalled to %s, want %s", data, want)
+	}
+
+	for _, in := range []string{`{"units":12500000,"currency":"USDC"}`, `{"value":"12.5"}`, `"12.5"`, `12.5`, string(data)} {
+		var m Money
+		if err := json.Unmarshal([]byte(in), &m); err != nil || m != USDC(12_500_000) {
+			t.Errorf("unmarshal %s = %v, %v", in, m, err)
+		}
+	}
+	var m Money
+	if err := json.Unmarshal([]byte(`{"units":1,"currency":"DOGE"}`), &m); !errors.Is(err, ErrUnknownCurrency) {
+		t.Errorf("unknown currency: got %v", err)
+	}
+}
@@ -0,0 +1,148 @@
+// Package notify delivers partnership activity events to notification
+// channels. Events are read from the activity_events table, which the
+// services write to in the same database transaction as the change an event
+// describes, so an event is never lost or sent for a change that rolled back.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"aa-sharing-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	batchSize = 20
+	// maxAttempts is how often delivery of an event is tried before it is
+	// given up on.
+	maxAttempts = 10
+)
+
+// Channel is a way of telling partners about an event.
+type Channel interface {
+	Name() string
+	Notify(ctx context.Context, event *models.ActivityEvent) error
+}
+
+// Log writes events to the server log.
+type Log struct{}
+
+func (Log) Name() string { return "log" }
+
+func (Log) Notify(ctx context.Context, event *models.ActivityEvent) error {
+	log.Printf("Partnership %d: %s", event.PartnershipID, event.Message)
+	return nil
+}
+
+// Webhook posts events as JSON to a URL.
+type Webhook struct {
+	URL    string
+	Client *http.Client
+}
+
+func NewWebhook(url string) *Webhook {
+	return &Webhook{URL: url, Client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (w *Webhook) Name() string { return "webhook" }
+
+func (w *Webhook) Notify(ctx context.Context, event *models.ActivityEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// Dispatcher sends undelivered activity events to every channel. Delivery is
+// at least once: an event is retried on all channels when any of them fails.
+type Dispatcher struct {
+	db       *gorm.DB
+	channels []Channel
+}
+
+func NewDispatcher(db *gorm.DB, channels ...Channel) *Dispatcher {
+	return &Dispatcher{db: db, channels: channels}
+}
+
+// Run dispatches events every interval until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context, interval time.Duration) error {
+	for {
+		if err := d.Dispatch(ctx); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return err
+			}
+			log.Println("Notification dispatch failed:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Dispatch delivers one batch of pending events. Events are locked while
+// they are sent so that several API instances can dispatch side by side.
+func (d *Dispatcher) Dispatch(ctx context.Context) error {
+	return d.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var events []models.ActivityEvent
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("notified_at IS NULL AND attempts < ?", maxAttempts).
+			Order("id").
+			Limit(batchSize).
+			Find(&events).Error; err != nil {
+			return err
+		}
+
+		for i := range events {
+			event := &events[i]
+			updates := map[string]interface{}{"attempts": event.Attempts + 1}
+			if err := d.send(ctx, event); err != nil {
+				log.Printf("Notifying activity event %d failed: %v", event.ID, err)
+				updates["last_error"] = err.Error()
+			} else {
+				updates["notified_at"] = time.Now()
+				updates["last_error"] = ""
+			}
+			if err := tx.Model(event).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (d *Dispatcher) send(ctx context.Context, event *models.ActivityEvent) error {
+	var errs []error
+	for _, channel := range d.channels {
+		if err := channel.Notify(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", channel.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
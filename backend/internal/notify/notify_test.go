@@ -0,0 +1,95 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"aa-sharing-backend/internal/models"
+	"aa-sharing-backend/internal/services"
+	"aa-sharing-backend/internal/testdb"
+)
+
+// recorder counts the events it is told about per milestone.
+type recorder struct {
+	mu         sync.Mutex
+	milestones map[uint]int
+}
+
+func (r *recorder) Name() string { return "recorder" }
+
+func (r *recorder) Notify(ctx context.Context, event *models.ActivityEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if event.MilestoneID != nil {
+		r.milestones[*event.MilestoneID]++
+	}
+	return nil
+}
+
+func TestMilestoneNotifiedOnceUnderConcurrentContributions(t *testing.T) {
+	db := testdb.Open(t)
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.SetMaxOpenConns(20)
+
+	wallet := services.NewWalletService(db)
+	partnership := testdb.Partnership(t, db)
+	goal := models.Goal{PartnershipID: partnership.ID, Name: "Trip", TargetAmount: models.USDC(100_000_000), Status: "active"}
+	if err := wallet.CreateGoal(&goal, []models.Money{models.USDC(30_000_000)}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 40 USDC in parallel; whichever contribution crosses 30 USDC claims the
+	// milestone
+	const contributions = 40
+	var wg sync.WaitGroup
+	errs := make(chan error, contributions)
+	for i := 0; i < contributions; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- wallet.CreateTransaction(&models.Transaction{
+				UserID:        partnership.UserAID,
+				PartnershipID: partnership.ID,
+				Type:          "contribution",
+				Amount:        models.USDC(1_000_000),
+				GoalID:        &goal.ID,
+				Status:        "confirmed",
+			})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("contribution failed: %v", err)
+		}
+	}
+
+	var reached []models.GoalMilestone
+	if err := db.Where("goal_id = ? AND reached_at IS NOT NULL", goal.ID).Find(&reached).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(reached) != 1 {
+		t.Fatalf("%d reached milestones, want 1", len(reached))
+	}
+	var events int64
+	db.Model(&models.ActivityEvent{}).Where("milestone_id = ?", reached[0].ID).Count(&events)
+	if events != 1 {
+		t.Errorf("%d activity events for the milestone, want 1", events)
+	}
+
+	channel := &recorder{milestones: map[uint]int{}}
+	dispatcher := NewDispatcher(db, channel)
+	for i := 0; i < 2; i++ {
+		if err := dispatcher.Dispatch(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(channel.milestones) != 1 || channel.milestones[reached[0].ID] != 1 {
+		t.Errorf("milestone notifications %v, want milestone %d once", channel.milestones, reached[0].ID)
+	}
+}
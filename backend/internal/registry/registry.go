@@ -0,0 +1,298 @@
+// Package registry knows the networks the app supports and where its
+// contracts are deployed on each of them. Networks start from built-in
+// defaults, contract addresses come from the deployment manifests written by
+// the Hardhat deploy scripts, and both can be overridden from the
+// environment:
+//
+//	CHAIN_<chainID>_RPC_URL               used by the backend; may carry an API key
+//	CHAIN_<chainID>_PUBLIC_RPC_URL        served to clients
+//	CHAIN_<chainID>_EXPLORER_URL
+//	CHAIN_<chainID>_CONFIRMATIONS
+//	CHAIN_<chainID>_BLOCK_TIME            average seconds between blocks
+//	CHAIN_<chainID>_<CONTRACT>_ADDRESS   e.g. CHAIN_747_AA_SHARING_ADDRESS
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Contract keys used in deployment manifests.
+const (
+	ContractAASharing        = "aaSharing"
+	ContractCrossChainBridge = "crossChainBridge"
+	ContractMockUSDC         = "mockUSDC"
+	ContractSimpleStorage    = "simpleStorage"
+)
+
+type NativeCurrency struct {
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
+	Decimals int    `json:"decimals"`
+}
+
+type Contract struct {
+	Address    string     `json:"address"`
+	DeployedAt *time.Time `json:"deployed_at,omitempty"`
+}
+
+type Network struct {
+	Key            string              `json:"key"`
+	ChainID        uint64              `json:"chain_id"`
+	Name           string              `json:"name"`
+	RPCURL         string              `json:"-"` // may carry an API key, so it is never served
+	PublicRPCURL   string              `json:"rpc_url,omitempty"`
+	ExplorerURL    string              `json:"explorer_url"`
+	Confirmations  uint64              `json:"confirmations"`
+	BlockTime      float64             `json:"block_time"` // average seconds between blocks
+	NativeCurrency NativeCurrency      `json:"native_currency"`
+	Contracts      map[string]Contract `json:"contracts"`
+}
+
+// ContractAddress returns the address of a contract on the network.
+func (n *Network) ContractAddress(key string) (common.Address, bool) {
+	contract, ok := n.Contracts[key]
+	if !ok || !common.IsHexAddress(contract.Address) {
+		return common.Address{}, false
+	}
+	return common.HexToAddress(contract.Address), true
+}
+
+var (
+	ether = NativeCurrency{Name: "Ether", Symbol: "ETH", Decimals: 18}
+	flow  = NativeCurrency{Name: "Flow", Symbol: "FLOW", Decimals: 18}
+)
+
+// defaultNetworks mirrors the networks in smart-contracts/hardhat.config.js
+// and the chains the frontend offers for cross-chain deposits.
+var defaultNetworks = []Network{
+	{Key: "ethereum", ChainID: 1, Name: "Ethereum", RPCURL: "https://ethereum-rpc.publicnode.com", PublicRPCURL: "https://ethereum-rpc.publicnode.com", ExplorerURL: "https://etherscan.io", Confirmations: 12, BlockTime: 12, NativeCurrency: ether},
+	{Key: "polygon", ChainID: 137, Name: "Polygon", RPCURL: "https://polygon-rpc.com", PublicRPCURL: "https://polygon-rpc.com", ExplorerURL: "https://polygonscan.com", Confirmations: 64, BlockTime: 2, NativeCurrency: NativeCurrency{Name: "MATIC", Symbol: "MATIC", Decimals: 18}},
+	{Key: "zksync", ChainID: 324, Name: "zkSync Era", RPCURL: "https://mainnet.era.zksync.io", PublicRPCURL: "https://mainnet.era.zksync.io", ExplorerURL: "https://explorer.zksync.io", Confirmations: 12, BlockTime: 1, NativeCurrency: ether},
+	{Key: "flowTestnet", ChainID: 545, Name: "Flow Testnet", RPCURL: "https://testnet.evm.nodes.onflow.org", PublicRPCURL: "https://testnet.evm.nodes.onflow.org", ExplorerURL: "https://testnet.flowscan.org", Confirmations: 3, BlockTime: 1, NativeCurrency: flow},
+	{Key: "flowMainnet", ChainID: 747, Name: "Flow Mainnet", RPCURL: "https://mainnet.evm.nodes.onflow.org", PublicRPCURL: "https://mainnet.evm.nodes.onflow.org", ExplorerURL: "https://flowscan.org", Confirmations: 3, BlockTime: 1, NativeCurrency: flow},
+	{Key: "hardhat", ChainID: 1337, Name: "Hardhat", RPCURL: "http://127.0.0.1:8545", Confirmations: 0, BlockTime: 1, NativeCurrency: ether},
+	{Key: "arbitrum", ChainID: 42161, Name: "Arbitrum One", RPCURL: "https://arb1.arbitrum.io/rpc", PublicRPCURL: "https://arb1.arbitrum.io/rpc", ExplorerURL: "https://arbiscan.io", Confirmations: 12, BlockTime: 0.25, NativeCurrency: ether},
+}
+
+// manifest is the file written by scripts/deploy*.js.
+type manifest struct {
+	Network   string                     `json:"network"`
+	ChainID   string                     `json:"chainId"`
+	Timestamp time.Time                  `json:"timestamp"`
+	Contracts map[string]json.RawMessage `json:"contracts"`
+}
+
+type Registry struct {
+	networks map[uint64]*Network
+}
+
+// Load builds the registry from the defaults, the *.json manifests in dir
+// and the environment. A missing dir is not an error. When several
+// manifests deploy the same contract on a chain, the newest one wins.
+func Load(dir string) (*Registry, error) {
+	r := &Registry{networks: map[uint64]*Network{}}
+	for _, network := range defaultNetworks {
+		network := network
+		network.Contracts = map[string]Contract{}
+		r.networks[network.ChainID] = &network
+	}
+
+	if dir != "" {
+		if err := r.loadManifests(dir); err != nil {
+			return nil, err
+		}
+	}
+	if err := r.applyEnv(os.Environ()); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Networks returns every known network ordered by chain ID.
+func (r *Registry) Networks() []Network {
+	networks := make([]Network, 0, len(r.networks))
+	for _, network := range r.networks {
+		networks = append(networks, *network)
+	}
+	sort.Slice(networks, func(i, j int) bool { return networks[i].ChainID < networks[j].ChainID })
+	return networks
+}
+
+// Network returns the network with chainID.
+func (r *Registry) Network(chainID uint64) (*Network, bool) {
+	network, ok := r.networks[chainID]
+	return network, ok
+}
+
+// Lookup returns the network named by a chain ID or a network key such as
+// flowMainnet.
+func (r *Registry) Lookup(chain string) (*Network, bool) {
+	if chainID, err := strconv.ParseUint(chain, 10, 64); err == nil {
+		return r.Network(chainID)
+	}
+	for _, network := range r.networks {
+		if strings.EqualFold(network.Key, chain) {
+			return network, true
+		}
+	}
+	return nil, false
+}
+
+func (r *Registry) loadManifests(dir string) error {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+
+	var manifests []manifest
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var m manifest
+		if err := json.Unmarshal(data, &m); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		manifests = append(manifests, m)
+	}
+	sort.Slice(manifests, func(i, j int) bool { return manifests[i].Timestamp.Before(manifests[j].Timestamp) })
+
+	for _, m := range manifests {
+		chainID, err := strconv.ParseUint(m.ChainID, 10, 64)
+		if err != nil {
+			return fmt.Errorf("manifest for %s: invalid chainId %q", m.Network, m.ChainID)
+		}
+		network := r.network(chainID)
+		if network.Name == "" {
+			network.Name = m.Network
+			network.Key = camelCase(strings.Fields(m.Network))
+		}
+
+		deployedAt := m.Timestamp
+		for key, raw := range m.Contracts {
+			var contract struct {
+				Address string `json:"address"`
+			}
+			if err := json.Unmarshal(raw, &contract); err != nil || !common.IsHexAddress(contract.Address) {
+				continue
+			}
+			network.Contracts[key] = Contract{Address: contract.Address, DeployedAt: &deployedAt}
+		}
+	}
+	return nil
+}
+
+func (r *Registry) applyEnv(environ []string) error {
+	for _, kv := range environ {
+		name, value, _ := strings.Cut(kv, "=")
+		rest, ok := strings.CutPrefix(name, "CHAIN_")
+		if !ok || value == "" {
+			continue
+		}
+		id, setting, ok := strings.Cut(rest, "_")
+		chainID, err := strconv.ParseUint(id, 10, 64)
+		if !ok || err != nil {
+			continue
+		}
+		network := r.network(chainID)
+
+		switch {
+		case setting == "RPC_URL":
+			network.RPCURL = value
+		case setting == "PUBLIC_RPC_URL":
+			network.PublicRPCURL = value
+		case setting == "EXPLORER_URL":
+			network.ExplorerURL = value
+		case setting == "CONFIRMATIONS":
+			confirmations, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			network.Confirmations = confirmations
+		case setting == "BLOCK_TIME":
+			blockTime, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			network.BlockTime = blockTime
+		case strings.HasSuffix(setting, "_ADDRESS"):
+			if !common.IsHexAddress(value) {
+				return fmt.Errorf("%s: invalid address %q", name, value)
+			}
+			key := contractKey(network, strings.TrimSuffix(setting, "_ADDRESS"))
+			network.Contracts[key] = Contract{Address: value}
+		}
+	}
+	return nil
+}
+
+// network returns the network with chainID, adding it if it is unknown.
+func (r *Registry) network(chainID uint64) *Network {
+	network, ok := r.networks[chainID]
+	if !ok {
+		network = &Network{
+			Key:            "chain" + strconv.FormatUint(chainID, 10),
+			ChainID:        chainID,
+			NativeCurrency: ether,
+			Contracts:      map[string]Contract{},
+		}
+		r.networks[chainID] = network
+	}
+	return network
+}
+
+// contractKey maps an environment name such as AA_SHARING to the manifest
+// key of the contract, aaSharing.
+func contractKey(network *Network, envName string) string {
+	for key := range network.Contracts {
+		if envKey(key) == envName {
+			return key
+		}
+	}
+	for _, key := range []string{ContractAASharing, ContractCrossChainBridge, ContractMockUSDC, ContractSimpleStorage} {
+		if envKey(key) == envName {
+			return key
+		}
+	}
+	return camelCase(strings.Split(strings.ToLower(envName), "_"))
+}
+
+// envKey turns a manifest key into its environment form: aaSharing becomes
+// AA_SHARING and mockUSDC becomes MOCK_USDC.
+func envKey(key string) string {
+	var b strings.Builder
+	runes := []rune(key)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && !unicode.IsUpper(runes[i-1]) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
+func camelCase(words []string) string {
+	var b strings.Builder
+	for i, word := range words {
+		if word == "" {
+			continue
+		}
+		if i == 0 {
+			b.WriteString(strings.ToLower(word))
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + strings.ToLower(word[1:]))
+	}
+	return b.String()
+}
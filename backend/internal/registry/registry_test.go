@@ -0,0 +1,45 @@
+package registry
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNetworksDoNotServeBackendRPCURLs(t *testing.T) {
+	t.Setenv("CHAIN_1_RPC_URL", "https://eth-mainnet.example.com/v2/secret-key")
+	t.Setenv("CHAIN_747_PUBLIC_RPC_URL", "https://flow.example.com")
+	t.Setenv("CHAIN_747_CONFIRMATIONS", "5")
+
+	r, err := Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ethereum, _ := r.Network(1)
+	if ethereum.RPCURL != "https://eth-mainnet.example.com/v2/secret-key" {
+		t.Errorf("backend RPC URL %q", ethereum.RPCURL)
+	}
+	flow, _ := r.Network(747)
+	if flow.PublicRPCURL != "https://flow.example.com" || flow.Confirmations != 5 {
+		t.Errorf("flow public RPC URL %q with %d confirmations", flow.PublicRPCURL, flow.Confirmations)
+	}
+
+	served, err := json.Marshal(r.Networks())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(served), "secret-key") || strings.Contains(string(served), "alchemy") {
+		t.Errorf("served networks include a keyed RPC URL: %s", served)
+	}
+	var networks []map[string]interface{}
+	if err := json.Unmarshal(served, &networks); err != nil {
+		t.Fatal(err)
+	}
+	for _, network := range networks {
+		if network["key"] == "hardhat" {
+			if _, ok := network["rpc_url"]; ok {
+				t.Errorf("hardhat serves rpc_url %v, want none", network["rpc_url"])
+			}
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package services
+
+import (
+	"aa-sharing-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+type ActivityService struct {
+	db *gorm.DB
+}
+
+func NewActivityService(db *gorm.DB) *ActivityService {
+	return &ActivityService{db: db}
+}
+
+// GetActivity returns a partnership's most recent activity events, newest
+// first. A non-zero before only returns events older than that event ID.
+func (s *ActivityService) GetActivity(partnershipID, before uint, limit int) ([]models.ActivityEvent, error) {
+	query := s.db.Where("partnership_id = ?", partnershipID)
+	if before != 0 {
+		query = query.Where("id < ?", before)
+	}
+
+	var events []models.ActivityEvent
+	if err := query.Order("id DESC").Limit(limit).Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"aa-sharing-backend/internal/bridge"
+	"aa-sharing-backend/internal/chain"
+	"aa-sharing-backend/internal/models"
+	"aa-sharing-backend/internal/registry"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+var (
+	ErrUnknownChain        = errors.New("unknown chain")
+	ErrBridgeNotDeployed   = errors.New("CrossChainBridge is not deployed on this chain")
+	ErrSameChain           = errors.New("source and destination chain must differ")
+	ErrAmountOutsideLimits = errors.New("amount is outside the bridge deposit limits")
+)
+
+// BridgeQuote is what a cross-chain deposit of Amount would cost and yield,
+// from the source CrossChainBridge's state as of FetchedAt.
+type BridgeQuote struct {
+	SourceChainID              uint64       `json:"source_chain_id"`
+	DestinationChainID         uint64       `json:"destination_chain_id"`
+	Amount                     models.Money `json:"amount"`
+	Fee                        models.Money `json:"fee"`
+	NetAmount                  models.Money `json:"net_amount"`
+	FeeBasisPoints             uint64       `json:"fee_basis_points"`
+	MinDeposit                 models.Money `json:"min_deposit"`
+	MaxDeposit                 models.Money `json:"max_deposit"`
+	EstimatedSettlementSeconds int64        `json:"estimated_settlement_seconds"`
+	FetchedAt                  time.Time    `json:"fetched_at"`
+	ValidUntil                 time.Time    `json:"valid_until"`
+}
+
+// bridgeTerms are the fee and limits of a CrossChainBridge deployment.
+type bridgeTerms struct {
+	feeBasisPoints uint64
+	minDeposit     int64
+	maxDeposit     int64
+	fetchedAt      time.Time
+}
+
+// BridgeQuoteService quotes cross-chain deposits. The fee and deposit limits
+// are read from the source chain's CrossChainBridge and cached for ttl.
+type BridgeQuoteService struct {
+	registry   *registry.Registry
+	ttl        time.Duration
+	relayDelay time.Duration // how long the relayer may take to notice a deposit
+
+	mu      sync.Mutex
+	clients map[uint64]*chain.Client
+	terms   map[uint64]bridgeTerms
+}
+
+func NewBridgeQuoteService(registry *registry.Registry, ttl, relayDelay time.Duration) *BridgeQuoteService {
+	return &BridgeQuoteService{
+		registry:   registry,
+		ttl:        ttl,
+		relayDelay: relayDelay,
+		clients:    map[uint64]*chain.Client{},
+		terms:      map[uint64]bridgeTerms{},
+	}
+}
+
+// Quote prices a deposit of amount from source to destination, which name
+// networks by chain ID or key. An amount outside the deposit limits, which
+// initiateCrossChainDeposit would reject with InvalidAmount, returns the
+// quote along with ErrAmountOutsideLimits.
+func (s *BridgeQuoteService) Quote(ctx context.Context, amount models.Money, source, destination string) (*BridgeQuote, error) {
+	sourceNetwork, ok := s.registry.Lookup(source)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownChain, source)
+	}
+	destinationNetwork, ok := s.registry.Lookup(destination)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownChain, destination)
+	}
+	if sourceNetwork.ChainID == destinationNetwork.ChainID {
+		return nil, ErrSameChain
+	}
+	if _, ok := destinationNetwork.ContractAddress(registry.ContractCrossChainBridge); !ok {
+		return nil, fmt.Errorf("%w: %s", ErrBridgeNotDeployed, destinationNetwork.Name)
+	}
+
+	terms, err := s.loadTerms(ctx, sourceNetwork)
+	if err != nil {
+		return nil, err
+	}
+
+	fee, net := bridge.CalculateFee(amount.Units, terms.feeBasisPoints)
+	quote := &BridgeQuote{
+		SourceChainID:              sourceNetwork.ChainID,
+		DestinationChainID:         destinationNetwork.ChainID,
+		Amount:                     amount,
+		Fee:                        models.USDC(fee),
+		NetAmount:                  models.USDC(net),
+		FeeBasisPoints:             terms.feeBasisPoints,
+		MinDeposit:                 models.USDC(terms.minDeposit),
+		MaxDeposit:                 models.USDC(terms.maxDeposit),
+		EstimatedSettlementSeconds: int64(s.settlementTime(sourceNetwork, destinationNetwork).Seconds()),
+		FetchedAt:                  terms.fetchedAt,
+		ValidUntil:                 terms.fetchedAt.Add(s.ttl),
+	}
+	if amount.Units < terms.minDeposit || amount.Units > terms.maxDeposit {
+		return quote, ErrAmountOutsideLimits
+	}
+	return quote, nil
+}
+
+// settlementTime estimates how long a deposit takes from initiation to
+// completion: the source confirmations the relayer waits for, the time it
+// takes to notice them, and the destination confirmations of the completion.
+func (s *BridgeQuoteService) settlementTime(source, destination *registry.Network) time.Duration {
+	blocks := func(network *registry.Network) time.Duration {
+		return time.Duration(float64(network.Confirmations+1) * network.BlockTime * float64(time.Second))
+	}
+	return blocks(source) + s.relayDelay + blocks(destination)
+}
+
+func (s *BridgeQuoteService) loadTerms(ctx context.Context, network *registry.Network) (bridgeTerms, error) {
+	s.mu.Lock()
+	terms, ok := s.terms[network.ChainID]
+	s.mu.Unlock()
+	if ok && time.Since(terms.fetchedAt) < s.ttl {
+		return terms, nil
+	}
+
+	terms, err := s.fetchTerms(ctx, network)
+	if err != nil {
+		return bridgeTerms{}, err
+	}
+	s.mu.Lock()
+	s.terms[network.ChainID] = terms
+	s.mu.Unlock()
+	return terms, nil
+}
+
+// fetchTerms reads bridgeFee, minDeposit and maxDeposit from the network's
+// CrossChainBridge.
+func (s *BridgeQuoteService) fetchTerms(ctx context.Context, network *registry.Network) (bridgeTerms, error) {
+	address, ok := network.ContractAddress(registry.ContractCrossChainBridge)
+	if !ok {
+		return bridgeTerms{}, fmt.Errorf("%w: %s", ErrBridgeNotDeployed, network.Name)
+	}
+	client, err := s.client(ctx, network)
+	if err != nil {
+		return bridgeTerms{}, err
+	}
+	caller, err := chain.NewCrossChainBridgeCaller(address, client)
+	if err != nil {
+		return bridgeTerms{}, err
+	}
+
+	opts := &bind.CallOpts{Context: ctx}
+	fee, err := caller.BridgeFee(opts)
+	if err != nil {
+		return bridgeTerms{}, err
+	}
+	minDeposit, err := caller.MinDeposit(opts)
+	if err != nil {
+		return bridgeTerms{}, err
+	}
+	maxDeposit, err := caller.MaxDeposit(opts)
+	if err != nil {
+		return bridgeTerms{}, err
+	}
+	if !fee.IsUint64() || !minDeposit.IsInt64() || !maxDeposit.IsInt64() {
+		return bridgeTerms{}, fmt.Errorf("CrossChainBridge on %s returned out of range terms", network.Name)
+	}
+	return bridgeTerms{
+		feeBasisPoints: fee.Uint64(),
+		minDeposit:     minDeposit.Int64(),
+		maxDeposit:     maxDeposit.Int64(),
+		fetchedAt:      time.Now(),
+	}, nil
+}
+
+func (s *BridgeQuoteService) client(ctx context.Context, network *registry.Network) (*chain.Client, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if client, ok := s.clients[network.ChainID]; ok {
+		return client, nil
+	}
+	client, err := chain.Dial(ctx, network.RPCURL)
+	if err != nil {
+		return nil, err
+	}
+	s.clients[network.ChainID] = client
+	return client, nil
+}
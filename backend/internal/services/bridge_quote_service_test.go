@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+
+	"aa-sharing-backend/internal/chain"
+	"aa-sharing-backend/internal/chaintest"
+	"aa-sharing-backend/internal/models"
+	"aa-sharing-backend/internal/registry"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestBridgeQuote(t *testing.T) {
+	c := chaintest.New(t)
+	ctx := context.Background()
+	aa := c.DeployAASharing(t)
+	address, _ := c.Deploy(t, "CrossChainBridge", aa.USDC, aa.Address, crypto.PubkeyToAddress(c.Deployer.PublicKey))
+	bridge, err := chain.NewCrossChainBridge(address, c.Client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Mined(t)(bridge.SetBridgeFee(c.Transactor(t, c.Deployer), big.NewInt(50)))
+
+	chainID, err := c.Client.ChainID(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := chainID.String()
+	t.Setenv("CHAIN_"+source+"_RPC_URL", os.Getenv("TEST_CHAIN_RPC_URL"))
+	t.Setenv("CHAIN_"+source+"_CONFIRMATIONS", "2")
+	t.Setenv("CHAIN_"+source+"_BLOCK_TIME", "1")
+	t.Setenv("CHAIN_"+source+"_CROSS_CHAIN_BRIDGE_ADDRESS", address.Hex())
+	t.Setenv("CHAIN_545_CROSS_CHAIN_BRIDGE_ADDRESS", address.Hex())
+	reg, err := registry.Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewBridgeQuoteService(reg, time.Hour, 10*time.Second)
+
+	quote, err := s.Quote(ctx, models.USDC(10_000_000), source, "flowTestnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if quote.Fee != models.USDC(50_000) || quote.NetAmount != models.USDC(9_950_000) || quote.FeeBasisPoints != 50 {
+		t.Errorf("fee %s (%d bps), net %s; want 0.05 (50 bps), 9.95", quote.Fee, quote.FeeBasisPoints, quote.NetAmount)
+	}
+	if quote.MinDeposit != models.USDC(1_000_000) || quote.MaxDeposit != models.USDC(100_000_000_000) {
+		t.Errorf("limits %s to %s, want 1 to 100000", quote.MinDeposit, quote.MaxDeposit)
+	}
+	// Three source blocks, the relay delay and four Flow blocks
+	if quote.EstimatedSettlementSeconds != 17 || quote.DestinationChainID != 545 {
+		t.Errorf("settles on %d in %ds, want 545 in 17s", quote.DestinationChainID, quote.EstimatedSettlementSeconds)
+	}
+
+	quote, err = s.Quote(ctx, models.USDC(500_000), source, "545")
+	if !errors.Is(err, ErrAmountOutsideLimits) || quote == nil || quote.Fee != models.USDC(2_500) {
+		t.Errorf("0.5 USDC: quote %+v, error %v; want the quote with ErrAmountOutsideLimits", quote, err)
+	}
+
+	// The fee is cached until the quote's ttl runs out
+	c.Mined(t)(bridge.SetBridgeFee(c.Transactor(t, c.Deployer), big.NewInt(100)))
+	if quote, err := s.Quote(ctx, models.USDC(10_000_000), source, "545"); err != nil || quote.FeeBasisPoints != 50 {
+		t.Errorf("quote within the ttl: %+v (%v), want the cached 50 bps", quote, err)
+	}
+	if _, err := s.Quote(ctx, models.USDC(10_000_000), source, source); !errors.Is(err, ErrSameChain) {
+		t.Errorf("quote to the same chain: got %v, want ErrSameChain", err)
+	}
+	if _, err := s.Quote(ctx, models.USDC(10_000_000), source, "ethereum"); !errors.Is(err, ErrBridgeNotDeployed) {
+		t.Errorf("quote to a chain without the bridge: got %v, want ErrBridgeNotDeployed", err)
+	}
+}
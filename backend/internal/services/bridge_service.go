@@ -0,0 +1,78 @@
+package services
+
+import (
+	"aa-sharing-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// BridgeService reads the cross-chain deposits recorded by the
+// CrossChainBridge relayer.
+type BridgeService struct {
+	db *gorm.DB
+}
+
+func NewBridgeService(db *gorm.DB) *BridgeService {
+	return &BridgeService{db: db}
+}
+
+// GetUserDeposits returns the deposits the user sent from their wallet or
+// that went into one of their partnerships, newest first, with their status
+// history.
+func (s *BridgeService) GetUserDeposits(user *models.User) ([]models.BridgeMessage, error) {
+	var deposits []models.BridgeMessage
+	if err := s.withHistory().Where(s.visibleTo(user)).Order("created_at DESC").Find(&deposits).Error; err != nil {
+		return nil, err
+	}
+	return deposits, nil
+}
+
+// GetPartnershipDeposits returns the deposits into a partnership, newest
+// first. A partnership that is not on chain has none.
+func (s *BridgeService) GetPartnershipDeposits(partnershipID uint) ([]models.BridgeMessage, error) {
+	var partnership models.Partnership
+	if err := s.db.First(&partnership, partnershipID).Error; err != nil {
+		return nil, err
+	}
+	deposits := []models.BridgeMessage{}
+	if partnership.ChainPartnershipID == nil {
+		return deposits, nil
+	}
+
+	if err := s.withHistory().Where("chain_partnership_id = ?", *partnership.ChainPartnershipID).
+		Order("created_at DESC").
+		Find(&deposits).Error; err != nil {
+		return nil, err
+	}
+	return deposits, nil
+}
+
+// GetDeposit returns a deposit by message ID. Deposits the user cannot see
+// are reported as not found.
+func (s *BridgeService) GetDeposit(user *models.User, messageID string) (*models.BridgeMessage, error) {
+	var deposit models.BridgeMessage
+	if err := s.withHistory().Where("LOWER(message_id) = LOWER(?)", messageID).Where(s.visibleTo(user)).
+		First(&deposit).Error; err != nil {
+		return nil, err
+	}
+	return &deposit, nil
+}
+
+func (s *BridgeService) withHistory() *gorm.DB {
+	return s.db.Preload("History", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id")
+	})
+}
+
+// visibleTo matches the deposits sent from the user's wallet or into one of
+// their partnerships.
+func (s *BridgeService) visibleTo(user *models.User) *gorm.DB {
+	partnerships := s.db.Model(&models.Partnership{}).
+		Select("chain_partnership_id").
+		Where("(user_a_id = ? OR user_b_id = ?) AND chain_partnership_id IS NOT NULL", user.ID, user.ID)
+	condition := s.db.Where("chain_partnership_id IN (?)", partnerships)
+	if user.WalletAddress != "" {
+		condition = condition.Or("LOWER(user_address) = LOWER(?)", user.WalletAddress)
+	}
+	return condition
+}
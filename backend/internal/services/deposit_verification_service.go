@@ -0,0 +1,271 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"aa-sharing-backend/internal/chain"
+	"aa-sharing-backend/internal/models"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrDepositMismatch is returned by Submit for a tx hash that is already
+// recorded as a different deposit.
+var ErrDepositMismatch = errors.New("transaction is already recorded as a different deposit")
+
+// ReceiptClient is the JSON-RPC API needed to verify deposits. It is
+// satisfied by *ethclient.Client.
+type ReceiptClient interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+// DepositVerificationService confirms client-reported deposits against the
+// receipt of the transaction the client says made them.
+type DepositVerificationService struct {
+	db            *gorm.DB
+	client        ReceiptClient
+	walletService *WalletService
+	contract      common.Address
+	confirmations uint64
+	aaSharing     *chain.AASharingFilterer
+}
+
+func NewDepositVerificationService(db *gorm.DB, client ReceiptClient, walletService *WalletService, contract common.Address, confirmations uint64) (*DepositVerificationService, error) {
+	aaSharing, err := chain.NewAASharingFilterer(contract, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &DepositVerificationService{
+		db:            db,
+		client:        client,
+		walletService: walletService,
+		contract:      contract,
+		confirmations: confirmations,
+		aaSharing:     aaSharing,
+	}, nil
+}
+
+// Submit records a deposit reported by a client as pending and verifies it
+// straight away. Transactions whose receipt is not available yet, or not
+// deep enough, stay pending and are picked up again by Run. Submitting the
+// same tx hash again, or one the indexer has recorded already, returns the
+// existing transaction; see claim.
+func (s *DepositVerificationService) Submit(ctx context.Context, transaction *models.Transaction) error {
+	transaction.TxHash = common.HexToHash(transaction.TxHash).Hex()
+
+	claimed, err := s.claim(transaction)
+	if err != nil || claimed {
+		return err
+	}
+
+	transaction.Status = "pending"
+	if err := s.walletService.CreateTransaction(transaction); err != nil {
+		return err
+	}
+	if err := s.verify(ctx, transaction); err != nil {
+		return err
+	}
+	return s.db.First(transaction, transaction.ID).Error
+}
+
+// claim looks for a live transaction of the user with the same tx hash and,
+// if there is one, replaces transaction with it. The existing row must be the
+// same deposit, otherwise ErrDepositMismatch is returned. The indexer does
+// not know the goal or description the client meant the deposit for, so
+// those are filled in from transaction where the row has none, and a
+// deposit that is already confirmed is allocated to the goal.
+func (s *DepositVerificationService) claim(transaction *models.Transaction) (bool, error) {
+	var claimed bool
+	err := transactionWithRetry(s.db, func(tx *gorm.DB) error {
+		var existing models.Transaction
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND tx_hash = ? AND status <> ?", transaction.UserID, transaction.TxHash, "failed").
+			First(&existing).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if existing.PartnershipID != transaction.PartnershipID ||
+			existing.Type != transaction.Type ||
+			existing.Amount.Units != transaction.Amount.Units ||
+			existing.Amount.Code() != transaction.Amount.Code() ||
+			(existing.GoalID != nil && transaction.GoalID != nil && *existing.GoalID != *transaction.GoalID) {
+			return ErrDepositMismatch
+		}
+
+		changes := map[string]interface{}{}
+		if existing.Description == "" && transaction.Description != "" {
+			existing.Description = transaction.Description
+			changes["description"] = existing.Description
+		}
+		allocate := existing.GoalID == nil && transaction.GoalID != nil
+		if allocate {
+			existing.GoalID = transaction.GoalID
+			changes["goal_id"] = *existing.GoalID
+		}
+		if len(changes) > 0 {
+			if err := tx.Model(&models.Transaction{}).Where("id = ?", existing.ID).Updates(changes).Error; err != nil {
+				return err
+			}
+		}
+		// Pending deposits are allocated when they are confirmed
+		if allocate && existing.Status == "confirmed" {
+			if err := s.walletService.allocateContribution(tx, &existing); err != nil {
+				return err
+			}
+		}
+
+		*transaction = existing
+		claimed = true
+		return nil
+	})
+	return claimed, err
+}
+
+// Run re-verifies pending client-reported deposits until ctx is cancelled.
+func (s *DepositVerificationService) Run(ctx context.Context, interval time.Duration) error {
+	for {
+		var transactions []models.Transaction
+		if err := s.db.Where("status = ? AND tx_hash <> ''", "pending").
+			Where("type IN ?", []string{"contribution", "gratitude"}).
+			Where("relay_status IS NULL OR relay_status = ''"). // tracked by the gasless relayer
+			Order("id").
+			Find(&transactions).Error; err != nil {
+			log.Println("Deposit verification failed:", err)
+		}
+		for i := range transactions {
+			if err := s.verify(ctx, &transactions[i]); err != nil {
+				if errors.Is(err, context.Canceled) {
+					return err
+				}
+				log.Printf("Verifying transaction %d failed: %v", transactions[i].ID, err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// verify checks a pending transaction against its receipt. It marks the
+// transaction failed with a reason when the receipt does not match, ties it
+// to the matching log and confirms it once the block is deep enough.
+func (s *DepositVerificationService) verify(ctx context.Context, transaction *models.Transaction) error {
+	receipt, err := s.client.TransactionReceipt(ctx, common.HexToHash(transaction.TxHash))
+	if errors.Is(err, ethereum.NotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return s.fail(transaction, "transaction reverted")
+	}
+
+	if transaction.LogIndex == nil {
+		logIndex, reason, err := s.match(transaction, receipt)
+		if err != nil {
+			return err
+		}
+		if reason != "" {
+			return s.fail(transaction, reason)
+		}
+
+		// The indexer may have recorded the same log already
+		var count int64
+		if err := s.db.Model(&models.Transaction{}).
+			Where("tx_hash = ? AND log_index = ? AND id <> ?", transaction.TxHash, logIndex, transaction.ID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return s.fail(transaction, "deposit already recorded")
+		}
+
+		if err := s.db.Model(transaction).Update("log_index", logIndex).Error; err != nil {
+			return err
+		}
+		transaction.LogIndex = &logIndex
+	}
+
+	head, err := s.client.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+	if receipt.BlockNumber.Uint64()+s.confirmations > head {
+		return nil
+	}
+	return s.walletService.ConfirmChainTransactions(transaction.TxHash, *transaction.LogIndex)
+}
+
+// match finds the FundsDeposited log in receipt that matches transaction.
+// It returns a reason instead when no log matches.
+func (s *DepositVerificationService) match(transaction *models.Transaction, receipt *types.Receipt) (uint, string, error) {
+	var partnership models.Partnership
+	if err := s.db.First(&partnership, transaction.PartnershipID).Error; err != nil {
+		return 0, "", err
+	}
+	if partnership.ChainPartnershipID == nil {
+		return 0, "partnership is not linked to an on-chain partnership", nil
+	}
+
+	var user models.User
+	if err := s.db.First(&user, transaction.UserID).Error; err != nil {
+		return 0, "", err
+	}
+	if !common.IsHexAddress(user.WalletAddress) {
+		return 0, "user has no wallet address", nil
+	}
+
+	reason := "no FundsDeposited event from the AASharing contract"
+	for _, l := range receipt.Logs {
+		if l.Address != s.contract {
+			continue
+		}
+		deposit, err := s.aaSharing.ParseFundsDeposited(*l)
+		if err != nil {
+			continue // another AASharing event
+		}
+
+		switch {
+		case deposit.PartnershipId.Cmp(new(big.Int).SetUint64(*partnership.ChainPartnershipID)) != 0:
+			reason = "deposit is for a different partnership"
+		case deposit.Depositor != common.HexToAddress(user.WalletAddress):
+			reason = "deposit was made by a different wallet"
+		case !deposit.Amount.IsInt64():
+			reason = fmt.Sprintf("deposited amount %s is out of range", deposit.Amount)
+		case deposit.Amount.Cmp(transaction.Amount.BigInt()) != 0:
+			reason = fmt.Sprintf("deposited amount %s does not match", models.USDC(deposit.Amount.Int64()))
+		case (deposit.Source == "gratitude") != (transaction.Type == "gratitude"):
+			reason = fmt.Sprintf("deposit source %q does not match type %q", deposit.Source, transaction.Type)
+		default:
+			return l.Index, "", nil
+		}
+	}
+	return 0, reason, nil
+}
+
+func (s *DepositVerificationService) fail(transaction *models.Transaction, reason string) error {
+	transaction.Status = "failed"
+	transaction.FailureReason = reason
+	return s.db.Model(transaction).Updates(map[string]interface{}{
+		"status":         "failed",
+		"failure_reason": reason,
+	}).Error
+}
@@ -0,0 +1,87 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"aa-sharing-backend/internal/models"
+	"aa-sharing-backend/internal/testdb"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const indexedTxHash = "0x1111111111111111111111111111111111111111111111111111111111111111"
+
+func TestSubmitClaimsIndexedDeposit(t *testing.T) {
+	db := testdb.Open(t)
+	wallet := NewWalletService(db)
+	s, err := NewDepositVerificationService(db, nil, wallet, common.Address{}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	partnership := testdb.Partnership(t, db)
+	goal := models.Goal{PartnershipID: partnership.ID, Name: "Trip", TargetAmount: models.USDC(100_000_000)}
+	if err := wallet.CreateGoal(&goal, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// As recorded and confirmed by the indexer before the client reports it
+	logIndex := uint(0)
+	if err := wallet.CreateTransaction(&models.Transaction{
+		UserID:        partnership.UserAID,
+		PartnershipID: partnership.ID,
+		Type:          "contribution",
+		Amount:        models.USDC(5_000_000),
+		Status:        "confirmed",
+		TxHash:        indexedTxHash,
+		LogIndex:      &logIndex,
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	report := func(amount int64, goalID *uint) (*models.Transaction, error) {
+		transaction := &models.Transaction{
+			UserID:        partnership.UserAID,
+			PartnershipID: partnership.ID,
+			Type:          "contribution",
+			Amount:        models.USDC(amount),
+			Description:   "Flights",
+			GoalID:        goalID,
+			TxHash:        indexedTxHash,
+		}
+		return transaction, s.Submit(context.Background(), transaction)
+	}
+
+	if _, err := report(6_000_000, &goal.ID); !errors.Is(err, ErrDepositMismatch) {
+		t.Fatalf("reporting another amount: got %v, want ErrDepositMismatch", err)
+	}
+
+	transaction, err := report(5_000_000, &goal.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if transaction.Status != "confirmed" || transaction.GoalID == nil || *transaction.GoalID != goal.ID || transaction.Description != "Flights" {
+		t.Errorf("claimed transaction %+v, want the confirmed row with the goal and description", transaction)
+	}
+	var count int64
+	db.Model(&models.Transaction{}).Count(&count)
+	if count != 1 {
+		t.Errorf("%d transactions, want the indexed one only", count)
+	}
+	if err := db.First(&goal, goal.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if goal.CurrentAmount.Units != 5_000_000 {
+		t.Errorf("goal holds %s, want the claimed 5 USDC", goal.CurrentAmount)
+	}
+
+	// Another goal is a different deposit
+	other := models.Goal{PartnershipID: partnership.ID, Name: "Gift", TargetAmount: models.USDC(10_000_000)}
+	if err := wallet.CreateGoal(&other, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := report(5_000_000, &other.ID); !errors.Is(err, ErrDepositMismatch) {
+		t.Fatalf("reporting another goal: got %v, want ErrDepositMismatch", err)
+	}
+}
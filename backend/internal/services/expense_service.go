@@ -0,0 +1,291 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"aa-sharing-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrNothingToSettle  = errors.New("you do not owe your partner anything")
+	ErrSettlementTooBig = errors.New("settlement is larger than what is owed")
+	ErrNotExpenseOwner  = errors.New("only the partner who paid or recorded an expense can delete it")
+)
+
+// ExpenseParticipant is one participant of a new expense with their weight
+// for the split method, as described on models.ExpenseShare.
+type ExpenseParticipant struct {
+	UserID uint
+	Weight int64
+}
+
+// PartnerBalance sums up one partner's expenses in one currency. Net is
+// positive when the partner is owed money and negative when they owe it.
+type PartnerBalance struct {
+	UserID          uint         `json:"user_id"`
+	Paid            models.Money `json:"paid"`
+	Share           models.Money `json:"share"`
+	SettledPaid     models.Money `json:"settled_paid"`
+	SettledReceived models.Money `json:"settled_received"`
+	Net             models.Money `json:"net"`
+}
+
+// Debt is what one partner owes the other.
+type Debt struct {
+	FromUserID uint         `json:"from_user_id"`
+	ToUserID   uint         `json:"to_user_id"`
+	Amount     models.Money `json:"amount"`
+}
+
+// ExpenseBalances is who owes whom in a partnership once all expenses and
+// settlements are taken into account.
+type ExpenseBalances struct {
+	PartnershipID uint             `json:"partnership_id"`
+	Balances      []PartnerBalance `json:"balances"`
+	Debts         []Debt           `json:"debts"`
+}
+
+type ExpenseService struct {
+	db *gorm.DB
+}
+
+func NewExpenseService(db *gorm.DB) *ExpenseService {
+	return &ExpenseService{db: db}
+}
+
+// CreateExpense splits expense.Amount between the participants and stores
+// the expense with its shares. The payer and all participants must be
+// partners.
+func (s *ExpenseService) CreateExpense(partnership *models.Partnership, expense *models.Expense, participants []ExpenseParticipant) error {
+	if !partnership.HasPartner(expense.PaidByID) {
+		return ErrNotPartner
+	}
+
+	weights := make([]int64, len(participants))
+	seen := map[uint]bool{}
+	for i, participant := range participants {
+		if !partnership.HasPartner(participant.UserID) {
+			return ErrNotPartner
+		}
+		if seen[participant.UserID] {
+			return fmt.Errorf("%w: user %d is listed twice", ErrInvalidSplit, participant.UserID)
+		}
+		seen[participant.UserID] = true
+		weights[i] = participant.Weight
+	}
+
+	amounts, err := splitExpense(expense.Amount.Units, expense.SplitMethod, weights)
+	if err != nil {
+		return err
+	}
+
+	expense.PartnershipID = partnership.ID
+	expense.Shares = make([]models.ExpenseShare, len(participants))
+	for i, participant := range participants {
+		expense.Shares[i] = models.ExpenseShare{
+			UserID: participant.UserID,
+			Weight: participant.Weight,
+			Amount: models.NewMoney(amounts[i], expense.Amount.Code()),
+		}
+	}
+	return s.db.Create(expense).Error
+}
+
+// GetExpenses returns a partnership's expenses, most recent first,
+// optionally only those in category.
+func (s *ExpenseService) GetExpenses(partnershipID uint, category string) ([]models.Expense, error) {
+	query := s.db.Where("partnership_id = ?", partnershipID)
+	if category != "" {
+		query = query.Where("category = ?", category)
+	}
+
+	var expenses []models.Expense
+	if err := query.Preload("PaidBy").
+		Preload("Shares").
+		Order("spent_at DESC, id DESC").
+		Find(&expenses).Error; err != nil {
+		return nil, err
+	}
+	return expenses, nil
+}
+
+func (s *ExpenseService) GetExpense(id uint) (*models.Expense, error) {
+	var expense models.Expense
+	if err := s.db.First(&expense, id).Error; err != nil {
+		return nil, err
+	}
+	return &expense, nil
+}
+
+// DeleteExpense removes an expense from the balances on behalf of userID,
+// who must have paid or recorded it; the other partner cannot rewrite the
+// balances on their own. Settlements already made are kept, so deleting a
+// settled expense leaves the payer owing the settled amount back.
+func (s *ExpenseService) DeleteExpense(expense *models.Expense, userID uint) error {
+	if userID != expense.PaidByID && userID != expense.CreatedByID {
+		return ErrNotExpenseOwner
+	}
+	return s.db.Delete(&models.Expense{}, expense.ID).Error
+}
+
+// GetBalances computes what each partner owes the other.
+func (s *ExpenseService) GetBalances(partnership *models.Partnership) (*ExpenseBalances, error) {
+	return s.balances(s.db, partnership)
+}
+
+// SettleUp records that userID paid their partner amount, or everything
+// they owe in amount's currency when amount is zero, as a settlement
+// transaction. The partnership row is locked so that two settlements cannot
+// both pay off the same debt.
+func (s *ExpenseService) SettleUp(partnership *models.Partnership, userID uint, amount models.Money) (*models.Transaction, error) {
+	var transaction models.Transaction
+	err := transactionWithRetry(s.db, func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&models.Partnership{}, partnership.ID).Error; err != nil {
+			return err
+		}
+
+		balances, err := s.balances(tx, partnership)
+		if err != nil {
+			return err
+		}
+		var owed *models.Money
+		for _, debt := range balances.Debts {
+			if debt.FromUserID == userID && debt.Amount.Code() == amount.Code() {
+				debt := debt
+				owed = &debt.Amount
+			}
+		}
+		if owed == nil {
+			return ErrNothingToSettle
+		}
+		if amount.IsZero() {
+			amount = *owed
+		}
+		if amount.Units > owed.Units {
+			return fmt.Errorf("%w: %s %s owed", ErrSettlementTooBig, owed, owed.Code())
+		}
+
+		partnerID := partnership.UserAID
+		if userID == partnership.UserAID {
+			partnerID = partnership.UserBID
+		}
+		transaction = models.Transaction{
+			UserID:        userID,
+			PartnershipID: partnership.ID,
+			Type:          "settlement",
+			Amount:        amount,
+			Description:   "Settled up with " + nickname(partnership, partnerID),
+			Status:        "confirmed",
+		}
+		return tx.Create(&transaction).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+// balances adds up the expense shares and settlements of a partnership.
+// Every share of an expense is owed to its payer; a settlement moves money
+// from the partner who made it to the other one.
+func (s *ExpenseService) balances(db *gorm.DB, partnership *models.Partnership) (*ExpenseBalances, error) {
+	type key struct {
+		userID   uint
+		currency string
+	}
+	totals := map[key]*PartnerBalance{}
+	balance := func(userID uint, currency string) *PartnerBalance {
+		k := key{userID, currency}
+		if totals[k] == nil {
+			zero := models.NewMoney(0, currency)
+			totals[k] = &PartnerBalance{UserID: userID, Paid: zero, Share: zero, SettledPaid: zero, SettledReceived: zero}
+		}
+		return totals[k]
+	}
+	add := func(total *models.Money, amount models.Money) error {
+		sum, err := total.Add(amount)
+		if err != nil {
+			return err
+		}
+		*total = sum
+		return nil
+	}
+	partnerIDs := []uint{partnership.UserAID, partnership.UserBID}
+
+	var expenses []models.Expense
+	if err := db.Where("partnership_id = ?", partnership.ID).Preload("Shares").Find(&expenses).Error; err != nil {
+		return nil, err
+	}
+	for _, expense := range expenses {
+		currency := expense.Amount.Code()
+		if err := add(&balance(expense.PaidByID, currency).Paid, expense.Amount); err != nil {
+			return nil, err
+		}
+		for _, share := range expense.Shares {
+			if err := add(&balance(share.UserID, currency).Share, share.Amount); err != nil {
+				return nil, err
+			}
+		}
+		// Both partners appear in every currency used
+		for _, id := range partnerIDs {
+			balance(id, currency)
+		}
+	}
+
+	var settlements []models.Transaction
+	if err := db.Where("partnership_id = ? AND type = ? AND status = ?", partnership.ID, "settlement", "confirmed").
+		Find(&settlements).Error; err != nil {
+		return nil, err
+	}
+	for _, settlement := range settlements {
+		currency := settlement.Amount.Code()
+		for _, id := range partnerIDs {
+			total := &balance(id, currency).SettledReceived
+			if id == settlement.UserID {
+				total = &balance(id, currency).SettledPaid
+			}
+			if err := add(total, settlement.Amount); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	result := &ExpenseBalances{PartnershipID: partnership.ID, Balances: []PartnerBalance{}, Debts: []Debt{}}
+	creditors := map[string]uint{}
+	for _, b := range totals {
+		net, err := b.Paid.Sub(b.Share)
+		if err != nil {
+			return nil, err
+		}
+		if net, err = net.Add(b.SettledPaid); err != nil {
+			return nil, err
+		}
+		if b.Net, err = net.Sub(b.SettledReceived); err != nil {
+			return nil, err
+		}
+		result.Balances = append(result.Balances, *b)
+		if b.Net.IsPositive() {
+			creditors[b.Net.Code()] = b.UserID
+		}
+	}
+	sort.Slice(result.Balances, func(i, j int) bool {
+		a, b := result.Balances[i], result.Balances[j]
+		if a.Net.Code() != b.Net.Code() {
+			return a.Net.Code() < b.Net.Code()
+		}
+		return a.UserID < b.UserID
+	})
+
+	// With two partners one partner's debt is the other's credit
+	for _, b := range result.Balances {
+		if creditor, ok := creditors[b.Net.Code()]; ok && b.Net.IsNegative() {
+			result.Debts = append(result.Debts, Debt{FromUserID: b.UserID, ToUserID: creditor, Amount: b.Net.Neg()})
+		}
+	}
+	return result, nil
+}
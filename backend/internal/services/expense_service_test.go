@@ -0,0 +1,72 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"aa-sharing-backend/internal/models"
+	"aa-sharing-backend/internal/testdb"
+)
+
+// expense records an equally split expense paid by paidByID.
+func expense(t *testing.T, s *ExpenseService, partnership *models.Partnership, paidByID, createdByID uint, amount models.Money) *models.Expense {
+	t.Helper()
+	expense := models.Expense{
+		PaidByID:    paidByID,
+		CreatedByID: createdByID,
+		Amount:      amount,
+		Description: "Groceries",
+		SplitMethod: models.SplitEqual,
+	}
+	if err := s.CreateExpense(partnership, &expense, []ExpenseParticipant{
+		{UserID: partnership.UserAID, Weight: 1},
+		{UserID: partnership.UserBID, Weight: 1},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return &expense
+}
+
+func TestDeleteExpenseOnlyByPayerOrCreator(t *testing.T) {
+	db := testdb.Open(t)
+	s := NewExpenseService(db)
+	partnership := testdb.Partnership(t, db)
+	alice, bob := partnership.UserAID, partnership.UserBID
+
+	paid := expense(t, s, partnership, alice, alice, models.USDC(10_000_000))
+	if err := s.DeleteExpense(paid, bob); !errors.Is(err, ErrNotExpenseOwner) {
+		t.Fatalf("deleting the partner's expense: got %v, want ErrNotExpenseOwner", err)
+	}
+	balances, err := s.GetBalances(partnership)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(balances.Debts) != 1 || balances.Debts[0].FromUserID != bob || balances.Debts[0].Amount != models.USDC(5_000_000) {
+		t.Fatalf("debts %+v, want Bob owing 5 USDC", balances.Debts)
+	}
+	if err := s.DeleteExpense(paid, alice); err != nil {
+		t.Fatalf("payer deleting: %v", err)
+	}
+
+	// Recorded by Bob for a bill Alice paid
+	recorded := expense(t, s, partnership, alice, bob, models.USDC(4_000_000))
+	if err := s.DeleteExpense(recorded, bob); err != nil {
+		t.Fatalf("creator deleting: %v", err)
+	}
+	if balances, err := s.GetBalances(partnership); err != nil || len(balances.Debts) != 0 {
+		t.Errorf("debts %+v (%v) after deleting every expense", balances.Debts, err)
+	}
+}
+
+func TestBalancesRejectOverflow(t *testing.T) {
+	db := testdb.Open(t)
+	s := NewExpenseService(db)
+	partnership := testdb.Partnership(t, db)
+
+	expense(t, s, partnership, partnership.UserAID, partnership.UserAID, models.USDC(math.MaxInt64))
+	expense(t, s, partnership, partnership.UserAID, partnership.UserAID, models.USDC(math.MaxInt64))
+	if _, err := s.GetBalances(partnership); !errors.Is(err, models.ErrAmountOverflow) {
+		t.Errorf("got %v, want ErrAmountOverflow", err)
+	}
+}
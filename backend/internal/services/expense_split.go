@@ -0,0 +1,75 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"aa-sharing-backend/internal/models"
+)
+
+var ErrInvalidSplit = errors.New("invalid expense split")
+
+// splitExpense divides total base units between participants according to
+// their weights under method. Exact weights are the amounts themselves and
+// must add up to total; percentage weights are basis points that must add up
+// to 10000. Units that cannot be divided evenly go one each to the
+// participants with the largest remainders, earlier participants first on
+// ties, so the shares always add up to total.
+func splitExpense(total int64, method string, weights []int64) ([]int64, error) {
+	if len(weights) == 0 {
+		return nil, fmt.Errorf("%w: no participants", ErrInvalidSplit)
+	}
+
+	var sum int64
+	for _, weight := range weights {
+		if weight < 0 || method != models.SplitExact && weight == 0 {
+			return nil, fmt.Errorf("%w: weights must be positive", ErrInvalidSplit)
+		}
+		sum += weight
+		if sum < 0 {
+			return nil, fmt.Errorf("%w: weights too large", ErrInvalidSplit)
+		}
+	}
+
+	switch method {
+	case models.SplitExact:
+		if sum != total {
+			return nil, fmt.Errorf("%w: exact amounts add up to %d, not %d", ErrInvalidSplit, sum, total)
+		}
+		return append([]int64(nil), weights...), nil
+	case models.SplitPercentage:
+		if sum != 10000 {
+			return nil, fmt.Errorf("%w: percentages must add up to 100", ErrInvalidSplit)
+		}
+	case models.SplitEqual, models.SplitShares:
+	default:
+		return nil, fmt.Errorf("%w: unknown split method %q", ErrInvalidSplit, method)
+	}
+
+	amounts := make([]int64, len(weights))
+	remainders := make([]*big.Int, len(weights))
+	assigned := int64(0)
+	bigTotal, bigSum := big.NewInt(total), big.NewInt(sum)
+	for i, weight := range weights {
+		quotient, remainder := new(big.Int).QuoRem(
+			new(big.Int).Mul(bigTotal, big.NewInt(weight)), bigSum, new(big.Int))
+		amounts[i] = quotient.Int64()
+		remainders[i] = remainder
+		assigned += amounts[i]
+	}
+
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]].Cmp(remainders[order[b]]) > 0
+	})
+	for i := 0; assigned < total; i++ {
+		amounts[order[i]]++
+		assigned++
+	}
+	return amounts, nil
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"math"
+	"time"
+
+	"aa-sharing-backend/internal/models"
+)
+
+const (
+	// rateWindow is how far back contributions count towards the
+	// partnership's contribution rate.
+	rateWindow = 90 * 24 * time.Hour
+	// minRateWindow keeps the first deposits of a new partnership from
+	// being extrapolated into an unrealistic rate.
+	minRateWindow = 7 * 24 * time.Hour
+	// maxForecast is the furthest out a completion date is forecast.
+	maxForecast = 100 * 365 * 24 * time.Hour
+
+	day   = 24 * time.Hour
+	week  = 7 * day
+	month = time.Duration(365.2425 / 12 * float64(day))
+)
+
+// GoalPlan is a goal's savings plan: what is still missing, what it takes
+// to reach the target date, and when the goal completes at the partnership's
+// current pace.
+type GoalPlan struct {
+	Remaining models.Money `json:"remaining"`
+	// RequiredMonthly and RequiredWeekly are set when the goal has a target
+	// date. Once the date is less than a period away the whole remainder is
+	// due in that period.
+	RequiredMonthly *models.Money `json:"required_monthly,omitempty"`
+	RequiredWeekly  *models.Money `json:"required_weekly,omitempty"`
+	// MonthlyRate is the partnership's average monthly contribution.
+	MonthlyRate models.Money `json:"monthly_rate"`
+	// ForecastCompletion is unset when the goal is reached, nothing is
+	// contributed, or the goal would take longer than maxForecast.
+	ForecastCompletion *time.Time `json:"forecast_completion,omitempty"`
+	// OnTrack is set when the goal has a target date.
+	OnTrack *bool `json:"on_track,omitempty"`
+}
+
+// GoalProgress is a goal with its savings plan.
+type GoalProgress struct {
+	models.Goal
+	Plan GoalPlan `json:"plan"`
+}
+
+// contributionRate is the average number of units contributed per day since
+// from, given the total contributed in that time.
+func contributionRate(total int64, from, now time.Time) float64 {
+	elapsed := now.Sub(from)
+	if elapsed < minRateWindow {
+		elapsed = minRateWindow
+	}
+	return float64(total) / (float64(elapsed) / float64(day))
+}
+
+// planGoal projects a goal from the partnership's contribution rate in
+// units per day. The forecast assumes all new contributions go to this goal.
+func planGoal(goal *models.Goal, dailyRate float64, now time.Time) GoalPlan {
+	currency := goal.TargetAmount.Code()
+	remaining := goal.TargetAmount.Units - goal.CurrentAmount.Units
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	plan := GoalPlan{
+		Remaining:   models.NewMoney(remaining, currency),
+		MonthlyRate: models.NewMoney(int64(math.Round(dailyRate*float64(month)/float64(day))), currency),
+	}
+
+	if remaining > 0 && dailyRate > 0 {
+		days := float64(remaining) / dailyRate
+		if days*float64(day) <= float64(maxForecast) {
+			forecast := now.Add(time.Duration(days * float64(day)))
+			plan.ForecastCompletion = &forecast
+		}
+	}
+
+	if goal.TargetDate == nil {
+		return plan
+	}
+	left := goal.TargetDate.Sub(now)
+	monthly := models.NewMoney(requiredPerPeriod(remaining, left, month), currency)
+	weekly := models.NewMoney(requiredPerPeriod(remaining, left, week), currency)
+	onTrack := remaining == 0 ||
+		plan.ForecastCompletion != nil && !plan.ForecastCompletion.After(*goal.TargetDate)
+	plan.RequiredMonthly = &monthly
+	plan.RequiredWeekly = &weekly
+	plan.OnTrack = &onTrack
+	return plan
+}
+
+// requiredPerPeriod is the contribution per period needed to save remaining
+// units in the time left, rounded up so that the target is met.
+func requiredPerPeriod(remaining int64, left, period time.Duration) int64 {
+	periods := float64(left) / float64(period)
+	if periods < 1 {
+		return remaining
+	}
+	return int64(math.Ceil(float64(remaining) / periods))
+}
@@ -0,0 +1,114 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"aa-sharing-backend/internal/models"
+)
+
+func TestContributionRate(t *testing.T) {
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		total int64
+		from  time.Time
+		want  float64
+	}{
+		{"over the window", 900, now.Add(-90 * day), 10},
+		{"over a week", 70, now.Add(-week), 10},
+		{"new partnership counts a week", 70, now.Add(-day), 10},
+		{"nothing contributed", 0, now.Add(-30 * day), 0},
+	}
+	for _, tt := range tests {
+		if got := contributionRate(tt.total, tt.from, now); got != tt.want {
+			t.Errorf("%s: rate %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func goalWith(target, current int64, targetDate *time.Time) *models.Goal {
+	return &models.Goal{
+		TargetAmount:  models.USDC(target),
+		CurrentAmount: models.USDC(current),
+		TargetDate:    targetDate,
+	}
+}
+
+func TestPlanGoalForecastsCompletion(t *testing.T) {
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	plan := planGoal(goalWith(1000, 400, nil), 10, now)
+	if plan.Remaining != models.USDC(600) {
+		t.Errorf("remaining %d, want 600", plan.Remaining.Units)
+	}
+	if plan.MonthlyRate != models.USDC(304) {
+		t.Errorf("monthly rate %d, want 304 (10 a day over 30.44 days)", plan.MonthlyRate.Units)
+	}
+	if plan.ForecastCompletion == nil || !plan.ForecastCompletion.Equal(now.Add(60*day)) {
+		t.Errorf("forecast %v, want %v", plan.ForecastCompletion, now.Add(60*day))
+	}
+	if plan.RequiredMonthly != nil || plan.RequiredWeekly != nil || plan.OnTrack != nil {
+		t.Errorf("goal without a target date has requirements %+v", plan)
+	}
+
+	if plan := planGoal(goalWith(1000, 400, nil), 0, now); plan.ForecastCompletion != nil {
+		t.Errorf("forecast %v without contributions", plan.ForecastCompletion)
+	}
+	if plan := planGoal(goalWith(1_000_000_000, 0, nil), 0.01, now); plan.ForecastCompletion != nil {
+		t.Errorf("forecast %v beyond %v", plan.ForecastCompletion, maxForecast)
+	}
+	if plan := planGoal(goalWith(1000, 1200, nil), 10, now); plan.Remaining.Units != 0 || plan.ForecastCompletion != nil {
+		t.Errorf("overfunded goal: remaining %d, forecast %v", plan.Remaining.Units, plan.ForecastCompletion)
+	}
+}
+
+func TestPlanGoalRequirements(t *testing.T) {
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	date := func(d time.Duration) *time.Time {
+		at := now.Add(d)
+		return &at
+	}
+	tests := []struct {
+		name            string
+		goal            *models.Goal
+		dailyRate       float64
+		monthly, weekly int64
+		onTrack         bool
+	}{
+		// 70 days are 10 weeks and 2.3 months
+		{"ahead of the date", goalWith(1000, 300, date(70*day)), 20, 305, 70, true},
+		{"forecast on the date", goalWith(1000, 300, date(70*day)), 10, 305, 70, true},
+		{"behind", goalWith(1000, 300, date(70*day)), 5, 305, 70, false},
+		{"nothing contributed", goalWith(1000, 300, date(70*day)), 0, 305, 70, false},
+		{"less than a week left", goalWith(1000, 300, date(3*day)), 10, 700, 700, false},
+		{"date passed", goalWith(1000, 300, date(-day)), 10, 700, 700, false},
+		{"reached", goalWith(1000, 1000, date(-day)), 0, 0, 0, true},
+	}
+	for _, tt := range tests {
+		plan := planGoal(tt.goal, tt.dailyRate, now)
+		if plan.RequiredMonthly == nil || plan.RequiredWeekly == nil || plan.OnTrack == nil {
+			t.Errorf("%s: incomplete plan %+v", tt.name, plan)
+			continue
+		}
+		if plan.RequiredMonthly.Units != tt.monthly || plan.RequiredWeekly.Units != tt.weekly {
+			t.Errorf("%s: %d a month and %d a week, want %d and %d",
+				tt.name, plan.RequiredMonthly.Units, plan.RequiredWeekly.Units, tt.monthly, tt.weekly)
+		}
+		if *plan.OnTrack != tt.onTrack {
+			t.Errorf("%s: on track %v, want %v", tt.name, *plan.OnTrack, tt.onTrack)
+		}
+	}
+}
+
+func TestRequiredPerPeriodRoundsUp(t *testing.T) {
+	if got := requiredPerPeriod(100, 3*week, week); got != 34 {
+		t.Errorf("100 over 3 weeks: %d a week, want 34", got)
+	}
+	if got := requiredPerPeriod(100, 4*week, week); got != 25 {
+		t.Errorf("100 over 4 weeks: %d a week, want 25", got)
+	}
+	if got := requiredPerPeriod(100, week/2, week); got != 100 {
+		t.Errorf("100 in half a week: %d, want all of it", got)
+	}
+}
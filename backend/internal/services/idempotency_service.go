@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"aa-sharing-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrIdempotencyKeyReused     = errors.New("idempotency key was already used for a different request")
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key is still being processed")
+)
+
+type IdempotencyService struct {
+	db  *gorm.DB
+	ttl time.Duration
+}
+
+// NewIdempotencyService creates an IdempotencyService. Keys are kept for ttl
+// after they were first used; after that the same key starts a new request.
+func NewIdempotencyService(db *gorm.DB, ttl time.Duration) *IdempotencyService {
+	return &IdempotencyService{db: db, ttl: ttl}
+}
+
+// Run deletes expired keys every interval until ctx is cancelled.
+func (s *IdempotencyService) Run(ctx context.Context, interval time.Duration) error {
+	for {
+		if _, err := s.Cleanup(); err != nil {
+			log.Println("Idempotency key cleanup failed:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Cleanup deletes keys older than the TTL and returns how many were removed.
+func (s *IdempotencyService) Cleanup() (int64, error) {
+	result := s.db.Where("created_at <= ?", time.Now().Add(-s.ttl)).Delete(&models.IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
+
+// Begin claims key for userID. When the key is new it returns a record the
+// caller must later Complete or Release, and replay is false. When the key
+// was already completed for the same fingerprint it returns the stored record
+// with replay set. An expired key, including one whose request never
+// finished, is dropped and claimed again.
+func (s *IdempotencyService) Begin(userID uint, key, fingerprint string) (record *models.IdempotencyKey, replay bool, err error) {
+	if err := s.db.Where("user_id = ? AND key = ? AND created_at <= ?", userID, key, time.Now().Add(-s.ttl)).
+		Delete(&models.IdempotencyKey{}).Error; err != nil {
+		return nil, false, err
+	}
+
+	record = &models.IdempotencyKey{
+		UserID:      userID,
+		Key:         key,
+		Fingerprint: fingerprint,
+	}
+	if err := s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(record).Error; err != nil {
+		return nil, false, err
+	}
+	if record.ID != 0 {
+		return record, false, nil
+	}
+
+	var existing models.IdempotencyKey
+	if err := s.db.Where("user_id = ? AND key = ?", userID, key).First(&existing).Error; err != nil {
+		return nil, false, err
+	}
+	if existing.Fingerprint != fingerprint {
+		return nil, false, ErrIdempotencyKeyReused
+	}
+	if existing.CompletedAt == nil {
+		return nil, false, ErrIdempotencyKeyInProgress
+	}
+	return &existing, true, nil
+}
+
+// Complete stores the response for a claimed key.
+func (s *IdempotencyService) Complete(record *models.IdempotencyKey, statusCode int, body []byte) error {
+	now := time.Now()
+	return s.db.Model(record).Updates(map[string]interface{}{
+		"status_code":   statusCode,
+		"response_body": body,
+		"completed_at":  now,
+	}).Error
+}
+
+// Release drops a claimed key so that the request can be retried, e.g. after
+// a server error that left no side effects.
+func (s *IdempotencyService) Release(record *models.IdempotencyKey) error {
+	return s.db.Delete(record).Error
+}
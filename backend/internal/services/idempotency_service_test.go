@@ -0,0 +1,75 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"aa-sharing-backend/internal/models"
+	"aa-sharing-backend/internal/testdb"
+)
+
+func TestIdempotencyKeyExpires(t *testing.T) {
+	db := testdb.Open(t)
+	s := NewIdempotencyService(db, time.Hour)
+	user := testdb.User(t, db)
+
+	record, replay, err := s.Begin(user.ID, "k", "first")
+	if err != nil || replay {
+		t.Fatalf("Begin = %v, %v", replay, err)
+	}
+	if _, _, err := s.Begin(user.ID, "k", "first"); !errors.Is(err, ErrIdempotencyKeyInProgress) {
+		t.Fatalf("claimed twice: got %v, want ErrIdempotencyKeyInProgress", err)
+	}
+	if err := s.Complete(record, http.StatusCreated, []byte(`{}`)); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := s.Begin(user.ID, "k", "second"); !errors.Is(err, ErrIdempotencyKeyReused) {
+		t.Fatalf("reused for another request: got %v, want ErrIdempotencyKeyReused", err)
+	}
+
+	// Once expired, the key starts a new request
+	if err := db.Model(record).Update("created_at", time.Now().Add(-2*time.Hour)).Error; err != nil {
+		t.Fatal(err)
+	}
+	if _, replay, err := s.Begin(user.ID, "k", "second"); err != nil || replay {
+		t.Fatalf("expired key: Begin = %v, %v", replay, err)
+	}
+}
+
+func TestIdempotencyCleanup(t *testing.T) {
+	db := testdb.Open(t)
+	s := NewIdempotencyService(db, time.Hour)
+	user := testdb.User(t, db)
+
+	// "stuck" is never completed, as when the server died mid-request
+	for _, key := range []string{"old", "stuck", "fresh"} {
+		record, _, err := s.Begin(user.ID, key, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if key != "stuck" {
+			if err := s.Complete(record, http.StatusOK, []byte(`{}`)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if err := db.Model(&models.IdempotencyKey{}).Where("key IN ?", []string{"old", "stuck"}).
+		Update("created_at", time.Now().Add(-2*time.Hour)).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	removed, err := s.Cleanup()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 2 {
+		t.Errorf("removed %d keys, want 2", removed)
+	}
+	var left []models.IdempotencyKey
+	db.Find(&left)
+	if len(left) != 1 || left[0].Key != "fresh" {
+		t.Errorf("keys left: %v", left)
+	}
+}
@@ -0,0 +1,212 @@
+package services
+
+import (
+	"errors"
+	"strings"
+
+	"aa-sharing-backend/internal/ledger"
+	"aa-sharing-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrNotPartner is returned when a user acts on a partnership they are not part of.
+	ErrNotPartner = errors.New("not a partner in this partnership")
+
+	ErrSelfPartnership     = errors.New("cannot partner with yourself")
+	ErrNicknamesRequired   = errors.New("nicknames required")
+	ErrPartnerNotFound     = errors.New("partner not found")
+	ErrPartnerIdentifier   = errors.New("exactly one of partner email or wallet address is required")
+	ErrNotInvitee          = errors.New("only the invited partner can respond to this invitation")
+	ErrInvalidStatus       = errors.New("invalid partnership status transition")
+	ErrPartnershipHasFunds = errors.New("partnership still holds funds; split them first")
+)
+
+// partnershipTransitions lists the statuses a partnership may move to from
+// each status through UpdateStatus. Pending invitations are resolved through
+// RespondToInvitation instead.
+var partnershipTransitions = map[string][]string{
+	models.PartnershipActive:   {models.PartnershipInactive, models.PartnershipSplit},
+	models.PartnershipInactive: {models.PartnershipActive, models.PartnershipSplit},
+}
+
+type PartnershipService struct {
+	db            *gorm.DB
+	walletService *WalletService
+}
+
+func NewPartnershipService(db *gorm.DB, walletService *WalletService) *PartnershipService {
+	return &PartnershipService{db: db, walletService: walletService}
+}
+
+// CreatePartnership invites a second user, identified by email or wallet
+// address, into a new pending partnership. It applies the same rules as
+// AASharing.createPartnership.
+func (s *PartnershipService) CreatePartnership(creatorID uint, partnerEmail, partnerWallet, nicknameA, nicknameB string) (*models.Partnership, error) {
+	if (partnerEmail == "") == (partnerWallet == "") {
+		return nil, ErrPartnerIdentifier
+	}
+	if strings.TrimSpace(nicknameA) == "" || strings.TrimSpace(nicknameB) == "" {
+		return nil, ErrNicknamesRequired
+	}
+
+	var partner models.User
+	query := s.db.Model(&models.User{})
+	if partnerEmail != "" {
+		query = query.Where("email = ?", partnerEmail)
+	} else {
+		query = query.Where("LOWER(wallet_address) = LOWER(?)", partnerWallet)
+	}
+	if err := query.First(&partner).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrPartnerNotFound
+		}
+		return nil, err
+	}
+
+	if partner.ID == creatorID {
+		return nil, ErrSelfPartnership
+	}
+
+	partnership := models.Partnership{
+		UserAID:   creatorID,
+		UserBID:   partner.ID,
+		NicknameA: nicknameA,
+		NicknameB: nicknameB,
+		Status:    models.PartnershipPending,
+	}
+	if err := s.db.Create(&partnership).Error; err != nil {
+		return nil, err
+	}
+	return s.GetPartnership(partnership.ID)
+}
+
+func (s *PartnershipService) GetPartnership(id uint) (*models.Partnership, error) {
+	var partnership models.Partnership
+	if err := s.db.Preload("UserA").Preload("UserB").First(&partnership, id).Error; err != nil {
+		return nil, err
+	}
+	return &partnership, nil
+}
+
+func (s *PartnershipService) GetUserPartnerships(userID uint) ([]models.Partnership, error) {
+	var partnerships []models.Partnership
+	if err := s.db.Where("user_a_id = ? OR user_b_id = ?", userID, userID).
+		Preload("UserA").
+		Preload("UserB").
+		Order("created_at DESC").
+		Find(&partnerships).Error; err != nil {
+		return nil, err
+	}
+	return partnerships, nil
+}
+
+// AuthorizePartner loads the partnership and checks that userID is one of its
+// two partners, mirroring the onlyPartner modifier of the AASharing contract.
+func (s *PartnershipService) AuthorizePartner(partnershipID, userID uint) (*models.Partnership, error) {
+	partnership, err := s.GetPartnership(partnershipID)
+	if err != nil {
+		return nil, err
+	}
+	if !partnership.HasPartner(userID) {
+		return nil, ErrNotPartner
+	}
+	return partnership, nil
+}
+
+// RespondToInvitation lets the invited partner accept or decline a pending
+// partnership. The partnership row is locked and only updated while it is
+// still pending, so that of two concurrent answers only the first counts.
+func (s *PartnershipService) RespondToInvitation(partnershipID, userID uint, accept bool) (*models.Partnership, error) {
+	status := models.PartnershipDeclined
+	if accept {
+		status = models.PartnershipActive
+	}
+
+	err := transactionWithRetry(s.db, func(tx *gorm.DB) error {
+		partnership, err := s.lockPartnership(tx, partnershipID, userID)
+		if err != nil {
+			return err
+		}
+		if partnership.UserBID != userID {
+			return ErrNotInvitee
+		}
+		return s.setStatus(tx, partnership, models.PartnershipPending, status)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetPartnership(partnershipID)
+}
+
+// UpdateStatus moves an accepted partnership between active, inactive and
+// split. A partnership can only be dissolved once its wallet is empty; the
+// wallet row is locked for the check so that no contribution can land
+// between the check and the status change.
+func (s *PartnershipService) UpdateStatus(partnershipID, userID uint, status string) (*models.Partnership, error) {
+	err := transactionWithRetry(s.db, func(tx *gorm.DB) error {
+		partnership, err := s.lockPartnership(tx, partnershipID, userID)
+		if err != nil {
+			return err
+		}
+
+		allowed := false
+		for _, next := range partnershipTransitions[partnership.Status] {
+			if next == status {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return ErrInvalidStatus
+		}
+
+		if status == models.PartnershipSplit {
+			if _, err := s.walletService.lockWallet(tx, partnershipID, models.DefaultCurrency); err != nil {
+				return err
+			}
+			balance, err := ledger.PartnershipBalance(tx, partnershipID, models.DefaultCurrency)
+			if err != nil {
+				return err
+			}
+			if !balance.IsZero() {
+				return ErrPartnershipHasFunds
+			}
+		}
+		return s.setStatus(tx, partnership, partnership.Status, status)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetPartnership(partnershipID)
+}
+
+// lockPartnership locks the partnership row for the rest of tx and checks
+// that userID is one of its partners.
+func (s *PartnershipService) lockPartnership(tx *gorm.DB, partnershipID, userID uint) (*models.Partnership, error) {
+	var partnership models.Partnership
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&partnership, partnershipID).Error; err != nil {
+		return nil, err
+	}
+	if !partnership.HasPartner(userID) {
+		return nil, ErrNotPartner
+	}
+	return &partnership, nil
+}
+
+// setStatus moves partnership from status from to status to, and returns
+// ErrInvalidStatus if it is no longer in from.
+func (s *PartnershipService) setStatus(tx *gorm.DB, partnership *models.Partnership, from, to string) error {
+	result := tx.Model(&models.Partnership{}).
+		Where("id = ? AND status = ?", partnership.ID, from).
+		Update("status", to)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected != 1 {
+		return ErrInvalidStatus
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"aa-sharing-backend/internal/models"
+	"aa-sharing-backend/internal/testdb"
+)
+
+func TestRespondToInvitationOnlyOnce(t *testing.T) {
+	db := testdb.Open(t)
+	s := NewPartnershipService(db, NewWalletService(db))
+
+	partnership := testdb.Partnership(t, db)
+	if err := db.Model(partnership).Update("status", models.PartnershipPending).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i, accept := range []bool{true, false} {
+		wg.Add(1)
+		go func(i int, accept bool) {
+			defer wg.Done()
+			_, errs[i] = s.RespondToInvitation(partnership.ID, partnership.UserBID, accept)
+		}(i, accept)
+	}
+	wg.Wait()
+
+	succeeded := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case !errors.Is(err, ErrInvalidStatus):
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if succeeded != 1 {
+		t.Errorf("%d answers were accepted, want 1", succeeded)
+	}
+
+	if _, err := s.RespondToInvitation(partnership.ID, partnership.UserAID, true); !errors.Is(err, ErrNotInvitee) && !errors.Is(err, ErrInvalidStatus) {
+		t.Errorf("inviter answered: %v", err)
+	}
+}
+
+func TestUpdateStatusSplitNeedsEmptyWallet(t *testing.T) {
+	db := testdb.Open(t)
+	walletService := NewWalletService(db)
+	s := NewPartnershipService(db, walletService)
+	partnership := testdb.Partnership(t, db)
+
+	if err := walletService.CreateTransaction(&models.Transaction{
+		UserID:        partnership.UserAID,
+		PartnershipID: partnership.ID,
+		Type:          "contribution",
+		Amount:        models.USDC(1_000_000),
+		Status:        "confirmed",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.UpdateStatus(partnership.ID, partnership.UserAID, models.PartnershipSplit); !errors.Is(err, ErrPartnershipHasFunds) {
+		t.Fatalf("split with funds: got %v, want ErrPartnershipHasFunds", err)
+	}
+	if _, err := s.UpdateStatus(partnership.ID, partnership.UserAID, models.PartnershipInactive); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.UpdateStatus(partnership.ID, partnership.UserBID, models.PartnershipPending); !errors.Is(err, ErrInvalidStatus) {
+		t.Errorf("back to pending: got %v, want ErrInvalidStatus", err)
+	}
+	outsider := testdb.User(t, db)
+	if _, err := s.UpdateStatus(partnership.ID, outsider.ID, models.PartnershipActive); !errors.Is(err, ErrNotPartner) {
+		t.Errorf("outsider: got %v, want ErrNotPartner", err)
+	}
+}
@@ -0,0 +1,386 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"aa-sharing-backend/internal/chain"
+	"aa-sharing-backend/internal/ledger"
+	"aa-sharing-backend/internal/models"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrDiscrepancyClosed is returned when reviewing a discrepancy that is
+	// no longer open.
+	ErrDiscrepancyClosed = errors.New("discrepancy is no longer open")
+	// ErrDiscrepancyChanged is returned by Approve when the balances moved
+	// while the correction was being made; the discrepancy is left open.
+	ErrDiscrepancyChanged = errors.New("discrepancy changed while it was being approved; review it again")
+)
+
+// Differences of at least these many whole units of their currency, e.g.
+// 1 and 100 USDC, are of medium and high severity; anything smaller is low.
+const (
+	mediumSeverityAmount = 1
+	highSeverityAmount   = 100
+)
+
+// ReconciliationService compares each partnership's ledger balance with its
+// stored WalletBalance and, when a chain is configured, with its totalBalance
+// in the AASharing contract, and records the differences it finds.
+type ReconciliationService struct {
+	db            *gorm.DB
+	walletService *WalletService
+	aaSharing     *chain.AASharingCaller
+}
+
+// NewReconciliationService creates a ReconciliationService. aaSharing is nil
+// when no chain is configured; only stored balances are checked then.
+func NewReconciliationService(db *gorm.DB, walletService *WalletService, aaSharing *chain.AASharingCaller) *ReconciliationService {
+	return &ReconciliationService{db: db, walletService: walletService, aaSharing: aaSharing}
+}
+
+// Run reconciles all partnerships every interval until ctx is cancelled.
+func (s *ReconciliationService) Run(ctx context.Context, interval time.Duration) error {
+	for {
+		if err := s.Reconcile(ctx); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return err
+			}
+			log.Println("Reconciliation failed:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Reconcile checks every partnership once. A partnership that cannot be
+// checked is logged and skipped so that it does not hold up the others.
+func (s *ReconciliationService) Reconcile(ctx context.Context) error {
+	var partnerships []models.Partnership
+	if err := s.db.Order("id").Find(&partnerships).Error; err != nil {
+		return err
+	}
+
+	for i := range partnerships {
+		if err := s.check(ctx, &partnerships[i]); err != nil {
+			if errors.Is(err, context.Canceled) {
+				return err
+			}
+			log.Printf("Reconciling partnership %d failed: %v", partnerships[i].ID, err)
+		}
+	}
+	return nil
+}
+
+// GetDiscrepancies returns the recorded discrepancies, newest first. Empty
+// filters match everything.
+func (s *ReconciliationService) GetDiscrepancies(status, severity string) ([]models.ReconciliationDiscrepancy, error) {
+	query := s.db.Preload("Partnership")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if severity != "" {
+		query = query.Where("severity = ?", severity)
+	}
+
+	var discrepancies []models.ReconciliationDiscrepancy
+	if err := query.Order("created_at DESC").Find(&discrepancies).Error; err != nil {
+		return nil, err
+	}
+	return discrepancies, nil
+}
+
+// Approve corrects an open discrepancy on behalf of adminID. A drifted
+// wallet balance is reset to the ledger. A chain difference is booked as a
+// confirmed adjustment transaction, so that the ledger matches the contract.
+// The partnership is checked again first, so a discrepancy that has since
+// gone away or changed is never corrected with a stale amount, and the
+// difference is measured once more under the wallet lock before it is
+// booked.
+func (s *ReconciliationService) Approve(ctx context.Context, id, adminID uint) (*models.ReconciliationDiscrepancy, error) {
+	var discrepancy models.ReconciliationDiscrepancy
+	if err := s.db.Preload("Partnership").First(&discrepancy, id).Error; err != nil {
+		return nil, err
+	}
+	if discrepancy.Status != models.DiscrepancyOpen {
+		return nil, ErrDiscrepancyClosed
+	}
+	if err := s.check(ctx, &discrepancy.Partnership); err != nil {
+		return nil, err
+	}
+
+	err := transactionWithRetry(s.db, func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&discrepancy, id).Error; err != nil {
+			return err
+		}
+		if discrepancy.Status != models.DiscrepancyOpen {
+			return ErrDiscrepancyClosed
+		}
+
+		// Every change to the partnership's holdings takes the wallet lock,
+		// so the balances cannot move between measuring and correcting them
+		if _, err := s.walletService.lockWallet(tx, discrepancy.PartnershipID, discrepancy.Expected.Code()); err != nil {
+			return err
+		}
+		expected, actual, err := s.balances(ctx, tx, &discrepancy.Partnership, discrepancy.Kind)
+		if err != nil {
+			return err
+		}
+		difference, err := actual.Sub(expected)
+		if err != nil {
+			return err
+		}
+		if difference.Units != discrepancy.Difference.Units {
+			return ErrDiscrepancyChanged
+		}
+
+		updates := map[string]interface{}{
+			"status":      models.DiscrepancyApproved,
+			"reviewed_by": adminID,
+			"closed_at":   time.Now(),
+		}
+		if discrepancy.Kind == models.DiscrepancyChainBalance {
+			transaction := models.Transaction{
+				UserID:        adminID,
+				PartnershipID: discrepancy.PartnershipID,
+				Type:          "adjustment",
+				Amount:        discrepancy.Difference,
+				Description:   fmt.Sprintf("Reconciliation adjustment for discrepancy %d", discrepancy.ID),
+				Status:        "confirmed",
+			}
+			if err := tx.Create(&transaction).Error; err != nil {
+				return err
+			}
+			if _, err := ledger.PostAdjustment(tx, discrepancy.PartnershipID, discrepancy.Difference, transaction.Description, &transaction.ID); err != nil {
+				return err
+			}
+			updates["adjustment_transaction_id"] = transaction.ID
+		}
+
+		if err := s.walletService.syncBalances(tx, discrepancy.PartnershipID); err != nil {
+			return err
+		}
+		return tx.Model(&discrepancy).Updates(updates).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.First(&discrepancy, id).Error; err != nil {
+		return nil, err
+	}
+	return &discrepancy, nil
+}
+
+// Dismiss closes an open discrepancy without correcting it. The same
+// difference is not reported again; a different one opens a new discrepancy.
+func (s *ReconciliationService) Dismiss(id, adminID uint) (*models.ReconciliationDiscrepancy, error) {
+	var discrepancy models.ReconciliationDiscrepancy
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&discrepancy, id).Error; err != nil {
+			return err
+		}
+		if discrepancy.Status != models.DiscrepancyOpen {
+			return ErrDiscrepancyClosed
+		}
+		return tx.Model(&discrepancy).Updates(map[string]interface{}{
+			"status":      models.DiscrepancyDismissed,
+			"reviewed_by": adminID,
+			"closed_at":   time.Now(),
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &discrepancy, nil
+}
+
+// check compares one partnership's balances with its ledger.
+func (s *ReconciliationService) check(ctx context.Context, partnership *models.Partnership) error {
+	expected, actual, err := s.balances(ctx, s.db, partnership, models.DiscrepancyWalletBalance)
+	if err != nil {
+		return err
+	}
+	if err := s.observe(partnership.ID, models.DiscrepancyWalletBalance, expected, actual); err != nil {
+		return err
+	}
+
+	if s.aaSharing == nil || partnership.ChainPartnershipID == nil {
+		return nil
+	}
+	expected, actual, err = s.balances(ctx, s.db, partnership, models.DiscrepancyChainBalance)
+	if err != nil {
+		return err
+	}
+	return s.observe(partnership.ID, models.DiscrepancyChainBalance, expected, actual)
+}
+
+// balances returns what the ledger says a partnership should hold and what
+// it actually holds for one kind of discrepancy: the stored WalletBalance,
+// or the totalBalance in the AASharing contract. The contract is expected to
+// hold the ledger balance plus the deposits awaiting confirmation and the
+// approved withdrawals not yet withdrawn on chain.
+func (s *ReconciliationService) balances(ctx context.Context, db *gorm.DB, partnership *models.Partnership, kind string) (expected, actual models.Money, err error) {
+	balance, err := ledger.PartnershipBalance(db, partnership.ID, models.DefaultCurrency)
+	if err != nil {
+		return expected, actual, err
+	}
+
+	if kind == models.DiscrepancyWalletBalance {
+		var wallet models.WalletBalance
+		err = db.Where("partnership_id = ?", partnership.ID).First(&wallet).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			wallet.Balance = models.NewMoney(0, balance.Code())
+		case err != nil:
+			return expected, actual, err
+		}
+		return balance, wallet.Balance, nil
+	}
+
+	if s.aaSharing == nil || partnership.ChainPartnershipID == nil {
+		return expected, actual, errors.New("partnership has no on-chain balance to compare")
+	}
+	onChain, err := s.aaSharing.Partnerships(&bind.CallOpts{Context: ctx}, new(big.Int).SetUint64(*partnership.ChainPartnershipID))
+	if err != nil {
+		return expected, actual, err
+	}
+	if !onChain.TotalBalance.IsInt64() {
+		return expected, actual, fmt.Errorf("on-chain balance %s out of range", onChain.TotalBalance)
+	}
+
+	// Indexed deposits waiting for confirmations are already on chain
+	var pendingUnits int64
+	if err := db.Model(&models.Transaction{}).
+		Where("partnership_id = ? AND status = ? AND log_index IS NOT NULL", partnership.ID, "pending").
+		Where("type IN ?", []string{"contribution", "gratitude"}).
+		Select("COALESCE(SUM(amount_units), 0)").
+		Scan(&pendingUnits).Error; err != nil {
+		return expected, actual, err
+	}
+	expected, err = balance.Add(models.NewMoney(pendingUnits, balance.Code()))
+	if err != nil {
+		return expected, actual, err
+	}
+	offChain, err := offChainWithdrawals(db, partnership.ID, balance.Code())
+	if err != nil {
+		return expected, actual, err
+	}
+	if expected, err = expected.Add(offChain); err != nil {
+		return expected, actual, err
+	}
+	return expected, models.USDC(onChain.TotalBalance.Int64()), nil
+}
+
+// offChainWithdrawals sums the approved withdrawal requests a partnership
+// has spent since its last on-chain withdrawal. The contract can only pay
+// out a partnership's whole balance, and only to a partner who asks for it,
+// so an approved request is taken from the ledger while its funds stay in
+// the contract until the partners next withdraw; that withdrawal empties
+// both.
+func offChainWithdrawals(db *gorm.DB, partnershipID uint, currency string) (models.Money, error) {
+	var lastChainWithdrawal uint
+	if err := db.Model(&models.Transaction{}).
+		Where("partnership_id = ? AND type = ? AND log_index IS NOT NULL", partnershipID, "split").
+		Select("COALESCE(MAX(id), 0)").
+		Scan(&lastChainWithdrawal).Error; err != nil {
+		return models.Money{}, err
+	}
+
+	var units int64
+	if err := db.Model(&models.Transaction{}).
+		Where("partnership_id = ? AND type = ? AND status = ? AND id > ?", partnershipID, "withdrawal", "confirmed", lastChainWithdrawal).
+		Where("amount_currency = ?", currency).
+		Select("COALESCE(SUM(amount_units), 0)").
+		Scan(&units).Error; err != nil {
+		return models.Money{}, err
+	}
+	return models.NewMoney(units, currency), nil
+}
+
+// observe records the outcome of one comparison. A difference updates the
+// open discrepancy of its kind or opens one; no difference resolves it.
+func (s *ReconciliationService) observe(partnershipID uint, kind string, expected, actual models.Money) error {
+	difference, err := actual.Sub(expected)
+	if err != nil {
+		return err
+	}
+
+	var latest models.ReconciliationDiscrepancy
+	err = s.db.Where("partnership_id = ? AND kind = ? AND status IN ?", partnershipID, kind,
+		[]string{models.DiscrepancyOpen, models.DiscrepancyDismissed}).
+		Order("id DESC").
+		First(&latest).Error
+	found := err == nil
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	open := found && latest.Status == models.DiscrepancyOpen
+
+	now := time.Now()
+	switch {
+	case difference.IsZero():
+		if !open {
+			return nil
+		}
+		return s.db.Model(&latest).Updates(map[string]interface{}{
+			"status":    models.DiscrepancyResolved,
+			"closed_at": now,
+		}).Error
+	case open:
+		return s.db.Model(&latest).Updates(map[string]interface{}{
+			"expected_units":   expected.Units,
+			"actual_units":     actual.Units,
+			"difference_units": difference.Units,
+			"severity":         severity(difference),
+			"last_seen_at":     now,
+		}).Error
+	case found && latest.Difference.Units == difference.Units:
+		// Dismissed by an admin
+		return nil
+	}
+
+	return s.db.Create(&models.ReconciliationDiscrepancy{
+		PartnershipID: partnershipID,
+		Kind:          kind,
+		Expected:      expected,
+		Actual:        actual,
+		Difference:    difference,
+		Severity:      severity(difference),
+		Status:        models.DiscrepancyOpen,
+		LastSeenAt:    now,
+	}).Error
+}
+
+// severity grades a difference on the scale of its currency's decimals.
+func severity(difference models.Money) string {
+	whole := int64(1)
+	for i := 0; i < difference.Decimals(); i++ {
+		whole *= 10
+	}
+	// Compared on both sides of zero, as the most negative amount has no
+	// positive counterpart
+	exceeds := func(amount int64) bool {
+		limit := amount * whole
+		return difference.Units >= limit || difference.Units <= -limit
+	}
+	switch {
+	case exceeds(highSeverityAmount):
+		return models.SeverityHigh
+	case exceeds(mediumSeverityAmount):
+		return models.SeverityMedium
+	}
+	return models.SeverityLow
+}
@@ -0,0 +1,158 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"aa-sharing-backend/internal/chaintest"
+	"aa-sharing-backend/internal/models"
+	"aa-sharing-backend/internal/testdb"
+)
+
+func TestSeverity(t *testing.T) {
+	tests := []struct {
+		difference models.Money
+		want       string
+	}{
+		{models.USDC(999_999), models.SeverityLow},
+		{models.USDC(-999_999), models.SeverityLow},
+		{models.USDC(1_000_000), models.SeverityMedium},
+		{models.USDC(-99_999_999), models.SeverityMedium},
+		{models.USDC(100_000_000), models.SeverityHigh},
+		{models.USDC(-100_000_000), models.SeverityHigh},
+		{models.USDC(math.MinInt64), models.SeverityHigh},
+	}
+	for _, tt := range tests {
+		if got := severity(tt.difference); got != tt.want {
+			t.Errorf("severity of %s %s is %s, want %s", tt.difference, tt.difference.Code(), got, tt.want)
+		}
+	}
+}
+
+func TestApproveRestoresMissingWallet(t *testing.T) {
+	db := testdb.Open(t)
+	wallet := NewWalletService(db)
+	s := NewReconciliationService(db, wallet, nil)
+	partnership := testdb.Partnership(t, db)
+	contribute(t, wallet, partnership, partnership.UserAID, models.USDC(4_000_000))
+
+	if err := db.Unscoped().Where("partnership_id = ?", partnership.ID).Delete(&models.WalletBalance{}).Error; err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if err := s.Reconcile(ctx); err != nil {
+		t.Fatal(err)
+	}
+	discrepancies, err := s.GetDiscrepancies(models.DiscrepancyOpen, "")
+	if err != nil || len(discrepancies) != 1 {
+		t.Fatalf("open discrepancies %v (%v), want the missing wallet", discrepancies, err)
+	}
+	if discrepancies[0].Difference.Units != -4_000_000 {
+		t.Fatalf("difference %s, want -4 USDC", discrepancies[0].Difference)
+	}
+
+	approved, err := s.Approve(ctx, discrepancies[0].ID, partnership.UserAID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if approved.Status != models.DiscrepancyApproved {
+		t.Errorf("discrepancy %s, want approved", approved.Status)
+	}
+	var restored models.WalletBalance
+	if err := db.Where("partnership_id = ?", partnership.ID).First(&restored).Error; err != nil {
+		t.Fatal(err)
+	}
+	if restored.Balance.Units != 4_000_000 {
+		t.Errorf("wallet balance %s, want the ledger's 4 USDC", restored.Balance)
+	}
+}
+
+func TestApproveClosesDiscrepancyThatWentAway(t *testing.T) {
+	db := testdb.Open(t)
+	wallet := NewWalletService(db)
+	s := NewReconciliationService(db, wallet, nil)
+	partnership := testdb.Partnership(t, db)
+	contribute(t, wallet, partnership, partnership.UserAID, models.USDC(4_000_000))
+
+	if err := db.Model(&models.WalletBalance{}).Where("partnership_id = ?", partnership.ID).
+		Update("balance_units", 3_000_000).Error; err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if err := s.Reconcile(ctx); err != nil {
+		t.Fatal(err)
+	}
+	discrepancies, _ := s.GetDiscrepancies(models.DiscrepancyOpen, "")
+	if len(discrepancies) != 1 {
+		t.Fatalf("%d open discrepancies, want 1", len(discrepancies))
+	}
+
+	// Fixed by someone else before the admin approves
+	if err := db.Model(&models.WalletBalance{}).Where("partnership_id = ?", partnership.ID).
+		Update("balance_units", 4_000_000).Error; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Approve(ctx, discrepancies[0].ID, partnership.UserAID); !errors.Is(err, ErrDiscrepancyClosed) {
+		t.Fatalf("approving a resolved discrepancy: got %v, want ErrDiscrepancyClosed", err)
+	}
+}
+
+func TestApprovedWithdrawalsMatchTheChain(t *testing.T) {
+	db := testdb.Open(t)
+	c := chaintest.New(t)
+	aa := c.DeployAASharing(t)
+	alice, bob := c.Account(t), c.Account(t)
+	chainID := aa.CreatePartnership(t, alice, bob)
+	aa.Fund(t, alice, 10_000_000)
+	c.Mined(t)(aa.DepositFunds(c.Transactor(t, alice), chainID, big.NewInt(10_000_000)))
+
+	wallet := NewWalletService(db)
+	s := NewReconciliationService(db, wallet, &aa.AASharingCaller)
+	partnership := testdb.Partnership(t, db)
+	linked := chainID.Uint64()
+	if err := db.Model(partnership).Update("chain_partnership_id", linked).Error; err != nil {
+		t.Fatal(err)
+	}
+	contribute(t, wallet, partnership, partnership.UserAID, models.USDC(10_000_000))
+
+	withdrawals := NewWithdrawalService(db, wallet, time.Hour)
+	request, err := withdrawals.RequestWithdrawal(partnership, partnership.UserAID, nil, models.USDC(4_000_000), "Groceries")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := withdrawals.ApproveWithdrawal(partnership, request.ID, partnership.UserBID); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	noDiscrepancies := func() {
+		t.Helper()
+		if err := s.Reconcile(ctx); err != nil {
+			t.Fatal(err)
+		}
+		open, err := s.GetDiscrepancies(models.DiscrepancyOpen, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, discrepancy := range open {
+			t.Errorf("open %s discrepancy of %s", discrepancy.Kind, discrepancy.Difference)
+		}
+	}
+	// The contract still holds the 4 USDC spent off-chain
+	noDiscrepancies()
+
+	// Withdrawing on chain empties the partnership in the contract and the
+	// ledger
+	receipt := c.Mined(t)(aa.Withdraw(c.Transactor(t, alice), chainID))
+	if err := wallet.RecordChainWithdrawal(partnership.ID, []SplitPayout{
+		{UserID: partnership.UserAID, Amount: models.USDC(5_000_000)},
+		{UserID: partnership.UserBID, Amount: models.USDC(5_000_000)},
+	}, receipt.TxHash.Hex(), 0); err != nil {
+		t.Fatal(err)
+	}
+	noDiscrepancies()
+}
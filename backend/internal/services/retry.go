@@ -0,0 +1,46 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+const (
+	maxTransactionAttempts = 5
+	retryBaseDelay         = 10 * time.Millisecond
+)
+
+// Postgres error codes that mean the transaction lost a race and can safely
+// be run again.
+const (
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+)
+
+// transactionWithRetry runs fn in a database transaction and retries it with
+// a growing delay when Postgres aborts it because of a serialization failure
+// or deadlock. fn must be safe to run more than once.
+func transactionWithRetry(db *gorm.DB, fn func(tx *gorm.DB) error) error {
+	var err error
+	for attempt := 0; attempt < maxTransactionAttempts; attempt++ {
+		if attempt > 0 {
+			time.Sleep(retryBaseDelay * time.Duration(1<<(attempt-1)))
+		}
+		err = db.Transaction(fn)
+		if !isRetryable(err) {
+			return err
+		}
+	}
+	return err
+}
+
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == pgSerializationFailure || pgErr.Code == pgDeadlockDetected
+}
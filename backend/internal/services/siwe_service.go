@@ -0,0 +1,159 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"time"
+
+	"aa-sharing-backend/internal/models"
+	"aa-sharing-backend/internal/siwe"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrInvalidNonce    = errors.New("nonce is invalid, expired or already used")
+	ErrDomainMismatch  = errors.New("SIWE message domain does not match")
+	ErrURIMismatch     = errors.New("SIWE message URI does not match")
+	ErrChainIDMismatch = errors.New("SIWE message chain ID does not match")
+	ErrWalletTaken     = errors.New("wallet is already linked to another user")
+)
+
+// pgUniqueViolation is the Postgres error code for a duplicate key.
+const pgUniqueViolation = "23505"
+
+// SIWEService implements the Sign-In With Ethereum login flow. It is the only
+// way a wallet address gets bound to a user, so that every stored address
+// has been proven by a signature.
+type SIWEService struct {
+	db       *gorm.DB
+	domain   string
+	uri      *url.URL
+	chainID  uint64
+	nonceTTL time.Duration
+}
+
+// NewSIWEService accepts messages for domain whose URI is on the same
+// origin as uri and whose chain ID is chainID.
+func NewSIWEService(db *gorm.DB, domain, uri string, chainID uint64, nonceTTL time.Duration) *SIWEService {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		parsed = &url.URL{}
+	}
+	return &SIWEService{db: db, domain: domain, uri: parsed, chainID: chainID, nonceTTL: nonceTTL}
+}
+
+// IssueNonce creates a new single-use nonce for a SIWE message.
+func (s *SIWEService) IssueNonce() (*models.AuthNonce, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+
+	nonce := models.AuthNonce{
+		Nonce:     hex.EncodeToString(buf),
+		ExpiresAt: time.Now().Add(s.nonceTTL),
+	}
+	if err := s.db.Create(&nonce).Error; err != nil {
+		return nil, err
+	}
+	return &nonce, nil
+}
+
+// Verify checks the signed SIWE message, consumes its nonce and returns the
+// user bound to the signing wallet.
+func (s *SIWEService) Verify(rawMessage, signature string) (*models.User, error) {
+	var user models.User
+	err := s.consume(rawMessage, signature, func(tx *gorm.DB, msg *siwe.Message) error {
+		return tx.Where("LOWER(wallet_address) = LOWER(?)", msg.Address.Hex()).First(&user).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Link checks the signed SIWE message, consumes its nonce and binds the
+// signing wallet to userID, replacing any wallet the user had before.
+func (s *SIWEService) Link(userID uint, rawMessage, signature string) (*models.User, error) {
+	var user models.User
+	err := s.consume(rawMessage, signature, func(tx *gorm.DB, msg *siwe.Message) error {
+		var owner models.User
+		err := tx.Where("LOWER(wallet_address) = LOWER(?)", msg.Address.Hex()).First(&owner).Error
+		switch {
+		case err == nil && owner.ID != userID:
+			return ErrWalletTaken
+		case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+			return err
+		}
+
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&user, userID).Error; err != nil {
+			return err
+		}
+		return tx.Model(&user).Update("wallet_address", msg.Address.Hex()).Error
+	})
+	// Two users linking the same wallet at once both pass the lookup; the
+	// unique index on wallet_address turns the loser away
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+		return nil, ErrWalletTaken
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// consume validates the message and its signature and then, in one database
+// transaction, marks its nonce used and calls fn.
+func (s *SIWEService) consume(rawMessage, signature string, fn func(tx *gorm.DB, msg *siwe.Message) error) error {
+	msg, err := siwe.ParseMessage(rawMessage)
+	if err != nil {
+		return err
+	}
+	if err := s.checkMessage(msg); err != nil {
+		return err
+	}
+	if err := msg.ValidAt(time.Now()); err != nil {
+		return err
+	}
+	if err := msg.VerifySignature(rawMessage, signature); err != nil {
+		return err
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&models.AuthNonce{}).
+			Where("nonce = ? AND used_at IS NULL AND expires_at > ?", msg.Nonce, now).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			return ErrInvalidNonce
+		}
+		return fn(tx, msg)
+	})
+}
+
+// checkMessage reports whether msg was written for this service: its domain,
+// the origin of its URI and its chain ID must all match the configuration,
+// so that a signature collected by another site or for another chain cannot
+// be replayed here.
+func (s *SIWEService) checkMessage(msg *siwe.Message) error {
+	if msg.Domain != s.domain {
+		return ErrDomainMismatch
+	}
+	uri, err := url.Parse(msg.URI)
+	if err != nil || uri.Scheme != s.uri.Scheme || uri.Host != s.uri.Host {
+		return ErrURIMismatch
+	}
+	if msg.ChainID != s.chainID {
+		return ErrChainIDMismatch
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package services
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"aa-sharing-backend/internal/models"
+	"aa-sharing-backend/internal/siwe"
+	"aa-sharing-backend/internal/testdb"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"gorm.io/gorm"
+)
+
+const (
+	testSIWEDomain  = "app.example.com"
+	testSIWEURI     = "https://app.example.com"
+	testSIWEChainID = 747
+)
+
+// siweLogin is a SIWE message and its personal_sign signature.
+type siweLogin struct {
+	message, signature string
+}
+
+// signSIWE writes a message for nonce the way the frontend does, with
+// override applied to its fields, and signs it with key.
+func signSIWE(t *testing.T, key *ecdsa.PrivateKey, nonce string, override map[string]string) siweLogin {
+	t.Helper()
+	fields := map[string]string{
+		"domain":   testSIWEDomain,
+		"uri":      testSIWEURI + "/login",
+		"chain ID": fmt.Sprint(testSIWEChainID),
+		"issued":   time.Now().UTC().Format(time.RFC3339),
+		"expires":  time.Now().Add(5 * time.Minute).UTC().Format(time.RFC3339),
+	}
+	for k, v := range override {
+		fields[k] = v
+	}
+	message := strings.Join([]string{
+		fields["domain"] + " wants you to sign in with your Ethereum account:",
+		crypto.PubkeyToAddress(key.PublicKey).Hex(),
+		"",
+		"Sign in to AA Sharing",
+		"",
+		"URI: " + fields["uri"],
+		"Version: 1",
+		"Chain ID: " + fields["chain ID"],
+		"Nonce: " + nonce,
+		"Issued At: " + fields["issued"],
+		"Expiration Time: " + fields["expires"],
+	}, "\n")
+
+	sig, err := crypto.Sign(accounts.TextHash([]byte(message)), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return siweLogin{message: message, signature: hexutil.Encode(sig)}
+}
+
+func newTestSIWEService(db *gorm.DB) *SIWEService {
+	return NewSIWEService(db, testSIWEDomain, testSIWEURI, testSIWEChainID, time.Minute)
+}
+
+func TestSIWECheckMessage(t *testing.T) {
+	s := newTestSIWEService(nil)
+	key, _ := crypto.GenerateKey()
+
+	tests := []struct {
+		name     string
+		override map[string]string
+		want     error
+	}{
+		{"matching", nil, nil},
+		{"another domain", map[string]string{"domain": "evil.example.com"}, ErrDomainMismatch},
+		{"another origin", map[string]string{"uri": "https://evil.example.com/login"}, ErrURIMismatch},
+		{"another scheme", map[string]string{"uri": "http://app.example.com/login"}, ErrURIMismatch},
+		{"another port", map[string]string{"uri": "https://app.example.com:8443/login"}, ErrURIMismatch},
+		{"another chain", map[string]string{"chain ID": "1"}, ErrChainIDMismatch},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			login := signSIWE(t, key, "0123456789abcdef", tt.override)
+			msg, err := siwe.ParseMessage(login.message)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := s.checkMessage(msg); !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSIWEVerify(t *testing.T) {
+	db := testdb.Open(t)
+	s := newTestSIWEService(db)
+	key, _ := crypto.GenerateKey()
+	user := testdb.User(t, db)
+	if err := db.Model(user).Update("wallet_address", strings.ToLower(crypto.PubkeyToAddress(key.PublicKey).Hex())).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	nonce, err := s.IssueNonce()
+	if err != nil {
+		t.Fatal(err)
+	}
+	login := signSIWE(t, key, nonce.Nonce, nil)
+	got, err := s.Verify(login.message, login.signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != user.ID {
+		t.Errorf("signed in as user %d, want %d", got.ID, user.ID)
+	}
+
+	if _, err := s.Verify(login.message, login.signature); !errors.Is(err, ErrInvalidNonce) {
+		t.Errorf("replayed login: got %v, want ErrInvalidNonce", err)
+	}
+
+	unknown, _ := crypto.GenerateKey()
+	nonce, _ = s.IssueNonce()
+	login = signSIWE(t, unknown, nonce.Nonce, nil)
+	if _, err := s.Verify(login.message, login.signature); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("unlinked wallet: got %v, want ErrRecordNotFound", err)
+	}
+}
+
+func TestSIWEVerifyRejectsForeignMessages(t *testing.T) {
+	db := testdb.Open(t)
+	s := newTestSIWEService(db)
+	key, _ := crypto.GenerateKey()
+	other, _ := crypto.GenerateKey()
+
+	tests := []struct {
+		name  string
+		login func(nonce string) siweLogin
+		want  error
+	}{
+		{"other chain", func(nonce string) siweLogin {
+			return signSIWE(t, key, nonce, map[string]string{"chain ID": "1"})
+		}, ErrChainIDMismatch},
+		{"other site", func(nonce string) siweLogin {
+			return signSIWE(t, key, nonce, map[string]string{"uri": "https://evil.example.com"})
+		}, ErrURIMismatch},
+		{"expired", func(nonce string) siweLogin {
+			return signSIWE(t, key, nonce, map[string]string{"expires": time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)})
+		}, siwe.ErrExpired},
+		{"forged signature", func(nonce string) siweLogin {
+			login := signSIWE(t, key, nonce, nil)
+			login.signature = signSIWE(t, other, nonce, nil).signature
+			return login
+		}, siwe.ErrInvalidSignature},
+		{"unknown nonce", func(string) siweLogin {
+			return signSIWE(t, key, "ffffffffffffffff", nil)
+		}, ErrInvalidNonce},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nonce, err := s.IssueNonce()
+			if err != nil {
+				t.Fatal(err)
+			}
+			login := tt.login(nonce.Nonce)
+			if _, err := s.Verify(login.message, login.signature); !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSIWELink(t *testing.T) {
+	db := testdb.Open(t)
+	s := newTestSIWEService(db)
+	key, _ := crypto.GenerateKey()
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	alice, bob := testdb.User(t, db), testdb.User(t, db)
+
+	nonce, _ := s.IssueNonce()
+	login := signSIWE(t, key, nonce.Nonce, nil)
+	linked, err := s.Link(alice.ID, login.message, login.signature)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if linked.WalletAddress != address.Hex() {
+		t.Errorf("linked wallet %q, want %q", linked.WalletAddress, address.Hex())
+	}
+
+	// Bob cannot take Alice's wallet even with a valid signature
+	nonce, _ = s.IssueNonce()
+	login = signSIWE(t, key, nonce.Nonce, nil)
+	if _, err := s.Link(bob.ID, login.message, login.signature); !errors.Is(err, ErrWalletTaken) {
+		t.Errorf("linking a taken wallet: got %v, want ErrWalletTaken", err)
+	}
+
+	// The index also holds when the lookup is bypassed, whatever the case
+	err = db.Model(&models.User{}).Where("id = ?", bob.ID).
+		Update("wallet_address", strings.ToLower(address.Hex())).Error
+	if err == nil {
+		t.Error("two users share a wallet address")
+	}
+
+	// Linking again is a no-op for the owner
+	nonce, _ = s.IssueNonce()
+	login = signSIWE(t, key, nonce.Nonce, nil)
+	if _, err := s.Link(alice.ID, login.message, login.signature); err != nil {
+		t.Errorf("relinking own wallet: %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package services
+
+import (
+	"aa-sharing-backend/internal/models"
+
+	"gorm.io/gorm"
+)
+
+// SubmissionService reads the state of the transactions the backend sends
+// through its transaction queues.
+type SubmissionService struct {
+	db *gorm.DB
+}
+
+func NewSubmissionService(db *gorm.DB) *SubmissionService {
+	return &SubmissionService{db: db}
+}
+
+// GetSubmissions returns the queued transactions, newest first. Empty filters
+// match everything.
+func (s *SubmissionService) GetSubmissions(status, sender string) ([]models.ChainSubmission, error) {
+	query := s.db
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	if sender != "" {
+		query = query.Where("LOWER(sender) = LOWER(?)", sender)
+	}
+
+	var submissions []models.ChainSubmission
+	if err := query.Order("created_at DESC").Find(&submissions).Error; err != nil {
+		return nil, err
+	}
+	return submissions, nil
+}
+
+func (s *SubmissionService) GetSubmission(id uint) (*models.ChainSubmission, error) {
+	var submission models.ChainSubmission
+	if err := s.db.First(&submission, id).Error; err != nil {
+		return nil, err
+	}
+	return &submission, nil
+}
+
+// GetSenderNonces returns the next nonce of every sending account.
+func (s *SubmissionService) GetSenderNonces() ([]models.SenderNonce, error) {
+	var nonces []models.SenderNonce
+	if err := s.db.Order("chain_id, sender").Find(&nonces).Error; err != nil {
+		return nil, err
+	}
+	return nonces, nil
+}
@@ -11,11 +11,12 @@ import (
 )
 
 type UserService struct {
-	db *gorm.DB
+	db        *gorm.DB
+	jwtSecret []byte
 }
 
-func NewUserService(db *gorm.DB) *UserService {
-	return &UserService{db: db}
+func NewUserService(db *gorm.DB, jwtSecret string) *UserService {
+	return &UserService{db: db, jwtSecret: []byte(jwtSecret)}
 }
 
 func (s *UserService) CreateUser(user *models.User) error {
@@ -49,7 +50,7 @@ func (s *UserService) GenerateJWT(userID uint) (string, error) {
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte("your-secret-key"))
+	return token.SignedString(s.jwtSecret)
 }
 
 func (s *UserService) ValidateJWT(tokenString string) (uint, error) {
@@ -57,7 +58,7 @@ func (s *UserService) ValidateJWT(tokenString string) (uint, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.New("invalid token")
 		}
-		return []byte("your-secret-key"), nil
+		return s.jwtSecret, nil
 	})
 
 	if err != nil {
@@ -65,9 +66,12 @@ func (s *UserService) ValidateJWT(tokenString string) (uint, error) {
 	}
 
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		userID := uint(claims["user_id"].(float64))
-		return userID, nil
+		userID, ok := claims["user_id"].(float64)
+		if !ok || userID <= 0 {
+			return 0, errors.New("invalid token")
+		}
+		return uint(userID), nil
 	}
 
 	return 0, errors.New("invalid token")
-}
\ No newline at end of file
+}
@@ -1,12 +1,40 @@
 package services
 
 import (
-	"aa-sharing-backend/internal/models"
+	"errors"
+	"fmt"
+	"sort"
 	"time"
 
+	"aa-sharing-backend/internal/ledger"
+	"aa-sharing-backend/internal/models"
+
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrNothingToSplit is returned when a split is requested on an empty wallet.
+var ErrNothingToSplit = errors.New("no funds to split")
+
+// ErrSplitChanged is returned when a split is confirmed for a balance other
+// than the partnership's current one.
+var ErrSplitChanged = errors.New("balance changed since the split was previewed")
+
+// splitConfirmationTTL is how long a partner's split confirmation waits for
+// the other partner's.
+const splitConfirmationTTL = 24 * time.Hour
+
+var (
+	ErrInsufficientFunds  = errors.New("not enough unallocated funds")
+	ErrGoalNotActive      = errors.New("goal is not active")
+	ErrGoalMismatch       = errors.New("goals must belong to the partnership and differ")
+	ErrInvalidGoalStatus  = errors.New("goal status must be active, completed or cancelled")
+	ErrNegativeGoalAmount = errors.New("goal amount cannot be negative")
+)
+
+// goalStatuses are the statuses a goal can be given.
+var goalStatuses = map[string]bool{"active": true, "completed": true, "cancelled": true}
+
 type WalletService struct {
 	db *gorm.DB
 }
@@ -15,6 +43,8 @@ func NewWalletService(db *gorm.DB) *WalletService {
 	return &WalletService{db: db}
 }
 
+// GetWalletBalance returns the partnership's wallet with its balance derived
+// from the ledger.
 func (s *WalletService) GetWalletBalance(partnershipID uint) (*models.WalletBalance, error) {
 	var balance models.WalletBalance
 	if err := s.db.Where("partnership_id = ?", partnershipID).
@@ -24,7 +54,7 @@ func (s *WalletService) GetWalletBalance(partnershipID uint) (*models.WalletBala
 			// Create new wallet balance if not exists
 			balance = models.WalletBalance{
 				PartnershipID: partnershipID,
-				Balance:       0,
+				Balance:       models.NewMoney(0, models.DefaultCurrency),
 				LastUpdated:   time.Now(),
 			}
 			if err := s.db.Create(&balance).Error; err != nil {
@@ -34,46 +64,305 @@ func (s *WalletService) GetWalletBalance(partnershipID uint) (*models.WalletBala
 			return nil, err
 		}
 	}
+
+	derived, err := ledger.PartnershipBalance(s.db, partnershipID, balance.Balance.Code())
+	if err != nil {
+		return nil, err
+	}
+	balance.Balance = derived
 	return &balance, nil
 }
 
-func (s *WalletService) UpdateBalance(partnershipID uint, amount float64) error {
-	return s.db.Transaction(func(tx *gorm.DB) error {
-		var balance models.WalletBalance
-		if err := tx.Where("partnership_id = ?", partnershipID).First(&balance).Error; err != nil {
-			if err == gorm.ErrRecordNotFound {
-				balance = models.WalletBalance{
-					PartnershipID: partnershipID,
-					Balance:       amount,
-					LastUpdated:   time.Now(),
-				}
-				return tx.Create(&balance).Error
-			}
-			return err
-		}
+// GetLedger returns the partnership's ledger accounts with their balances and
+// the journal entries that produced them.
+func (s *WalletService) GetLedger(partnershipID uint) ([]ledger.AccountBalance, []models.JournalEntry, error) {
+	accounts, err := ledger.AccountBalances(s.db, partnershipID)
+	if err != nil {
+		return nil, nil, err
+	}
+	entries, err := ledger.Entries(s.db, partnershipID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return accounts, entries, nil
+}
 
-		balance.Balance += amount
-		balance.LastUpdated = time.Now()
-		return tx.Save(&balance).Error
+// UpdateBalance adds amount to the partnership's wallet balance.
+func (s *WalletService) UpdateBalance(partnershipID uint, amount models.Money) error {
+	return transactionWithRetry(s.db, func(tx *gorm.DB) error {
+		return s.updateBalance(tx, partnershipID, amount)
 	})
 }
 
+// updateBalance adds amount to the wallet balance within tx. The row is
+// locked for the rest of the transaction so that concurrent updates are
+// applied one after another instead of overwriting each other.
+func (s *WalletService) updateBalance(tx *gorm.DB, partnershipID uint, amount models.Money) error {
+	balance, err := s.lockWallet(tx, partnershipID, amount.Code())
+	if err != nil {
+		return err
+	}
+
+	newBalance, err := balance.Balance.Add(amount)
+	if err != nil {
+		return err
+	}
+	return tx.Model(&balance).Updates(map[string]interface{}{
+		"balance_units": newBalance.Units,
+		"last_updated":  time.Now(),
+	}).Error
+}
+
+// lockWallet locks the partnership's wallet row for the rest of tx, creating
+// it first if needed. Every change to the partnership's holdings takes this
+// lock, so it also serializes allocations to goals.
+func (s *WalletService) lockWallet(tx *gorm.DB, partnershipID uint, currency string) (*models.WalletBalance, error) {
+	// Make sure the row exists without racing another first contribution
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "partnership_id"}},
+		DoNothing: true,
+	}).Create(&models.WalletBalance{
+		PartnershipID: partnershipID,
+		Balance:       models.NewMoney(0, currency),
+		LastUpdated:   time.Now(),
+	}).Error; err != nil {
+		return nil, err
+	}
+
+	var balance models.WalletBalance
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("partnership_id = ?", partnershipID).
+		First(&balance).Error; err != nil {
+		return nil, err
+	}
+	return &balance, nil
+}
+
+// CreateTransaction records a transaction and, for confirmed money coming
+// into the partnership, posts it to the ledger and updates the wallet balance
+// in the same database transaction. Pending transactions are posted when they
+// are confirmed.
 func (s *WalletService) CreateTransaction(transaction *models.Transaction) error {
-	return s.db.Transaction(func(tx *gorm.DB) error {
+	return transactionWithRetry(s.db, func(tx *gorm.DB) error {
+		// A previous attempt may have assigned an ID before rolling back
+		transaction.ID = 0
+
 		// Create transaction record
 		if err := tx.Create(transaction).Error; err != nil {
 			return err
 		}
 
-		// Update wallet balance
-		if transaction.Type == "gratitude" || transaction.Type == "contribution" {
-			return s.UpdateBalance(transaction.PartnershipID, transaction.Amount)
+		if transaction.Status != "confirmed" {
+			return nil
+		}
+		return s.postTransaction(tx, transaction)
+	})
+}
+
+// postTransaction records a confirmed incoming transaction in the ledger and
+// updates the wallet balance. A transaction targeting a goal is allocated to
+// it in the same database transaction.
+func (s *WalletService) postTransaction(tx *gorm.DB, transaction *models.Transaction) error {
+	if transaction.Type != "gratitude" && transaction.Type != "contribution" {
+		return nil
+	}
+	if _, err := ledger.PostTransaction(tx, transaction); err != nil {
+		return err
+	}
+	if err := s.updateBalance(tx, transaction.PartnershipID, transaction.Amount); err != nil {
+		return err
+	}
+	if transaction.GoalID == nil {
+		return nil
+	}
+	return s.allocateContribution(tx, transaction)
+}
+
+// allocateContribution allocates a confirmed contribution to its goal. By the
+// time a pending contribution is confirmed its goal may have been cancelled or
+// deleted; the money then stays in the pool. The allocation is also capped at
+// the pool balance, which an adjustment may have left short.
+func (s *WalletService) allocateContribution(tx *gorm.DB, transaction *models.Transaction) error {
+	var goal models.Goal
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND partnership_id = ?", *transaction.GoalID, transaction.PartnershipID).
+		First(&goal).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if goal.Status == "cancelled" || goal.TargetAmount.Code() != transaction.Amount.Code() {
+		return nil
+	}
+
+	pool, err := ledger.Balance(tx, transaction.PartnershipID, transaction.Amount.Code(), ledger.AccountPool)
+	if err != nil {
+		return err
+	}
+	amount := transaction.Amount
+	if pool.Units < amount.Units {
+		amount = pool
+	}
+	if !amount.IsPositive() {
+		return nil
+	}
+
+	if _, err := ledger.PostGoalAllocation(tx, &goal, amount, &transaction.ID); err != nil {
+		return err
+	}
+	return s.refreshGoal(tx, &goal)
+}
+
+// refreshGoal sets a goal's current amount to its ledger balance. An active
+// goal that has reached its target is completed. A completed goal stays
+// completed when its savings are spent or moved; only an explicit status
+// update reopens it.
+func (s *WalletService) refreshGoal(tx *gorm.DB, goal *models.Goal) error {
+	current, err := ledger.GoalBalance(tx, goal)
+	if err != nil {
+		return err
+	}
+
+	status := goal.Status
+	if status == "active" && current.Units >= goal.TargetAmount.Units {
+		status = "completed"
+	}
+	if err := tx.Model(goal).Updates(map[string]interface{}{
+		"current_amount_units": current.Units,
+		"status":               status,
+	}).Error; err != nil {
+		return err
+	}
+	return s.reachMilestones(tx, goal, current)
+}
+
+// reachMilestones marks the goal's milestones at or below current as reached
+// and adds them to the activity feed. Claiming a milestone is a single
+// conditional update, so when contributions race past it only the one whose
+// update flips reached_at announces it.
+func (s *WalletService) reachMilestones(tx *gorm.DB, goal *models.Goal, current models.Money) error {
+	var reached []models.GoalMilestone
+	if err := tx.Model(&reached).
+		Clauses(clause.Returning{}).
+		Where("goal_id = ? AND reached_at IS NULL AND amount_currency = ? AND amount_units <= ?",
+			goal.ID, current.Code(), current.Units).
+		Update("reached_at", time.Now()).Error; err != nil {
+		return err
+	}
+	sort.Slice(reached, func(i, j int) bool { return reached[i].Amount.Units < reached[j].Amount.Units })
+
+	for i := range reached {
+		milestone := &reached[i]
+		message := fmt.Sprintf("%s reached %s %s", goal.Name, milestone.Amount, milestone.Amount.Code())
+		if milestone.Percent != nil {
+			message = fmt.Sprintf("%s reached %d%% of its target (%s %s)", goal.Name, *milestone.Percent, milestone.Amount, milestone.Amount.Code())
+		}
+		if err := tx.Create(&models.ActivityEvent{
+			PartnershipID: goal.PartnershipID,
+			Kind:          models.ActivityGoalMilestone,
+			GoalID:        &goal.ID,
+			MilestoneID:   &milestone.ID,
+			Message:       message,
+		}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConfirmChainTransactions marks the pending transactions recorded for a
+// contract log as confirmed, posting incoming funds to the ledger.
+func (s *WalletService) ConfirmChainTransactions(txHash string, logIndex uint) error {
+	return transactionWithRetry(s.db, func(tx *gorm.DB) error {
+		var transactions []models.Transaction
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("tx_hash = ? AND log_index = ? AND status = ?", txHash, logIndex, "pending").
+			Find(&transactions).Error; err != nil {
+			return err
 		}
 
+		for i := range transactions {
+			transaction := &transactions[i]
+			if err := tx.Model(transaction).Update("status", "confirmed").Error; err != nil {
+				return err
+			}
+			if err := s.postTransaction(tx, transaction); err != nil {
+				return err
+			}
+		}
 		return nil
 	})
 }
 
+// AttachChainLog ties a pending transaction that has no contract log yet,
+// such as a relayed contribution, to the log that made it. It returns false
+// if the transaction is already tied to a log.
+func (s *WalletService) AttachChainLog(transactionID uint, txHash string, logIndex uint) (bool, error) {
+	result := s.db.Model(&models.Transaction{}).
+		Where("id = ? AND log_index IS NULL AND status = ?", transactionID, "pending").
+		Updates(map[string]interface{}{"tx_hash": txHash, "log_index": logIndex})
+	return result.RowsAffected > 0, result.Error
+}
+
+// RevertChainTransactions undoes the transactions recorded for a contract log
+// whose block is no longer part of the chain. Anything already posted to the
+// ledger is reversed and the transactions are marked failed. They also drop
+// their log index so the log can be indexed again if it is re-included.
+func (s *WalletService) RevertChainTransactions(txHash string, logIndex uint) error {
+	return transactionWithRetry(s.db, func(tx *gorm.DB) error {
+		var transactions []models.Transaction
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("tx_hash = ? AND log_index = ?", txHash, logIndex).
+			Find(&transactions).Error; err != nil {
+			return err
+		}
+		if len(transactions) == 0 {
+			return nil
+		}
+
+		for _, transaction := range transactions {
+			if err := ledger.ReverseTransaction(tx, transaction.ID, "Reverted by chain reorganization"); err != nil {
+				return err
+			}
+			if err := tx.Model(&transaction).Updates(map[string]interface{}{
+				"status":      "failed",
+				"description": transaction.Description + " (reverted by chain reorganization)",
+				"log_index":   nil,
+			}).Error; err != nil {
+				return err
+			}
+		}
+		return s.syncBalances(tx, transactions[0].PartnershipID)
+	})
+}
+
+// syncBalances sets the wallet balance and the goals' current amounts of a
+// partnership to their ledger balances.
+func (s *WalletService) syncBalances(tx *gorm.DB, partnershipID uint) error {
+	balance, err := ledger.PartnershipBalance(tx, partnershipID, models.DefaultCurrency)
+	if err != nil {
+		return err
+	}
+	if err := tx.Model(&models.WalletBalance{}).
+		Where("partnership_id = ?", partnershipID).
+		Updates(map[string]interface{}{"balance_units": balance.Units, "last_updated": time.Now()}).Error; err != nil {
+		return err
+	}
+
+	var goals []models.Goal
+	if err := tx.Where("partnership_id = ?", partnershipID).Find(&goals).Error; err != nil {
+		return err
+	}
+	for i := range goals {
+		if err := s.refreshGoal(tx, &goals[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *WalletService) GetTransactions(partnershipID uint) ([]models.Transaction, error) {
 	var transactions []models.Transaction
 	if err := s.db.Where("partnership_id = ?", partnershipID).
@@ -86,7 +375,19 @@ func (s *WalletService) GetTransactions(partnershipID uint) ([]models.Transactio
 	return transactions, nil
 }
 
-func (s *WalletService) CreateGoal(goal *models.Goal) error {
+// CreateGoal creates a goal with milestones at the given amounts, or at the
+// DefaultMilestonePercents of its target when none are given.
+func (s *WalletService) CreateGoal(goal *models.Goal, milestones []models.Money) error {
+	goal.Milestones = nil
+	if len(milestones) == 0 {
+		goal.Milestones = goal.DefaultMilestones()
+	}
+	for _, amount := range milestones {
+		goal.Milestones = append(goal.Milestones, models.GoalMilestone{Amount: amount})
+	}
+	sort.Slice(goal.Milestones, func(i, j int) bool {
+		return goal.Milestones[i].Amount.Units < goal.Milestones[j].Amount.Units
+	})
 	return s.db.Create(goal).Error
 }
 
@@ -94,6 +395,7 @@ func (s *WalletService) GetGoals(partnershipID uint) ([]models.Goal, error) {
 	var goals []models.Goal
 	if err := s.db.Where("partnership_id = ?", partnershipID).
 		Preload("Partnership").
+		Preload("Milestones", func(db *gorm.DB) *gorm.DB { return db.Order("amount_units") }).
 		Order("created_at DESC").
 		Find(&goals).Error; err != nil {
 		return nil, err
@@ -101,61 +403,511 @@ func (s *WalletService) GetGoals(partnershipID uint) ([]models.Goal, error) {
 	return goals, nil
 }
 
+// GetGoalProgress returns a partnership's goals with their savings plans,
+// projected from the contributions confirmed over the last rateWindow.
+func (s *WalletService) GetGoalProgress(partnershipID uint) ([]GoalProgress, error) {
+	goals, err := s.GetGoals(partnershipID)
+	if err != nil || len(goals) == 0 {
+		return nil, err
+	}
+
+	now := time.Now()
+	from := now.Add(-rateWindow)
+	if created := goals[0].Partnership.CreatedAt; created.After(from) {
+		from = created
+	}
+
+	var totals []struct {
+		AmountCurrency string
+		Total          int64
+	}
+	if err := s.db.Model(&models.Transaction{}).
+		Select("amount_currency, COALESCE(SUM(amount_units), 0) AS total").
+		Where("partnership_id = ? AND status = ? AND type IN ? AND created_at >= ?",
+			partnershipID, "confirmed", []string{"contribution", "gratitude"}, from).
+		Group("amount_currency").
+		Scan(&totals).Error; err != nil {
+		return nil, err
+	}
+	rates := map[string]float64{}
+	for _, t := range totals {
+		rates[t.AmountCurrency] = contributionRate(t.Total, from, now)
+	}
+
+	progress := make([]GoalProgress, len(goals))
+	for i := range goals {
+		progress[i] = GoalProgress{
+			Goal: goals[i],
+			Plan: planGoal(&goals[i], rates[goals[i].TargetAmount.Code()], now),
+		}
+	}
+	return progress, nil
+}
+
+func (s *WalletService) GetGoal(id uint) (*models.Goal, error) {
+	var goal models.Goal
+	if err := s.db.First(&goal, id).Error; err != nil {
+		return nil, err
+	}
+	return &goal, nil
+}
+
+// UpdateGoal applies updates to a goal. A change of current_amount_units is
+// posted to the ledger as an allocation between the pool and the goal and,
+// like any allocation, may not take more than the pool holds. Unless the
+// status is set explicitly, it follows the goal's progress towards its target.
+// The goal's currency cannot be changed: its ledger account is kept in it.
+// current_amount_units cannot be negative, so a goal never holds less than
+// nothing and the pool is never credited with money that was not deposited.
 func (s *WalletService) UpdateGoal(id uint, updates map[string]interface{}) error {
-	return s.db.Model(&models.Goal{}).Where("id = ?", id).Updates(updates).Error
+	if status, ok := updates["status"]; ok {
+		if status, _ := status.(string); !goalStatuses[status] {
+			return ErrInvalidGoalStatus
+		}
+	}
+	if units, ok := updates["current_amount_units"].(int64); ok && units < 0 {
+		return ErrNegativeGoalAmount
+	}
+
+	return transactionWithRetry(s.db, func(tx *gorm.DB) error {
+		var goal models.Goal
+		if err := tx.First(&goal, id).Error; err != nil {
+			return err
+		}
+		for _, column := range []string{"target_amount_currency", "current_amount_currency"} {
+			if currency, ok := updates[column]; ok && currency != goal.TargetAmount.Code() {
+				return fmt.Errorf("%w: the goal is in %s", models.ErrCurrencyMismatch, goal.TargetAmount.Code())
+			}
+		}
+
+		if units, ok := updates["current_amount_units"].(int64); ok {
+			if _, err := s.lockWallet(tx, goal.PartnershipID, goal.TargetAmount.Code()); err != nil {
+				return err
+			}
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&goal, id).Error; err != nil {
+				return err
+			}
+			current, err := ledger.GoalBalance(tx, &goal)
+			if err != nil {
+				return err
+			}
+			delta, err := models.NewMoney(units, current.Code()).Sub(current)
+			if err != nil {
+				return err
+			}
+			if err := s.checkPool(tx, goal.PartnershipID, delta); err != nil {
+				return err
+			}
+			if _, err := ledger.PostGoalAllocation(tx, &goal, delta, nil); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&goal).Updates(updates).Error; err != nil {
+			return err
+		}
+		if _, ok := updates["target_amount_units"]; ok {
+			// Percentage milestones not yet reached move with the target
+			if err := tx.Model(&models.GoalMilestone{}).
+				Where("goal_id = ? AND percent IS NOT NULL AND reached_at IS NULL", goal.ID).
+				Updates(map[string]interface{}{
+					"amount_units":    gorm.Expr("? * percent / 100", goal.TargetAmount.Units),
+					"amount_currency": goal.TargetAmount.Code(),
+				}).Error; err != nil {
+				return err
+			}
+		}
+		if _, ok := updates["status"]; ok {
+			return s.reachMilestones(tx, &goal, goal.CurrentAmount)
+		}
+		return s.refreshGoal(tx, &goal)
+	})
 }
 
-func (s *WalletService) SplitFunds(partnershipID uint) error {
-	return s.db.Transaction(func(tx *gorm.DB) error {
-		// Get current balance
-		var balance models.WalletBalance
-		if err := tx.Where("partnership_id = ?", partnershipID).First(&balance).Error; err != nil {
+// ReallocateFunds moves amount between the pool and a partnership's goals. A
+// nil fromGoalID takes the money from the pool and a nil toGoalID returns it
+// there. The source must hold at least amount, so goals can never be
+// allocated more than the partnership holds. It returns the goals involved.
+func (s *WalletService) ReallocateFunds(partnershipID uint, fromGoalID, toGoalID *uint, amount models.Money) ([]models.Goal, error) {
+	if fromGoalID == nil && toGoalID == nil ||
+		fromGoalID != nil && toGoalID != nil && *fromGoalID == *toGoalID {
+		return nil, ErrGoalMismatch
+	}
+
+	var goals []models.Goal
+	err := transactionWithRetry(s.db, func(tx *gorm.DB) error {
+		goals = nil
+		if _, err := s.lockWallet(tx, partnershipID, amount.Code()); err != nil {
 			return err
 		}
 
-		// Split amount
-		splitAmount := balance.Balance / 2
+		var from, to *models.Goal
+		for _, id := range []*uint{fromGoalID, toGoalID} {
+			if id == nil {
+				continue
+			}
+			var goal models.Goal
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&goal, *id).Error; err != nil {
+				return err
+			}
+			if goal.PartnershipID != partnershipID {
+				return ErrGoalMismatch
+			}
+			if goal.TargetAmount.Code() != amount.Code() {
+				return models.ErrCurrencyMismatch
+			}
+			goals = append(goals, goal)
+		}
+		if fromGoalID != nil {
+			from = &goals[0]
+		}
+		if toGoalID != nil {
+			to = &goals[len(goals)-1]
+			if to.Status != "active" {
+				return ErrGoalNotActive
+			}
+		}
+
+		switch {
+		case from == nil:
+			if err := s.checkPool(tx, partnershipID, amount); err != nil {
+				return err
+			}
+			if _, err := ledger.PostGoalAllocation(tx, to, amount, nil); err != nil {
+				return err
+			}
+		default:
+			available, err := ledger.GoalBalance(tx, from)
+			if err != nil {
+				return err
+			}
+			if available.Units < amount.Units {
+				return ErrInsufficientFunds
+			}
+			if to == nil {
+				_, err = ledger.PostGoalAllocation(tx, from, amount.Neg(), nil)
+			} else {
+				_, err = ledger.PostGoalTransfer(tx, from, to, amount)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		for i := range goals {
+			if err := s.refreshGoal(tx, &goals[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return goals, nil
+}
+
+// checkPool returns ErrInsufficientFunds if allocating amount to goals would
+// take more than the partnership's pool holds. Releasing money is always
+// allowed.
+func (s *WalletService) checkPool(tx *gorm.DB, partnershipID uint, amount models.Money) error {
+	if !amount.IsPositive() {
+		return nil
+	}
+	pool, err := ledger.Balance(tx, partnershipID, amount.Code(), ledger.AccountPool)
+	if err != nil {
+		return err
+	}
+	if pool.Units < amount.Units {
+		return fmt.Errorf("%w: %s available", ErrInsufficientFunds, pool)
+	}
+	return nil
+}
+
+// SplitPayout is the amount one partner receives from a split.
+type SplitPayout struct {
+	UserID uint         `json:"user_id"`
+	Amount models.Money `json:"amount"`
+}
+
+// SplitPreview describes the outcome of splitting a partnership's balance.
+// An odd base unit cannot be halved and is paid to UserA, the partner who
+// created the partnership.
+type SplitPreview struct {
+	PartnershipID        uint          `json:"partnership_id"`
+	Balance              models.Money  `json:"balance"`
+	Payouts              []SplitPayout `json:"payouts"`
+	Remainder            models.Money  `json:"remainder"`
+	RemainderRecipientID uint          `json:"remainder_recipient_id"`
+	ResultingStatus      string        `json:"resulting_status"`
+	ConfirmedBy          []uint        `json:"confirmed_by"`
+	Executed             bool          `json:"executed"`
+
+	holdings []ledger.AccountBalance
+}
+
+// PreviewSplit computes what SplitFunds would pay out without writing anything.
+func (s *WalletService) PreviewSplit(partnershipID uint) (*SplitPreview, error) {
+	return s.previewSplit(s.db, partnershipID)
+}
 
-		// Get partnership details
+// ConfirmSplit records userID's confirmation of splitting balance, the
+// amount the partner was shown by PreviewSplit. Once both partners have
+// confirmed the same balance, the funds are split in the same transaction.
+// The wallet row is locked like for every change to the holdings, so the
+// balance cannot move between the check and the split.
+func (s *WalletService) ConfirmSplit(partnershipID, userID uint, balance models.Money) (*SplitPreview, error) {
+	var preview *SplitPreview
+	err := transactionWithRetry(s.db, func(tx *gorm.DB) error {
 		var partnership models.Partnership
-		if err := tx.Preload("UserA").Preload("UserB").First(&partnership, partnershipID).Error; err != nil {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&partnership, partnershipID).Error; err != nil {
+			return err
+		}
+		if !partnership.HasPartner(userID) {
+			return ErrNotPartner
+		}
+		if _, err := s.lockWallet(tx, partnershipID, models.DefaultCurrency); err != nil {
 			return err
 		}
 
-		// Create split transactions
-		transactions := []models.Transaction{
-			{
-				UserID:        partnership.UserAID,
-				PartnershipID: partnershipID,
-				Type:          "split",
-				Amount:        splitAmount,
-				Description:   "Balance split",
-				Status:        "confirmed",
-			},
-			{
-				UserID:        partnership.UserBID,
-				PartnershipID: partnershipID,
-				Type:          "split",
-				Amount:        splitAmount,
-				Description:   "Balance split",
-				Status:        "confirmed",
-			},
+		current, err := s.previewSplit(tx, partnershipID)
+		if err != nil {
+			return err
+		}
+		if current.Balance.Units != balance.Units || current.Balance.Code() != balance.Code() {
+			return fmt.Errorf("%w: %s %s to split now", ErrSplitChanged, current.Balance, current.Balance.Code())
 		}
 
-		for _, transaction := range transactions {
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "partnership_id"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"balance_units", "balance_currency", "expires_at", "created_at"}),
+		}).Create(&models.SplitConfirmation{
+			PartnershipID: partnershipID,
+			UserID:        userID,
+			Balance:       balance,
+			ExpiresAt:     time.Now().Add(splitConfirmationTTL),
+		}).Error; err != nil {
+			return err
+		}
+
+		if preview, err = s.previewSplit(tx, partnershipID); err != nil {
+			return err
+		}
+		if len(preview.ConfirmedBy) < 2 {
+			return nil
+		}
+
+		if err := s.splitFunds(tx, &partnership, preview); err != nil {
+			return err
+		}
+		return tx.Where("partnership_id = ?", partnershipID).Delete(&models.SplitConfirmation{}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return preview, nil
+}
+
+func (s *WalletService) previewSplit(db *gorm.DB, partnershipID uint) (*SplitPreview, error) {
+	var partnership models.Partnership
+	if err := db.First(&partnership, partnershipID).Error; err != nil {
+		return nil, err
+	}
+	if partnership.Status != models.PartnershipActive && partnership.Status != models.PartnershipInactive {
+		return nil, ErrInvalidStatus
+	}
+
+	accounts, err := ledger.AccountBalances(db, partnershipID)
+	if err != nil {
+		return nil, err
+	}
+	balance := models.NewMoney(0, models.DefaultCurrency)
+	var holdings []ledger.AccountBalance
+	for _, account := range accounts {
+		if account.Currency != balance.Code() || (account.Type != ledger.AccountPool && account.Type != ledger.AccountGoal) {
+			continue
+		}
+		holdings = append(holdings, account)
+		if balance, err = balance.Add(account.Balance); err != nil {
+			return nil, err
+		}
+	}
+	if !balance.IsPositive() {
+		return nil, ErrNothingToSplit
+	}
+
+	// Confirmations of another balance or that have expired no longer count
+	var confirmedBy []uint
+	if err := db.Model(&models.SplitConfirmation{}).
+		Where("partnership_id = ? AND balance_units = ? AND balance_currency = ? AND expires_at > ?",
+			partnershipID, balance.Units, balance.Code(), time.Now()).
+		Order("created_at").
+		Pluck("user_id", &confirmedBy).Error; err != nil {
+		return nil, err
+	}
+
+	half, remainder := balance.Halve()
+	shareA, err := half.Add(remainder)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SplitPreview{
+		PartnershipID: partnershipID,
+		Balance:       balance,
+		Payouts: []SplitPayout{
+			{UserID: partnership.UserAID, Amount: shareA},
+			{UserID: partnership.UserBID, Amount: half},
+		},
+		Remainder:            remainder,
+		RemainderRecipientID: partnership.UserAID,
+		ResultingStatus:      models.PartnershipSplit,
+		ConfirmedBy:          confirmedBy,
+		holdings:             holdings,
+	}, nil
+}
+
+func (s *WalletService) splitFunds(tx *gorm.DB, partnership *models.Partnership, preview *SplitPreview) error {
+	// Create split transactions
+	for _, payout := range preview.Payouts {
+		description := "Balance split"
+		if payout.UserID == preview.RemainderRecipientID && !preview.Remainder.IsZero() {
+			description = fmt.Sprintf("Balance split (includes %s %s remainder)", preview.Remainder, preview.Remainder.Code())
+		}
+
+		transaction := models.Transaction{
+			UserID:        payout.UserID,
+			PartnershipID: partnership.ID,
+			Type:          "split",
+			Amount:        payout.Amount,
+			Description:   description,
+			Status:        "confirmed",
+		}
+		if err := tx.Create(&transaction).Error; err != nil {
+			return err
+		}
+	}
+
+	// Drain the pool and every goal into the partners' accounts
+	var postings []ledger.Posting
+	for _, holding := range preview.holdings {
+		postings = append(postings, ledger.Posting{
+			AccountType: holding.Type,
+			GoalID:      holding.GoalID,
+			Amount:      holding.Balance.Neg(),
+		})
+	}
+	for _, payout := range preview.Payouts {
+		postings = append(postings, ledger.Partner(payout.UserID, payout.Amount))
+	}
+	if _, err := ledger.Post(tx, partnership.ID, ledger.EntrySplit, "Balance split", nil, postings...); err != nil {
+		return err
+	}
+
+	// Goals can no longer be reached once their funds are paid out
+	if err := tx.Model(&models.Goal{}).
+		Where("partnership_id = ?", partnership.ID).
+		Updates(map[string]interface{}{"current_amount_units": 0}).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&models.Goal{}).
+		Where("partnership_id = ? AND status = ?", partnership.ID, "active").
+		Update("status", "cancelled").Error; err != nil {
+		return err
+	}
+
+	// Reset balance
+	if err := tx.Model(&models.WalletBalance{}).
+		Where("partnership_id = ?", partnership.ID).
+		Updates(map[string]interface{}{"balance_units": 0, "last_updated": time.Now()}).Error; err != nil {
+		return err
+	}
+
+	// Update partnership status
+	if err := tx.Model(partnership).Update("status", models.PartnershipSplit).Error; err != nil {
+		return err
+	}
+
+	preview.Executed = true
+	return nil
+}
+
+// RecordChainWithdrawal mirrors an AASharing withdraw() call, which pays
+// amountEach to both partners and empties the partnership on chain. The
+// whole DB balance is drained; any difference to the amount paid out (such
+// as the odd unit the contract cannot halve) goes to the external account.
+// The payouts stay pending until ConfirmChainTransactions, but the funds are
+// taken out of the balance straight away so they cannot be spent twice.
+// Calling it again for the same log is a no-op.
+func (s *WalletService) RecordChainWithdrawal(partnershipID uint, payouts []SplitPayout, txHash string, logIndex uint) error {
+	return transactionWithRetry(s.db, func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.Transaction{}).
+			Where("tx_hash = ? AND log_index = ?", txHash, logIndex).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+
+		accounts, err := ledger.AccountBalances(tx, partnershipID)
+		if err != nil {
+			return err
+		}
+
+		var postings []ledger.Posting
+		external := models.NewMoney(0, models.DefaultCurrency)
+		for _, account := range accounts {
+			if account.Type != ledger.AccountPool && account.Type != ledger.AccountGoal {
+				continue
+			}
+			postings = append(postings, ledger.Posting{
+				AccountType: account.Type,
+				GoalID:      account.GoalID,
+				Amount:      account.Balance.Neg(),
+			})
+			if external, err = external.Add(account.Balance); err != nil {
+				return err
+			}
+		}
+
+		var transactionID *uint
+		for _, payout := range payouts {
+			transaction := models.Transaction{
+				UserID:        payout.UserID,
+				PartnershipID: partnershipID,
+				Type:          "split",
+				Amount:        payout.Amount,
+				Description:   "On-chain withdrawal",
+				TxHash:        txHash,
+				LogIndex:      &logIndex,
+				Status:        "pending",
+			}
 			if err := tx.Create(&transaction).Error; err != nil {
 				return err
 			}
+			if transactionID == nil {
+				transactionID = &transaction.ID
+			}
+			postings = append(postings, ledger.Partner(payout.UserID, payout.Amount))
+			if external, err = external.Sub(payout.Amount); err != nil {
+				return err
+			}
 		}
+		postings = append(postings, ledger.External(external))
 
-		// Reset balance
-		balance.Balance = 0
-		balance.LastUpdated = time.Now()
-		if err := tx.Save(&balance).Error; err != nil {
+		if _, err := ledger.Post(tx, partnershipID, ledger.EntrySplit, "On-chain withdrawal", transactionID, postings...); err != nil {
 			return err
 		}
 
-		// Update partnership status
-		return tx.Model(&partnership).Update("status", "split").Error
+		if err := tx.Model(&models.Goal{}).
+			Where("partnership_id = ?", partnershipID).
+			Updates(map[string]interface{}{"current_amount_units": 0}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.WalletBalance{}).
+			Where("partnership_id = ?", partnershipID).
+			Updates(map[string]interface{}{"balance_units": 0, "last_updated": time.Now()}).Error
 	})
-}
\ No newline at end of file
+}
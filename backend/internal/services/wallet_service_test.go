@@ -0,0 +1,327 @@
+package services
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"aa-sharing-backend/internal/ledger"
+	"aa-sharing-backend/internal/models"
+	"aa-sharing-backend/internal/testdb"
+
+	"gorm.io/gorm"
+)
+
+// contribute adds a confirmed contribution of amount to the partnership.
+func contribute(t *testing.T, s *WalletService, partnership *models.Partnership, userID uint, amount models.Money) {
+	t.Helper()
+	if err := s.CreateTransaction(&models.Transaction{
+		UserID:        userID,
+		PartnershipID: partnership.ID,
+		Type:          "contribution",
+		Amount:        amount,
+		Status:        "confirmed",
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConfirmSplit(t *testing.T) {
+	db := testdb.Open(t)
+	s := NewWalletService(db)
+	partnership := testdb.Partnership(t, db)
+	contribute(t, s, partnership, partnership.UserAID, models.USDC(10_000_001))
+
+	preview, err := s.PreviewSplit(partnership.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preview.Payouts[0].Amount.Units != 5_000_001 || preview.Payouts[1].Amount.Units != 5_000_000 {
+		t.Fatalf("payouts %v, want 5.000001 and 5", preview.Payouts)
+	}
+
+	if _, err := s.ConfirmSplit(partnership.ID, partnership.UserAID, models.USDC(9_000_000)); !errors.Is(err, ErrSplitChanged) {
+		t.Fatalf("confirming another balance: got %v, want ErrSplitChanged", err)
+	}
+	preview, err = s.ConfirmSplit(partnership.ID, partnership.UserAID, preview.Balance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preview.Executed || len(preview.ConfirmedBy) != 1 {
+		t.Fatalf("after one confirmation: executed %v, confirmed by %v", preview.Executed, preview.ConfirmedBy)
+	}
+
+	preview, err = s.ConfirmSplit(partnership.ID, partnership.UserBID, preview.Balance)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !preview.Executed {
+		t.Fatal("split not executed after both partners confirmed")
+	}
+	balance, err := ledger.PartnershipBalance(db, partnership.ID, models.DefaultCurrency)
+	if err != nil || !balance.IsZero() {
+		t.Errorf("partnership still holds %s (%v)", balance, err)
+	}
+}
+
+func TestConfirmSplitIgnoresStaleConfirmations(t *testing.T) {
+	db := testdb.Open(t)
+	s := NewWalletService(db)
+	partnership := testdb.Partnership(t, db)
+	contribute(t, s, partnership, partnership.UserAID, models.USDC(10_000_000))
+
+	first, err := s.ConfirmSplit(partnership.ID, partnership.UserAID, models.USDC(10_000_000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Money arrives after A confirmed: A agreed to split 10, not 15
+	contribute(t, s, partnership, partnership.UserBID, models.USDC(5_000_000))
+	preview, err := s.ConfirmSplit(partnership.ID, partnership.UserBID, models.USDC(15_000_000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preview.Executed || len(preview.ConfirmedBy) != 1 {
+		t.Fatalf("split of 15 went through on A's confirmation of %s", first.Balance)
+	}
+
+	// B's confirmation of 15 expires before A confirms it
+	expire := func() {
+		t.Helper()
+		if err := db.Model(&models.SplitConfirmation{}).Where("partnership_id = ?", partnership.ID).
+			Update("expires_at", time.Now().Add(-time.Minute)).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	expire()
+	preview, err = s.ConfirmSplit(partnership.ID, partnership.UserAID, models.USDC(15_000_000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preview.Executed {
+		t.Fatal("split went through on an expired confirmation")
+	}
+
+	// And A's expires before B confirms again
+	expire()
+	preview, err = s.ConfirmSplit(partnership.ID, partnership.UserBID, models.USDC(15_000_000))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if preview.Executed {
+		t.Fatal("split went through on an expired confirmation")
+	}
+}
+
+func TestConfirmSplitRejectsOutsiders(t *testing.T) {
+	db := testdb.Open(t)
+	s := NewWalletService(db)
+	partnership := testdb.Partnership(t, db)
+	contribute(t, s, partnership, partnership.UserAID, models.USDC(1_000_000))
+
+	outsider := testdb.User(t, db)
+	if _, err := s.ConfirmSplit(partnership.ID, outsider.ID, models.USDC(1_000_000)); !errors.Is(err, ErrNotPartner) {
+		t.Errorf("got %v, want ErrNotPartner", err)
+	}
+	var count int64
+	db.Model(&models.SplitConfirmation{}).Where("partnership_id = ?", partnership.ID).Count(&count)
+	if count != 0 {
+		t.Errorf("outsider left %d confirmations", count)
+	}
+	if _, err := s.ConfirmSplit(9999, partnership.UserAID, models.USDC(1_000_000)); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("unknown partnership: got %v", err)
+	}
+}
+
+func TestWalletBalanceDerivedFromLedger(t *testing.T) {
+	db := testdb.Open(t)
+	s := NewWalletService(db)
+	partnership := testdb.Partnership(t, db)
+	contribute(t, s, partnership, partnership.UserAID, models.USDC(6_000_000))
+	contribute(t, s, partnership, partnership.UserBID, models.USDC(4_000_000))
+
+	// A stored balance that drifted from the journal is not what we report
+	drift := db.Model(&models.WalletBalance{}).Where("partnership_id = ?", partnership.ID).Update("balance_units", 1)
+	if drift.Error != nil || drift.RowsAffected != 1 {
+		t.Fatalf("updating the stored balance: %v, %d rows", drift.Error, drift.RowsAffected)
+	}
+	wallet, err := s.GetWalletBalance(partnership.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wallet.Balance != models.USDC(10_000_000) {
+		t.Errorf("wallet balance %s, want 10", wallet.Balance)
+	}
+
+	accounts, entries, err := s.GetLedger(partnership.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("%d journal entries, want one per contribution", len(entries))
+	}
+	want := map[uint]models.Money{
+		0:                   models.USDC(10_000_000),
+		partnership.UserAID: models.USDC(-6_000_000),
+		partnership.UserBID: models.USDC(-4_000_000),
+	}
+	for _, account := range accounts {
+		if account.Balance != want[account.UserID] {
+			t.Errorf("%s account of user %d holds %s, want %s", account.Type, account.UserID, account.Balance, want[account.UserID])
+		}
+	}
+	if unbalanced, err := ledger.UnbalancedEntries(db); err != nil || len(unbalanced) != 0 {
+		t.Errorf("unbalanced entries %v (%v)", unbalanced, err)
+	}
+}
+
+func TestConcurrentContributionsLoseNothing(t *testing.T) {
+	db := testdb.Open(t)
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Enough connections for real contention, few enough for any server
+	sqlDB.SetMaxOpenConns(20)
+
+	s := NewWalletService(db)
+	partnership := testdb.Partnership(t, db)
+
+	const contributions = 300
+	var wg sync.WaitGroup
+	errs := make(chan error, contributions)
+	for i := 0; i < contributions; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			userID := partnership.UserAID
+			if i%2 == 1 {
+				userID = partnership.UserBID
+			}
+			errs <- s.CreateTransaction(&models.Transaction{
+				UserID:        userID,
+				PartnershipID: partnership.ID,
+				Type:          "contribution",
+				Amount:        models.USDC(int64(1_000_000 + i)),
+				Status:        "confirmed",
+			})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("contribution failed: %v", err)
+		}
+	}
+
+	// 300 USDC plus 0+1+...+299 base units
+	want := int64(contributions*1_000_000 + contributions*(contributions-1)/2)
+
+	balance, err := ledger.PartnershipBalance(db, partnership.ID, models.DefaultCurrency)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.Units != want {
+		t.Errorf("ledger balance %d, want %d", balance.Units, want)
+	}
+
+	var wallet models.WalletBalance
+	if err := db.Where("partnership_id = ?", partnership.ID).First(&wallet).Error; err != nil {
+		t.Fatal(err)
+	}
+	if wallet.Balance.Units != want {
+		t.Errorf("stored wallet balance %d, want %d", wallet.Balance.Units, want)
+	}
+
+	var count int64
+	db.Model(&models.WalletBalance{}).Where("partnership_id = ?", partnership.ID).Count(&count)
+	if count != 1 {
+		t.Errorf("%d wallet rows, want 1", count)
+	}
+	db.Model(&models.Transaction{}).Where("partnership_id = ?", partnership.ID).Count(&count)
+	if count != contributions {
+		t.Errorf("%d transactions, want %d", count, contributions)
+	}
+	if unbalanced, err := ledger.UnbalancedEntries(db); err != nil || len(unbalanced) != 0 {
+		t.Errorf("unbalanced journal entries %v (%v)", unbalanced, err)
+	}
+}
+
+func TestUpdateGoalRejectsUnknownStatusAndCurrency(t *testing.T) {
+	db := testdb.Open(t)
+	s := NewWalletService(db)
+	partnership := testdb.Partnership(t, db)
+	goal := models.Goal{
+		PartnershipID: partnership.ID,
+		Name:          "Trip",
+		TargetAmount:  models.USDC(100_000_000),
+		CurrentAmount: models.USDC(0),
+		Status:        "active",
+	}
+	if err := s.CreateGoal(&goal, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, status := range []string{"archived", ""} {
+		if err := s.UpdateGoal(goal.ID, map[string]interface{}{"status": status}); !errors.Is(err, ErrInvalidGoalStatus) {
+			t.Errorf("status %q: got %v, want ErrInvalidGoalStatus", status, err)
+		}
+	}
+	for _, column := range []string{"target_amount_currency", "current_amount_currency"} {
+		err := s.UpdateGoal(goal.ID, map[string]interface{}{"target_amount_units": int64(50), column: "EUR"})
+		if !errors.Is(err, models.ErrCurrencyMismatch) {
+			t.Errorf("%s: got %v, want ErrCurrencyMismatch", column, err)
+		}
+	}
+
+	var stored models.Goal
+	if err := db.First(&stored, goal.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status != "active" || stored.TargetAmount != models.USDC(100_000_000) {
+		t.Errorf("goal %s with target %s %s after rejected updates", stored.Status, stored.TargetAmount, stored.TargetAmount.Code())
+	}
+
+	if err := s.UpdateGoal(goal.ID, map[string]interface{}{"status": "cancelled", "target_amount_currency": models.DefaultCurrency}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUpdateGoalRejectsNegativeAmount(t *testing.T) {
+	db := testdb.Open(t)
+	s := NewWalletService(db)
+	partnership := testdb.Partnership(t, db)
+	contribute(t, s, partnership, partnership.UserAID, models.USDC(10_000_000))
+	goal := models.Goal{
+		PartnershipID: partnership.ID,
+		Name:          "Trip",
+		TargetAmount:  models.USDC(100_000_000),
+		CurrentAmount: models.USDC(0),
+		Status:        "active",
+	}
+	if err := s.CreateGoal(&goal, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UpdateGoal(goal.ID, map[string]interface{}{"current_amount_units": int64(4_000_000)}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A negative amount would credit the pool with money never deposited
+	err := s.UpdateGoal(goal.ID, map[string]interface{}{"current_amount_units": int64(-100_000_000)})
+	if !errors.Is(err, ErrNegativeGoalAmount) {
+		t.Fatalf("got %v, want ErrNegativeGoalAmount", err)
+	}
+	if balance, err := ledger.GoalBalance(db, &goal); err != nil || balance != models.USDC(4_000_000) {
+		t.Errorf("goal holds %s (%v), want 4", balance, err)
+	}
+	if pool, err := ledger.Balance(db, partnership.ID, models.DefaultCurrency, ledger.AccountPool); err != nil || pool != models.USDC(6_000_000) {
+		t.Errorf("pool holds %s (%v), want 6", pool, err)
+	}
+
+	// Emptying the goal is still allowed
+	if err := s.UpdateGoal(goal.ID, map[string]interface{}{"current_amount_units": int64(0)}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,289 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"aa-sharing-backend/internal/ledger"
+	"aa-sharing-backend/internal/models"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrWithdrawalNotPending = errors.New("withdrawal request is no longer pending")
+	ErrWithdrawalExpired    = errors.New("withdrawal request has expired")
+	ErrOwnWithdrawal        = errors.New("a withdrawal request must be reviewed by the other partner")
+)
+
+// WithdrawalService lets a partner spend partnership money with the other
+// partner's consent. Requests left unreviewed for ttl expire.
+type WithdrawalService struct {
+	db            *gorm.DB
+	walletService *WalletService
+	ttl           time.Duration
+}
+
+func NewWithdrawalService(db *gorm.DB, walletService *WalletService, ttl time.Duration) *WithdrawalService {
+	return &WithdrawalService{db: db, walletService: walletService, ttl: ttl}
+}
+
+// RequestWithdrawal proposes spending amount from the pool, or from the goal
+// when goalID is set, and tells the other partner through the activity feed.
+// The source must hold the amount now; it is checked again on approval.
+func (s *WithdrawalService) RequestWithdrawal(partnership *models.Partnership, userID uint, goalID *uint, amount models.Money, purpose string) (*models.WithdrawalRequest, error) {
+	request := models.WithdrawalRequest{
+		PartnershipID: partnership.ID,
+		RequestedByID: userID,
+		GoalID:        goalID,
+		Amount:        amount,
+		Purpose:       purpose,
+		Status:        models.WithdrawalPending,
+		ExpiresAt:     time.Now().Add(s.ttl),
+	}
+
+	err := transactionWithRetry(s.db, func(tx *gorm.DB) error {
+		request.ID = 0
+		if err := s.checkSource(tx, &request); err != nil {
+			return err
+		}
+		if err := tx.Create(&request).Error; err != nil {
+			return err
+		}
+		return s.recordActivity(tx, partnership, &request, models.ActivityWithdrawalRequested,
+			fmt.Sprintf("%s asked to spend %s %s%s on %s", nickname(partnership, userID),
+				amount, amount.Code(), s.sourceLabel(tx, &request), purpose))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// GetWithdrawals returns a partnership's withdrawal requests, newest first,
+// optionally only those with the given status. Overdue requests are expired
+// first so that they are not listed as pending.
+func (s *WithdrawalService) GetWithdrawals(partnershipID uint, status string) ([]models.WithdrawalRequest, error) {
+	if err := s.expire(s.db.Where("partnership_id = ?", partnershipID)); err != nil {
+		return nil, err
+	}
+
+	query := s.db.Where("partnership_id = ?", partnershipID)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var requests []models.WithdrawalRequest
+	if err := query.Preload("RequestedBy").Order("created_at DESC").Find(&requests).Error; err != nil {
+		return nil, err
+	}
+	return requests, nil
+}
+
+func (s *WithdrawalService) GetWithdrawal(id uint) (*models.WithdrawalRequest, error) {
+	var request models.WithdrawalRequest
+	if err := s.db.First(&request, id).Error; err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
+// ApproveWithdrawal approves a pending request on behalf of the other
+// partner. The withdrawal transaction is recorded, posted to the ledger and
+// taken from the wallet balance and the goal in the same database
+// transaction as the approval. No funds move on chain: the contract only
+// pays a partnership's whole balance out to both partners, so reconciliation
+// expects the contract to hold approved withdrawals until then.
+func (s *WithdrawalService) ApproveWithdrawal(partnership *models.Partnership, id, reviewerID uint) (*models.WithdrawalRequest, error) {
+	return s.review(partnership, id, reviewerID, func(tx *gorm.DB, request *models.WithdrawalRequest) error {
+		if err := s.checkSource(tx, request); err != nil {
+			return err
+		}
+
+		transaction := models.Transaction{
+			UserID:        request.RequestedByID,
+			PartnershipID: request.PartnershipID,
+			Type:          "withdrawal",
+			Amount:        request.Amount,
+			GoalID:        request.GoalID,
+			Description:   request.Purpose,
+			Status:        "confirmed",
+		}
+		if err := tx.Create(&transaction).Error; err != nil {
+			return err
+		}
+		if _, err := ledger.PostWithdrawal(tx, &transaction); err != nil {
+			return err
+		}
+		if err := s.walletService.updateBalance(tx, request.PartnershipID, request.Amount.Neg()); err != nil {
+			return err
+		}
+		if request.GoalID != nil {
+			goal, err := s.lockGoal(tx, request)
+			if err != nil {
+				return err
+			}
+			if err := s.walletService.refreshGoal(tx, goal); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(request).Updates(map[string]interface{}{
+			"status":         models.WithdrawalApproved,
+			"reviewed_by_id": reviewerID,
+			"reviewed_at":    time.Now(),
+			"transaction_id": transaction.ID,
+		}).Error; err != nil {
+			return err
+		}
+		return s.recordActivity(tx, partnership, request, models.ActivityWithdrawalApproved,
+			fmt.Sprintf("%s approved spending %s %s on %s", nickname(partnership, reviewerID),
+				request.Amount, request.Amount.Code(), request.Purpose))
+	})
+}
+
+// RejectWithdrawal rejects a pending request on behalf of the other partner.
+func (s *WithdrawalService) RejectWithdrawal(partnership *models.Partnership, id, reviewerID uint, reason string) (*models.WithdrawalRequest, error) {
+	return s.review(partnership, id, reviewerID, func(tx *gorm.DB, request *models.WithdrawalRequest) error {
+		if err := tx.Model(request).Updates(map[string]interface{}{
+			"status":           models.WithdrawalRejected,
+			"reviewed_by_id":   reviewerID,
+			"reviewed_at":      time.Now(),
+			"rejection_reason": reason,
+		}).Error; err != nil {
+			return err
+		}
+		return s.recordActivity(tx, partnership, request, models.ActivityWithdrawalRejected,
+			fmt.Sprintf("%s declined spending %s %s on %s", nickname(partnership, reviewerID),
+				request.Amount, request.Amount.Code(), request.Purpose))
+	})
+}
+
+// review locks a request and hands it to decide once it is known to be
+// pending, unexpired and reviewed by the partner who did not make it. An
+// overdue request is marked expired and ErrWithdrawalExpired returned.
+func (s *WithdrawalService) review(partnership *models.Partnership, id, reviewerID uint, decide func(tx *gorm.DB, request *models.WithdrawalRequest) error) (*models.WithdrawalRequest, error) {
+	var request models.WithdrawalRequest
+	expired := false
+	err := transactionWithRetry(s.db, func(tx *gorm.DB) error {
+		expired = false
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("partnership_id = ?", partnership.ID).
+			First(&request, id).Error; err != nil {
+			return err
+		}
+
+		if err := checkReview(&request, reviewerID, time.Now()); err != nil {
+			if !errors.Is(err, ErrWithdrawalExpired) {
+				return err
+			}
+			expired = true
+			return tx.Model(&request).Update("status", models.WithdrawalExpired).Error
+		}
+		return decide(tx, &request)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if expired {
+		return nil, ErrWithdrawalExpired
+	}
+	return &request, nil
+}
+
+// checkReview reports whether reviewerID may approve or reject request at
+// now.
+func checkReview(request *models.WithdrawalRequest, reviewerID uint, now time.Time) error {
+	switch {
+	case request.Status != models.WithdrawalPending:
+		return ErrWithdrawalNotPending
+	case !now.Before(request.ExpiresAt):
+		return ErrWithdrawalExpired
+	case request.RequestedByID == reviewerID:
+		return ErrOwnWithdrawal
+	}
+	return nil
+}
+
+// expire marks the overdue pending requests matched by scope as expired.
+func (s *WithdrawalService) expire(scope *gorm.DB) error {
+	return scope.Model(&models.WithdrawalRequest{}).
+		Where("status = ? AND expires_at <= ?", models.WithdrawalPending, time.Now()).
+		Update("status", models.WithdrawalExpired).Error
+}
+
+// checkSource returns ErrInsufficientFunds unless the pool or goal the
+// request spends from holds its amount. The wallet row is locked first, as
+// for every change to the partnership's holdings.
+func (s *WithdrawalService) checkSource(tx *gorm.DB, request *models.WithdrawalRequest) error {
+	if _, err := s.walletService.lockWallet(tx, request.PartnershipID, request.Amount.Code()); err != nil {
+		return err
+	}
+
+	var available models.Money
+	var err error
+	if request.GoalID == nil {
+		available, err = ledger.Balance(tx, request.PartnershipID, request.Amount.Code(), ledger.AccountPool)
+	} else {
+		var goal *models.Goal
+		if goal, err = s.lockGoal(tx, request); err != nil {
+			return err
+		}
+		available, err = ledger.GoalBalance(tx, goal)
+	}
+	if err != nil {
+		return err
+	}
+	if available.Units < request.Amount.Units {
+		return fmt.Errorf("%w: %s available", ErrInsufficientFunds, available)
+	}
+	return nil
+}
+
+func (s *WithdrawalService) lockGoal(tx *gorm.DB, request *models.WithdrawalRequest) (*models.Goal, error) {
+	var goal models.Goal
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&goal, *request.GoalID).Error; err != nil {
+		return nil, err
+	}
+	if goal.PartnershipID != request.PartnershipID {
+		return nil, ErrGoalMismatch
+	}
+	if goal.TargetAmount.Code() != request.Amount.Code() {
+		return nil, models.ErrCurrencyMismatch
+	}
+	return &goal, nil
+}
+
+// sourceLabel names the goal a request spends from for activity messages.
+func (s *WithdrawalService) sourceLabel(tx *gorm.DB, request *models.WithdrawalRequest) string {
+	if request.GoalID == nil {
+		return ""
+	}
+	var goal models.Goal
+	if err := tx.First(&goal, *request.GoalID).Error; err != nil {
+		return ""
+	}
+	return " from " + goal.Name
+}
+
+func (s *WithdrawalService) recordActivity(tx *gorm.DB, partnership *models.Partnership, request *models.WithdrawalRequest, kind, message string) error {
+	return tx.Create(&models.ActivityEvent{
+		PartnershipID: partnership.ID,
+		Kind:          kind,
+		GoalID:        request.GoalID,
+		Message:       message,
+	}).Error
+}
+
+// nickname returns the name a partnership uses for one of its partners.
+func nickname(partnership *models.Partnership, userID uint) string {
+	name := partnership.NicknameB
+	if userID == partnership.UserAID {
+		name = partnership.NicknameA
+	}
+	if name == "" {
+		return "Your partner"
+	}
+	return name
+}
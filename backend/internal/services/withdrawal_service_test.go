@@ -0,0 +1,167 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"aa-sharing-backend/internal/models"
+	"aa-sharing-backend/internal/testdb"
+
+	"gorm.io/gorm"
+)
+
+func TestCheckReview(t *testing.T) {
+	now := time.Date(2025, 7, 1, 12, 0, 0, 0, time.UTC)
+	const requester, reviewer = 1, 2
+	tests := []struct {
+		name       string
+		status     string
+		expiresAt  time.Time
+		reviewerID uint
+		want       error
+	}{
+		{"pending", models.WithdrawalPending, now.Add(time.Hour), reviewer, nil},
+		{"own request", models.WithdrawalPending, now.Add(time.Hour), requester, ErrOwnWithdrawal},
+		{"at the deadline", models.WithdrawalPending, now, reviewer, ErrWithdrawalExpired},
+		{"overdue own request", models.WithdrawalPending, now.Add(-time.Hour), requester, ErrWithdrawalExpired},
+		{"approved", models.WithdrawalApproved, now.Add(time.Hour), reviewer, ErrWithdrawalNotPending},
+		{"rejected", models.WithdrawalRejected, now.Add(time.Hour), reviewer, ErrWithdrawalNotPending},
+		{"expired", models.WithdrawalExpired, now.Add(-time.Hour), reviewer, ErrWithdrawalNotPending},
+	}
+	for _, tt := range tests {
+		request := models.WithdrawalRequest{RequestedByID: requester, Status: tt.status, ExpiresAt: tt.expiresAt}
+		if err := checkReview(&request, tt.reviewerID, now); !errors.Is(err, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+// withdrawalRequest stores a request of the partnership's user A with the
+// given status and deadline.
+func withdrawalRequest(t *testing.T, db *gorm.DB, partnership *models.Partnership, status string, expiresAt time.Time) *models.WithdrawalRequest {
+	t.Helper()
+	request := models.WithdrawalRequest{
+		PartnershipID: partnership.ID,
+		RequestedByID: partnership.UserAID,
+		Amount:        models.USDC(1_000_000),
+		Purpose:       "Groceries",
+		Status:        status,
+		ExpiresAt:     expiresAt,
+	}
+	if err := db.Create(&request).Error; err != nil {
+		t.Fatal(err)
+	}
+	return &request
+}
+
+func TestExpireOnlyOverduePendingRequests(t *testing.T) {
+	db := testdb.Open(t)
+	s := NewWithdrawalService(db, NewWalletService(db), time.Hour)
+	partnership, other := testdb.Partnership(t, db), testdb.Partnership(t, db)
+
+	overdue := withdrawalRequest(t, db, partnership, models.WithdrawalPending, time.Now().Add(-time.Minute))
+	open := withdrawalRequest(t, db, partnership, models.WithdrawalPending, time.Now().Add(time.Hour))
+	approved := withdrawalRequest(t, db, partnership, models.WithdrawalApproved, time.Now().Add(-time.Minute))
+	elsewhere := withdrawalRequest(t, db, other, models.WithdrawalPending, time.Now().Add(-time.Minute))
+
+	if err := s.expire(db.Where("partnership_id = ?", partnership.ID)); err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		request *models.WithdrawalRequest
+		want    string
+	}{
+		{overdue, models.WithdrawalExpired},
+		{open, models.WithdrawalPending},
+		{approved, models.WithdrawalApproved},
+		{elsewhere, models.WithdrawalPending},
+	} {
+		got, err := s.GetWithdrawal(tt.request.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Status != tt.want {
+			t.Errorf("request %d (%s, expires %s) is %s, want %s", got.ID, tt.request.Status,
+				tt.request.ExpiresAt.Format(time.RFC3339), got.Status, tt.want)
+		}
+	}
+
+	// Listing expires the other partnership's overdue request too
+	pending, err := s.GetWithdrawals(other.ID, models.WithdrawalPending)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("%d pending requests listed, want the overdue one expired", len(pending))
+	}
+}
+
+func TestReviewExpiresOverdueRequest(t *testing.T) {
+	db := testdb.Open(t)
+	s := NewWithdrawalService(db, NewWalletService(db), time.Hour)
+	partnership := testdb.Partnership(t, db)
+	request := withdrawalRequest(t, db, partnership, models.WithdrawalPending, time.Now().Add(-time.Minute))
+
+	if _, err := s.ApproveWithdrawal(partnership, request.ID, partnership.UserBID); !errors.Is(err, ErrWithdrawalExpired) {
+		t.Fatalf("approving an overdue request: got %v, want ErrWithdrawalExpired", err)
+	}
+	got, err := s.GetWithdrawal(request.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != models.WithdrawalExpired || got.TransactionID != nil {
+		t.Errorf("request %s with transaction %v, want expired without one", got.Status, got.TransactionID)
+	}
+
+	// Once expired it is no longer pending
+	if _, err := s.RejectWithdrawal(partnership, request.ID, partnership.UserBID, "late"); !errors.Is(err, ErrWithdrawalNotPending) {
+		t.Errorf("rejecting an expired request: got %v, want ErrWithdrawalNotPending", err)
+	}
+}
+
+func TestWithdrawalKeepsGoalCompleted(t *testing.T) {
+	db := testdb.Open(t)
+	wallet := NewWalletService(db)
+	s := NewWithdrawalService(db, wallet, time.Hour)
+	partnership := testdb.Partnership(t, db)
+	goal := models.Goal{PartnershipID: partnership.ID, Name: "Trip", TargetAmount: models.USDC(10_000_000), Status: "active"}
+	if err := wallet.CreateGoal(&goal, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := wallet.CreateTransaction(&models.Transaction{
+		UserID:        partnership.UserAID,
+		PartnershipID: partnership.ID,
+		Type:          "contribution",
+		Amount:        models.USDC(10_000_000),
+		GoalID:        &goal.ID,
+		Status:        "confirmed",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	milestones := func() int64 {
+		var count int64
+		db.Model(&models.ActivityEvent{}).Where("goal_id = ? AND kind = ?", goal.ID, models.ActivityGoalMilestone).Count(&count)
+		return count
+	}
+	reached := milestones()
+
+	request, err := s.RequestWithdrawal(partnership, partnership.UserAID, &goal.ID, models.USDC(6_000_000), "Flights")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.ApproveWithdrawal(partnership, request.ID, partnership.UserBID); err != nil {
+		t.Fatal(err)
+	}
+
+	spent, err := wallet.GetGoal(goal.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spent.Status != "completed" || spent.CurrentAmount != models.USDC(4_000_000) {
+		t.Errorf("goal %s holding %s after spending its savings, want completed holding 4", spent.Status, spent.CurrentAmount)
+	}
+	if got := milestones(); got != reached {
+		t.Errorf("%d milestone events after the withdrawal, want %d", got, reached)
+	}
+}
@@ -0,0 +1,165 @@
+// Package siwe parses and verifies Sign-In With Ethereum (EIP-4361) messages.
+package siwe
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const headerSuffix = " wants you to sign in with your Ethereum account:"
+
+var (
+	ErrMalformedMessage = errors.New("malformed SIWE message")
+	ErrInvalidSignature = errors.New("invalid SIWE signature")
+	ErrExpired          = errors.New("SIWE message has expired")
+	ErrNotYetValid      = errors.New("SIWE message is not yet valid")
+)
+
+// Message is a parsed EIP-4361 message.
+type Message struct {
+	Domain         string
+	Address        common.Address
+	Statement      string
+	URI            string
+	Version        string
+	ChainID        uint64
+	Nonce          string
+	IssuedAt       time.Time
+	ExpirationTime *time.Time
+	NotBefore      *time.Time
+	RequestID      string
+	Resources      []string
+}
+
+// ParseMessage parses the plain-text representation of a SIWE message.
+func ParseMessage(raw string) (*Message, error) {
+	lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
+	if len(lines) < 3 {
+		return nil, ErrMalformedMessage
+	}
+
+	var msg Message
+	if !strings.HasSuffix(lines[0], headerSuffix) {
+		return nil, fmt.Errorf("%w: missing header", ErrMalformedMessage)
+	}
+	msg.Domain = strings.TrimSuffix(lines[0], headerSuffix)
+	if msg.Domain == "" {
+		return nil, fmt.Errorf("%w: missing domain", ErrMalformedMessage)
+	}
+
+	if !common.IsHexAddress(lines[1]) {
+		return nil, fmt.Errorf("%w: invalid address", ErrMalformedMessage)
+	}
+	msg.Address = common.HexToAddress(lines[1])
+
+	// The address is followed by an empty line, an optional statement and
+	// another empty line before the fields start.
+	i := 2
+	if lines[i] != "" {
+		return nil, fmt.Errorf("%w: expected empty line after address", ErrMalformedMessage)
+	}
+	i++
+	if i < len(lines) && !strings.HasPrefix(lines[i], "URI: ") {
+		msg.Statement = lines[i]
+		i++
+		if i >= len(lines) || lines[i] != "" {
+			return nil, fmt.Errorf("%w: expected empty line after statement", ErrMalformedMessage)
+		}
+		i++
+	}
+
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if line == "Resources:" {
+			for i++; i < len(lines); i++ {
+				if !strings.HasPrefix(lines[i], "- ") {
+					return nil, fmt.Errorf("%w: invalid resource line", ErrMalformedMessage)
+				}
+				msg.Resources = append(msg.Resources, strings.TrimPrefix(lines[i], "- "))
+			}
+			break
+		}
+
+		key, value, found := strings.Cut(line, ": ")
+		if !found {
+			return nil, fmt.Errorf("%w: invalid field %q", ErrMalformedMessage, line)
+		}
+
+		var err error
+		switch key {
+		case "URI":
+			msg.URI = value
+		case "Version":
+			msg.Version = value
+		case "Chain ID":
+			msg.ChainID, err = strconv.ParseUint(value, 10, 64)
+		case "Nonce":
+			msg.Nonce = value
+		case "Issued At":
+			msg.IssuedAt, err = time.Parse(time.RFC3339, value)
+		case "Expiration Time":
+			var t time.Time
+			t, err = time.Parse(time.RFC3339, value)
+			msg.ExpirationTime = &t
+		case "Not Before":
+			var t time.Time
+			t, err = time.Parse(time.RFC3339, value)
+			msg.NotBefore = &t
+		case "Request ID":
+			msg.RequestID = value
+		default:
+			return nil, fmt.Errorf("%w: unknown field %q", ErrMalformedMessage, key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid %s", ErrMalformedMessage, key)
+		}
+	}
+
+	if msg.URI == "" || msg.Version != "1" || msg.ChainID == 0 || len(msg.Nonce) < 8 || msg.IssuedAt.IsZero() {
+		return nil, fmt.Errorf("%w: missing required field", ErrMalformedMessage)
+	}
+
+	return &msg, nil
+}
+
+// ValidAt checks the message's optional validity window against now.
+func (m *Message) ValidAt(now time.Time) error {
+	if m.ExpirationTime != nil && !now.Before(*m.ExpirationTime) {
+		return ErrExpired
+	}
+	if m.NotBefore != nil && now.Before(*m.NotBefore) {
+		return ErrNotYetValid
+	}
+	return nil
+}
+
+// VerifySignature recovers the signer of the EIP-191 personal_sign signature
+// over raw and checks that it matches the address in the message.
+func (m *Message) VerifySignature(raw, signature string) error {
+	sig, err := hexutil.Decode(signature)
+	if err != nil || len(sig) != crypto.SignatureLength {
+		return ErrInvalidSignature
+	}
+
+	// Wallets return V as 27/28; crypto.SigToPub expects 0/1.
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	pub, err := crypto.SigToPub(accounts.TextHash([]byte(raw)), sig)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	if crypto.PubkeyToAddress(*pub) != m.Address {
+		return ErrInvalidSignature
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package siwe
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func testMessage(address common.Address) string {
+	return fmt.Sprintf(`localhost:3000 wants you to sign in with your Ethereum account:
+%s
+
+Sign in to AA Sharing
+
+URI: http://localhost:3000/login
+Version: 1
+Chain ID: 747
+Nonce: 0123456789abcdef
+Issued At: 2026-01-02T03:04:05Z
+Expiration Time: 2026-01-02T03:09:05Z`, address.Hex())
+}
+
+// sign signs raw the way personal_sign does, with V as 27 or 28.
+func sign(t *testing.T, key *ecdsa.PrivateKey, raw string) string {
+	t.Helper()
+	sig, err := crypto.Sign(accounts.TextHash([]byte(raw)), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return hexutil.Encode(sig)
+}
+
+func TestParseMessage(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	msg, err := ParseMessage(testMessage(address))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg.Domain != "localhost:3000" || msg.Address != address || msg.Statement != "Sign in to AA Sharing" {
+		t.Errorf("header parsed as %q %s %q", msg.Domain, msg.Address, msg.Statement)
+	}
+	if msg.URI != "http://localhost:3000/login" || msg.ChainID != 747 || msg.Nonce != "0123456789abcdef" {
+		t.Errorf("fields parsed as %q %d %q", msg.URI, msg.ChainID, msg.Nonce)
+	}
+	if want := time.Date(2026, 1, 2, 3, 9, 5, 0, time.UTC); msg.ExpirationTime == nil || !msg.ExpirationTime.Equal(want) {
+		t.Errorf("expiration time %v, want %v", msg.ExpirationTime, want)
+	}
+}
+
+func TestParseMessageRejectsMalformed(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	valid := testMessage(crypto.PubkeyToAddress(key.PublicKey))
+
+	tests := map[string]string{
+		"no header":       "hello\n0x0000000000000000000000000000000000000000\n",
+		"bad address":     "localhost wants you to sign in with your Ethereum account:\n0x1234\n\nURI: http://x\n",
+		"unknown field":   valid + "\nColour: blue",
+		"missing nonce":   "localhost wants you to sign in with your Ethereum account:\n0x0000000000000000000000000000000000000001\n\nURI: http://x\nVersion: 1\nChain ID: 1\nIssued At: 2026-01-02T03:04:05Z",
+		"bad chain ID":    "localhost wants you to sign in with your Ethereum account:\n0x0000000000000000000000000000000000000001\n\nURI: http://x\nVersion: 1\nChain ID: one\nNonce: 0123456789abcdef\nIssued At: 2026-01-02T03:04:05Z",
+		"unknown version": "localhost wants you to sign in with your Ethereum account:\n0x0000000000000000000000000000000000000001\n\nURI: http://x\nVersion: 2\nChain ID: 1\nNonce: 0123456789abcdef\nIssued At: 2026-01-02T03:04:05Z",
+	}
+	for name, raw := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseMessage(raw); !errors.Is(err, ErrMalformedMessage) {
+				t.Errorf("got %v, want ErrMalformedMessage", err)
+			}
+		})
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	other, _ := crypto.GenerateKey()
+	raw := testMessage(crypto.PubkeyToAddress(key.PublicKey))
+	msg, err := ParseMessage(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := msg.VerifySignature(raw, sign(t, key, raw)); err != nil {
+		t.Errorf("signature by the message's address: %v", err)
+	}
+
+	tests := map[string]string{
+		"signed by another key": sign(t, other, raw),
+		"signed another text":   sign(t, key, raw+"\nRequest ID: 1"),
+		"not hex":               "signature",
+		"too short":             "0x1234",
+	}
+	for name, signature := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := msg.VerifySignature(raw, signature); !errors.Is(err, ErrInvalidSignature) {
+				t.Errorf("got %v, want ErrInvalidSignature", err)
+			}
+		})
+	}
+}
+
+func TestValidAt(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	msg, err := ParseMessage(testMessage(crypto.PubkeyToAddress(key.PublicKey)) + "\nNot Before: 2026-01-02T03:04:05Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		now  time.Time
+		want error
+	}{
+		{time.Date(2026, 1, 2, 3, 4, 0, 0, time.UTC), ErrNotYetValid},
+		{time.Date(2026, 1, 2, 3, 5, 0, 0, time.UTC), nil},
+		{time.Date(2026, 1, 2, 3, 9, 5, 0, time.UTC), ErrExpired},
+	}
+	for _, tt := range tests {
+		if err := msg.ValidAt(tt.now); !errors.Is(err, tt.want) {
+			t.Errorf("ValidAt(%v) = %v, want %v", tt.now, err, tt.want)
+		}
+	}
+}
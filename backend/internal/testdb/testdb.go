@@ -0,0 +1,108 @@
+// Package testdb gives tests a migrated Postgres database of their own.
+//
+// Tests that need one call Open, which skips the test unless
+// TEST_DATABASE_URL points at a Postgres server the tests may create schemas
+// on. Every test gets a fresh schema that is dropped when it finishes, so
+// tests can run in parallel against the same server.
+package testdb
+
+import (
+	"fmt"
+	"math/rand"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"aa-sharing-backend/internal/migrations"
+	"aa-sharing-backend/internal/models"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Open returns a connection to a new, migrated schema.
+func Open(t testing.TB) *gorm.DB {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	admin, err := open(dsn)
+	if err != nil {
+		t.Fatalf("connect to test database: %v", err)
+	}
+	schema := fmt.Sprintf("test_%d_%d", time.Now().UnixNano(), rand.Intn(1e6))
+	if err := admin.Exec("CREATE SCHEMA " + schema).Error; err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+
+	db, err := open(withSearchPath(dsn, schema))
+	if err != nil {
+		t.Fatalf("connect to test schema: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+		admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+		if sqlDB, err := admin.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	if err := migrations.Run(db); err != nil {
+		t.Fatalf("migrate test schema: %v", err)
+	}
+	return db
+}
+
+func open(dsn string) (*gorm.DB, error) {
+	return gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+}
+
+// withSearchPath makes every connection opened with dsn use schema.
+func withSearchPath(dsn, schema string) string {
+	if !strings.Contains(dsn, "://") {
+		return dsn + " search_path=" + schema
+	}
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return dsn
+	}
+	query := u.Query()
+	query.Set("search_path", schema)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// User creates a user with a unique email address.
+func User(t testing.TB, db *gorm.DB) *models.User {
+	t.Helper()
+	user := models.User{Name: "Test user"}
+	user.Email = fmt.Sprintf("user%d-%d@example.com", time.Now().UnixNano(), rand.Intn(1e6))
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	return &user
+}
+
+// Partnership creates an active partnership between two new users.
+func Partnership(t testing.TB, db *gorm.DB) *models.Partnership {
+	t.Helper()
+	partnership := models.Partnership{
+		UserAID:   User(t, db).ID,
+		UserBID:   User(t, db).ID,
+		NicknameA: "Alice",
+		NicknameB: "Bob",
+		Status:    models.PartnershipActive,
+	}
+	if err := db.Create(&partnership).Error; err != nil {
+		t.Fatalf("create partnership: %v", err)
+	}
+	return &partnership
+}
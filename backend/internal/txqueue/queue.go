@@ -0,0 +1,403 @@
+// Package txqueue sends the transactions the backend signs itself. Nonces
+// are allocated per sender from Postgres, and every signed transaction is
+// stored before it is broadcast, so a restart neither reuses a nonce nor
+// leaves a gap: stored transactions are simply broadcast again. Transactions
+// that stay unmined are replaced at a higher gas price, and those whose
+// nonce was taken by another transaction are marked dropped.
+package txqueue
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
+	"time"
+
+	"aa-sharing-backend/internal/models"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrEstimateGas is returned by Enqueue for a transaction that would revert.
+var ErrEstimateGas = errors.New("estimate gas")
+
+// Client is the JSON-RPC API used by the queue. It is satisfied by
+// *ethclient.Client.
+type Client interface {
+	ChainID(ctx context.Context) (*big.Int, error)
+	BlockNumber(ctx context.Context) (uint64, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error)
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+}
+
+type Config struct {
+	Key           *ecdsa.PrivateKey
+	Confirmations uint64        // blocks on top of a mined transaction before it is confirmed
+	BumpAfter     time.Duration // wait for a broadcast to be mined before raising its gas price
+	BumpPercent   int64         // gas price increase per bump
+	MaxGasPrice   *big.Int      // gas prices are never bumped above this; nil for no limit
+}
+
+// Queue sends transactions from one account on one chain.
+type Queue struct {
+	db      *gorm.DB
+	client  Client
+	cfg     Config
+	sender  common.Address
+	chainID *big.Int
+}
+
+// New creates a queue for the account of cfg.Key on the chain of client.
+func New(ctx context.Context, db *gorm.DB, client Client, cfg Config) (*Queue, error) {
+	if cfg.Key == nil {
+		return nil, errors.New("sender key is required")
+	}
+	if cfg.BumpAfter == 0 {
+		cfg.BumpAfter = 2 * time.Minute
+	}
+	if cfg.BumpPercent < 10 {
+		// Nodes reject replacements that pay less than 10% more
+		cfg.BumpPercent = 20
+	}
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Queue{
+		db:      db,
+		client:  client,
+		cfg:     cfg,
+		sender:  crypto.PubkeyToAddress(cfg.Key.PublicKey),
+		chainID: chainID,
+	}, nil
+}
+
+// Sender returns the address transactions are sent from.
+func (q *Queue) Sender() common.Address {
+	return q.sender
+}
+
+// ChainID returns the ID of the chain transactions are sent to.
+func (q *Queue) ChainID() *big.Int {
+	return q.chainID
+}
+
+// Request is a contract call to send.
+type Request struct {
+	To        common.Address
+	Data      []byte
+	Value     *big.Int // nil for none
+	Reference string   // what the transaction is for, e.g. bridge_message:12
+}
+
+// Enqueue signs req with the sender's next nonce and stores it using db,
+// which may be a database transaction of the caller: the nonce is only
+// allocated if that transaction commits. The stored transaction is sent by
+// Broadcast, or by the next Sync.
+func (q *Queue) Enqueue(ctx context.Context, db *gorm.DB, req Request) (*models.ChainSubmission, error) {
+	value := req.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	gas, err := q.client.EstimateGas(ctx, ethereum.CallMsg{From: q.sender, To: &req.To, Value: value, Data: req.Data})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrEstimateGas, err)
+	}
+	gasPrice, err := q.client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if q.cfg.MaxGasPrice != nil && gasPrice.Cmp(q.cfg.MaxGasPrice) > 0 {
+		gasPrice = new(big.Int).Set(q.cfg.MaxGasPrice)
+	}
+
+	var submission *models.ChainSubmission
+	err = db.Transaction(func(tx *gorm.DB) error {
+		nonce, err := q.allocateNonce(ctx, tx)
+		if err != nil {
+			return err
+		}
+		signed, err := q.sign(nonce, req.To, value, gas, gasPrice, req.Data)
+		if err != nil {
+			return err
+		}
+		raw, err := signed.MarshalBinary()
+		if err != nil {
+			return err
+		}
+
+		submission = &models.ChainSubmission{
+			ChainID:        q.chainID.Uint64(),
+			Sender:         q.sender.Hex(),
+			Nonce:          nonce,
+			To:             req.To.Hex(),
+			Data:           req.Data,
+			Value:          value.String(),
+			Gas:            gas,
+			GasPrice:       gasPrice.String(),
+			TxHash:         signed.Hash().Hex(),
+			RawTransaction: raw,
+			Reference:      req.Reference,
+			Status:         models.SubmissionPending,
+			SignedAt:       time.Now(),
+		}
+		return tx.Create(submission).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return submission, nil
+}
+
+// Broadcast sends the stored transaction of a pending submission. Failures
+// are recorded on the submission, which is broadcast again on the next Sync.
+func (q *Queue) Broadcast(ctx context.Context, submission *models.ChainSubmission) error {
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(submission.RawTransaction); err != nil {
+		return err
+	}
+	return q.send(ctx, submission, &tx)
+}
+
+// send broadcasts tx, the current transaction of submission.
+func (q *Queue) send(ctx context.Context, submission *models.ChainSubmission, tx *types.Transaction) error {
+	changes := map[string]interface{}{"broadcasts": submission.Broadcasts + 1}
+	if err := q.client.SendTransaction(ctx, tx); err != nil && !isKnownTransaction(err) {
+		log.Printf("Transaction queue: broadcasting %s failed: %v", tx.Hash().Hex(), err)
+		changes["last_error"] = err.Error()
+	}
+	return q.db.Model(submission).Updates(changes).Error
+}
+
+// Sync advances the sender's pending and mined submissions, lowest nonce
+// first.
+func (q *Queue) Sync(ctx context.Context) error {
+	var submissions []models.ChainSubmission
+	if err := q.db.Where("chain_id = ? AND sender = ? AND status IN ?", q.chainID.Uint64(), q.sender.Hex(),
+		[]string{models.SubmissionPending, models.SubmissionMined}).
+		Order("nonce").
+		Find(&submissions).Error; err != nil {
+		return err
+	}
+	if len(submissions) == 0 {
+		return nil
+	}
+
+	// Read the nonce before any receipt: a nonce that is used while none of
+	// a submission's broadcasts has a receipt went to another transaction
+	confirmed, err := q.client.NonceAt(ctx, q.sender, nil)
+	if err != nil {
+		return err
+	}
+	head, err := q.client.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := range submissions {
+		submission := &submissions[i]
+		switch submission.Status {
+		case models.SubmissionPending:
+			err = q.checkPending(ctx, submission, confirmed)
+		case models.SubmissionMined:
+			err = q.checkMined(ctx, submission, head)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get returns a submission by ID.
+func (q *Queue) Get(id uint) (*models.ChainSubmission, error) {
+	var submission models.ChainSubmission
+	if err := q.db.First(&submission, id).Error; err != nil {
+		return nil, err
+	}
+	return &submission, nil
+}
+
+// checkPending looks for a receipt of any of the submission's broadcasts.
+// Without one, the submission is dropped if its nonce has been used,
+// replaced at a higher gas price once BumpAfter has passed, and broadcast
+// again otherwise.
+func (q *Queue) checkPending(ctx context.Context, submission *models.ChainSubmission, confirmedNonce uint64) error {
+	hashes := append(strings.Fields(submission.ReplacedTxHashes), submission.TxHash)
+	for _, hash := range hashes {
+		receipt, err := q.client.TransactionReceipt(ctx, common.HexToHash(hash))
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		return q.mined(submission, receipt)
+	}
+
+	if confirmedNonce > submission.Nonce {
+		log.Printf("Transaction queue: nonce %d of %s was used by another transaction, %s dropped",
+			submission.Nonce, submission.Sender, submission.TxHash)
+		return q.db.Model(submission).Updates(map[string]interface{}{
+			"status":     models.SubmissionDropped,
+			"last_error": "nonce used by another transaction",
+		}).Error
+	}
+
+	if time.Since(submission.SignedAt) >= q.cfg.BumpAfter {
+		return q.bump(ctx, submission)
+	}
+	return q.Broadcast(ctx, submission)
+}
+
+// mined records the receipt of one of the submission's broadcasts.
+func (q *Queue) mined(submission *models.ChainSubmission, receipt *types.Receipt) error {
+	blockNumber := receipt.BlockNumber.Uint64()
+	return q.db.Model(submission).Updates(map[string]interface{}{
+		"status":       models.SubmissionMined,
+		"tx_hash":      receipt.TxHash.Hex(),
+		"block_number": blockNumber,
+		"succeeded":    receipt.Status == types.ReceiptStatusSuccessful,
+		"mined_at":     time.Now(),
+	}).Error
+}
+
+// checkMined confirms a mined submission once its block is deep enough. A
+// transaction that was reorganized out of the chain is pending again.
+func (q *Queue) checkMined(ctx context.Context, submission *models.ChainSubmission, head uint64) error {
+	receipt, err := q.client.TransactionReceipt(ctx, common.HexToHash(submission.TxHash))
+	if errors.Is(err, ethereum.NotFound) {
+		log.Printf("Transaction queue: %s was reorganized out of the chain", submission.TxHash)
+		return q.db.Model(submission).Updates(map[string]interface{}{
+			"status":       models.SubmissionPending,
+			"block_number": nil,
+			"mined_at":     nil,
+		}).Error
+	}
+	if err != nil {
+		return err
+	}
+
+	blockNumber := receipt.BlockNumber.Uint64()
+	if blockNumber+q.cfg.Confirmations > head {
+		if submission.BlockNumber == nil || *submission.BlockNumber != blockNumber {
+			return q.mined(submission, receipt)
+		}
+		return nil
+	}
+
+	status := models.SubmissionConfirmed
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		status = models.SubmissionReverted
+	}
+	return q.db.Model(submission).Updates(map[string]interface{}{
+		"status":       status,
+		"block_number": blockNumber,
+		"succeeded":    receipt.Status == types.ReceiptStatusSuccessful,
+	}).Error
+}
+
+// bump replaces the submission's transaction with the same one at a higher
+// gas price.
+func (q *Queue) bump(ctx context.Context, submission *models.ChainSubmission) error {
+	var stuck types.Transaction
+	if err := stuck.UnmarshalBinary(submission.RawTransaction); err != nil {
+		return err
+	}
+
+	gasPrice := new(big.Int).Mul(stuck.GasPrice(), big.NewInt(100+q.cfg.BumpPercent))
+	gasPrice.Div(gasPrice, big.NewInt(100))
+	suggested, err := q.client.SuggestGasPrice(ctx)
+	if err != nil {
+		return err
+	}
+	if suggested.Cmp(gasPrice) > 0 {
+		gasPrice = suggested
+	}
+	if q.cfg.MaxGasPrice != nil && gasPrice.Cmp(q.cfg.MaxGasPrice) > 0 {
+		if stuck.GasPrice().Cmp(q.cfg.MaxGasPrice) >= 0 {
+			return q.Broadcast(ctx, submission)
+		}
+		gasPrice = new(big.Int).Set(q.cfg.MaxGasPrice)
+	}
+
+	tx, err := q.sign(stuck.Nonce(), *stuck.To(), stuck.Value(), stuck.Gas(), gasPrice, stuck.Data())
+	if err != nil {
+		return err
+	}
+	raw, err := tx.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	log.Printf("Transaction queue: replacing %s with %s at gas price %s", stuck.Hash().Hex(), tx.Hash().Hex(), gasPrice)
+
+	if err := q.db.Model(submission).Updates(map[string]interface{}{
+		"gas_price":          gasPrice.String(),
+		"tx_hash":            tx.Hash().Hex(),
+		"replaced_tx_hashes": strings.TrimSpace(submission.ReplacedTxHashes + " " + stuck.Hash().Hex()),
+		"raw_transaction":    raw,
+		"signed_at":          time.Now(),
+		"bumps":              submission.Bumps + 1,
+	}).Error; err != nil {
+		return err
+	}
+	return q.send(ctx, submission, tx)
+}
+
+// allocateNonce takes the sender's next nonce, with its row locked until tx
+// ends. The node's pending nonce is used instead when it is ahead, e.g.
+// after the account sent transactions outside the queue.
+func (q *Queue) allocateNonce(ctx context.Context, tx *gorm.DB) (uint64, error) {
+	row := models.SenderNonce{ChainID: q.chainID.Uint64(), Sender: q.sender.Hex()}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&row).Error; err != nil {
+		return 0, err
+	}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("chain_id = ? AND sender = ?", row.ChainID, row.Sender).
+		First(&row).Error; err != nil {
+		return 0, err
+	}
+
+	pending, err := q.client.PendingNonceAt(ctx, q.sender)
+	if err != nil {
+		return 0, err
+	}
+	nonce := row.NextNonce
+	if pending > nonce {
+		nonce = pending
+	}
+	if err := tx.Model(&row).Update("next_nonce", nonce+1).Error; err != nil {
+		return 0, err
+	}
+	return nonce, nil
+}
+
+func (q *Queue) sign(nonce uint64, to common.Address, value *big.Int, gas uint64, gasPrice *big.Int, data []byte) (*types.Transaction, error) {
+	return types.SignTx(types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		GasPrice: gasPrice,
+		Gas:      gas,
+		To:       &to,
+		Value:    value,
+		Data:     data,
+	}), types.LatestSignerForChainID(q.chainID), q.cfg.Key)
+}
+
+// isKnownTransaction reports whether a node rejected a broadcast because it
+// already has the transaction.
+func isKnownTransaction(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "already known") || strings.Contains(msg, "known transaction")
+}
@@ -0,0 +1,204 @@
+package txqueue
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"aa-sharing-backend/internal/chaintest"
+	"aa-sharing-backend/internal/models"
+	"aa-sharing-backend/internal/testdb"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+	"gorm.io/gorm"
+)
+
+const testConfirmations = 2
+
+// setup returns a queue sending from a new funded account, and the address
+// its test transactions pay.
+func setup(t *testing.T, cfg Config) (*gorm.DB, *chaintest.Chain, *Queue, common.Address) {
+	t.Helper()
+	db := testdb.Open(t)
+	c := chaintest.New(t)
+	cfg.Key = c.Account(t)
+	cfg.Confirmations = testConfirmations
+	q, err := New(context.Background(), db, c.Client, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payee, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, c, q, crypto.PubkeyToAddress(payee.PublicKey)
+}
+
+func enqueue(t *testing.T, q *Queue, db *gorm.DB, to common.Address, wei int64) *models.ChainSubmission {
+	t.Helper()
+	submission, err := q.Enqueue(context.Background(), db, Request{To: to, Value: big.NewInt(wei), Reference: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return submission
+}
+
+func runSync(t *testing.T, q *Queue) {
+	t.Helper()
+	if err := q.Sync(context.Background()); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+}
+
+func reload(t *testing.T, q *Queue, submission *models.ChainSubmission) *models.ChainSubmission {
+	t.Helper()
+	reloaded, err := q.Get(submission.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return reloaded
+}
+
+func TestQueueSendsInNonceOrder(t *testing.T) {
+	db, c, q, payee := setup(t, Config{})
+	ctx := context.Background()
+
+	// A nonce taken in a rolled back transaction is not used up
+	rollback := errors.New("rollback")
+	err := db.Transaction(func(tx *gorm.DB) error {
+		enqueue(t, q, tx, payee, 1)
+		return rollback
+	})
+	if !errors.Is(err, rollback) {
+		t.Fatal(err)
+	}
+
+	var submissions []*models.ChainSubmission
+	for i := int64(1); i <= 3; i++ {
+		submissions = append(submissions, enqueue(t, q, db, payee, i))
+	}
+	for i, submission := range submissions {
+		if submission.Nonce != uint64(i) {
+			t.Errorf("submission %d has nonce %d, want %d", i, submission.Nonce, i)
+		}
+	}
+
+	// Stored but never broadcast: the first Sync sends them, lowest nonce
+	// first, and the dev node mines each one as it arrives
+	runSync(t, q)
+	runSync(t, q)
+	for _, submission := range submissions {
+		if got := reload(t, q, submission); got.Status != models.SubmissionMined || !got.Succeeded {
+			t.Errorf("nonce %d is %s, want mined", got.Nonce, got.Status)
+		}
+	}
+
+	c.MineBlocks(t, testConfirmations)
+	runSync(t, q)
+	for _, submission := range submissions {
+		if got := reload(t, q, submission); got.Status != models.SubmissionConfirmed {
+			t.Errorf("nonce %d is %s, want confirmed", got.Nonce, got.Status)
+		}
+	}
+	if balance, err := c.Client.BalanceAt(ctx, payee, nil); err != nil || balance.Int64() != 6 {
+		t.Errorf("payee received %v wei (%v), want 6", balance, err)
+	}
+}
+
+func TestQueueDropsSubmissionWhoseNonceWasUsed(t *testing.T) {
+	db, c, q, payee := setup(t, Config{})
+	ctx := context.Background()
+
+	stored := enqueue(t, q, db, payee, 1)
+
+	// The account spends the nonce outside the queue before the stored
+	// transaction is broadcast
+	tx, err := types.SignNewTx(q.cfg.Key, types.LatestSignerForChainID(q.ChainID()), &types.LegacyTx{
+		Nonce:    stored.Nonce,
+		GasPrice: big.NewInt(params.GWei * 10),
+		Gas:      params.TxGas,
+		To:       &payee,
+		Value:    big.NewInt(100),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Mined(t)(tx, c.Client.SendTransaction(ctx, tx))
+
+	runSync(t, q)
+	if got := reload(t, q, stored); got.Status != models.SubmissionDropped {
+		t.Fatalf("submission is %s, want dropped", got.Status)
+	}
+
+	// The next request skips the used nonce
+	next := enqueue(t, q, db, payee, 2)
+	if next.Nonce != stored.Nonce+1 {
+		t.Errorf("next nonce %d, want %d", next.Nonce, stored.Nonce+1)
+	}
+	if err := q.Broadcast(ctx, next); err != nil {
+		t.Fatal(err)
+	}
+	c.Receipt(t, common.HexToHash(next.TxHash))
+}
+
+func TestQueueReplacesStuckTransaction(t *testing.T) {
+	db, c, q, payee := setup(t, Config{BumpAfter: time.Nanosecond})
+
+	// Never broadcast, as if the node had lost it: the next Sync signs it
+	// again at a higher gas price
+	stuck := enqueue(t, q, db, payee, 1)
+	runSync(t, q)
+
+	replaced := reload(t, q, stuck)
+	if replaced.Bumps != 1 || replaced.ReplacedTxHashes != stuck.TxHash || replaced.TxHash == stuck.TxHash {
+		t.Fatalf("bumps %d, replaced %q, hash %s", replaced.Bumps, replaced.ReplacedTxHashes, replaced.TxHash)
+	}
+	oldPrice, _ := new(big.Int).SetString(stuck.GasPrice, 10)
+	newPrice, _ := new(big.Int).SetString(replaced.GasPrice, 10)
+	if newPrice.Cmp(oldPrice) <= 0 {
+		t.Errorf("gas price %s after bump, was %s", newPrice, oldPrice)
+	}
+
+	c.Receipt(t, common.HexToHash(replaced.TxHash))
+	runSync(t, q)
+	if got := reload(t, q, stuck); got.Status != models.SubmissionMined || got.TxHash != replaced.TxHash || got.Nonce != stuck.Nonce {
+		t.Errorf("submission %s with %s at nonce %d, want the replacement mined", got.Status, got.TxHash, got.Nonce)
+	}
+}
+
+func TestQueueRebroadcastsReorganizedTransaction(t *testing.T) {
+	db, c, q, payee := setup(t, Config{})
+
+	snapshot := c.Snapshot(t)
+	submission := enqueue(t, q, db, payee, 1)
+	if err := q.Broadcast(context.Background(), submission); err != nil {
+		t.Fatal(err)
+	}
+	c.Receipt(t, common.HexToHash(submission.TxHash))
+	runSync(t, q)
+	if got := reload(t, q, submission); got.Status != models.SubmissionMined {
+		t.Fatalf("submission is %s, want mined", got.Status)
+	}
+
+	// A longer branch without the transaction replaces its block
+	c.Revert(t, snapshot)
+	c.MineBlocks(t, 3)
+	runSync(t, q)
+	if got := reload(t, q, submission); got.Status != models.SubmissionPending || got.BlockNumber != nil {
+		t.Fatalf("submission is %s at block %v, want pending", got.Status, got.BlockNumber)
+	}
+
+	runSync(t, q)
+	c.Receipt(t, common.HexToHash(submission.TxHash))
+	c.MineBlocks(t, testConfirmations)
+	runSync(t, q)
+	runSync(t, q)
+	if got := reload(t, q, submission); got.Status != models.SubmissionConfirmed {
+		t.Errorf("submission is %s, want confirmed", got.Status)
+	}
+}
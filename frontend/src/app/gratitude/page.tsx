@@ -6,6 +6,7 @@ import { useContracts, Partnership } from '@/hooks/useContracts';
 import UserInfo from '@/components/user-info';
 import BackgroundImage from '@/components/background-image';
 import { MockAPI } from '@/lib/mock-api';
+import { usdc } from '@/lib/money';
 
 interface GratitudeItem {
   content: string;
@@ -120,7 +121,7 @@ export default function GratitudePage() {
           await MockAPI.createGratitude({
             userId: user?.id || 'anonymous',
             content: item.content,
-            amount: item.amount,
+            amount: usdc(item.amount),
           });
         }
       }
@@ -5,6 +5,7 @@ import { usePrivy } from '@privy-io/react-auth';
 import BackgroundImage from '@/components/background-image';
 import CrossChainWidget from '@/components/cross-chain-widget';
 import { MockAPI } from '@/lib/mock-api';
+import { formatMoney, usdc } from '@/lib/money';
 
 export default function WalletPage() {
   const { authenticated, ready, login } = usePrivy();
@@ -18,16 +19,18 @@ export default function WalletPage() {
     setIsLoading(true);
     
     try {
+      const amount = usdc(contributionAmount);
+
       // 使用模拟API更新钱包余额
-      await MockAPI.updateWalletBalance('partnership-1', contributionAmount);
+      await MockAPI.updateWalletBalance('partnership-1', amount);
       
       // 创建交易记录
       await MockAPI.createTransaction({
         userId: 'user-1',
         partnershipId: 'partnership-1',
         type: 'contribution',
-        amount: contributionAmount,
-        description: `Manual wallet contribution: $${contributionAmount}`,
+        amount,
+        description: `Manual wallet contribution: ${formatMoney(amount)}`,
         status: 'confirmed',
       });
       
@@ -1,4 +1,7 @@
 // 模拟后端API服务
+// Amounts use the backend's Money shape, see ./money.
+import { Money, addMoney, usdc } from './money';
+
 export interface User {
   id: string;
   email: string;
@@ -10,13 +13,13 @@ export interface GratitudeEntry {
   id: string;
   userId: string;
   content: string;
-  amount: number;
+  amount: Money;
   createdAt: string;
 }
 
 export interface WalletBalance {
   partnershipId: string;
-  balance: number;
+  balance: Money;
   lastUpdated: string;
 }
 
@@ -25,8 +28,8 @@ export interface Goal {
   partnershipId: string;
   name: string;
   description: string;
-  targetAmount: number;
-  currentAmount: number;
+  targetAmount: Money;
+  currentAmount: Money;
   status: 'active' | 'completed' | 'cancelled';
 }
 
@@ -35,7 +38,7 @@ export interface Transaction {
   userId: string;
   partnershipId: string;
   type: 'gratitude' | 'contribution' | 'split';
-  amount: number;
+  amount: Money;
   description: string;
   status: 'pending' | 'confirmed' | 'failed';
   createdAt: string;
@@ -52,8 +55,8 @@ let mockData = {
       partnershipId: 'partnership-1',
       name: 'Trip to Bali',
       description: 'Our dream vacation together',
-      targetAmount: 2000,
-      currentAmount: 215.75,
+      targetAmount: usdc('2000'),
+      currentAmount: usdc('215.75'),
       status: 'active' as const,
     }
   ] as Goal[],
@@ -118,7 +121,7 @@ export class MockAPI {
     if (!balance) {
       balance = {
         partnershipId,
-        balance: 150.75,
+        balance: usdc('150.75'),
         lastUpdated: new Date().toISOString(),
       };
       mockData.walletBalances.push(balance);
@@ -126,18 +129,18 @@ export class MockAPI {
     return balance;
   }
 
-  static async updateWalletBalance(partnershipId: string, amount: number): Promise<WalletBalance> {
+  static async updateWalletBalance(partnershipId: string, amount: Money): Promise<WalletBalance> {
     await delay(500);
     let balance = mockData.walletBalances.find(w => w.partnershipId === partnershipId);
     if (!balance) {
       balance = {
         partnershipId,
-        balance: 150.75 + amount,
+        balance: addMoney(usdc('150.75'), amount),
         lastUpdated: new Date().toISOString(),
       };
       mockData.walletBalances.push(balance);
     } else {
-      balance.balance += amount;
+      balance.balance = addMoney(balance.balance, amount);
       balance.lastUpdated = new Date().toISOString();
     }
     
@@ -197,8 +200,8 @@ export class MockAPI {
           partnershipId: 'partnership-1',
           name: 'Trip to Bali',
           description: 'Our dream vacation together',
-          targetAmount: 2000,
-          currentAmount: 215.75,
+          targetAmount: usdc('2000'),
+          currentAmount: usdc('215.75'),
           status: 'active',
         }
       ],
@@ -0,0 +1,56 @@
+// Amounts as the backend API (/api/v1) sends and accepts them: an exact
+// number of base units of the currency, e.g. 1 USDC is { units: 1000000 }.
+// `value` is the same amount as a decimal string for display. Do arithmetic
+// on `units` only, never on `value` or on floats.
+export interface Money {
+  units: number;
+  currency: string;
+  decimals: number;
+  value: string;
+}
+
+const USDC_DECIMALS = 6;
+
+// Builds a Money from base units.
+export function money(units: number, currency = 'USDC', decimals = USDC_DECIMALS): Money {
+  if (!Number.isSafeInteger(units)) {
+    throw new Error(`Amount out of range: ${units}`);
+  }
+  const sign = units < 0 ? '-' : '';
+  const digits = Math.abs(units).toString().padStart(decimals + 1, '0');
+  const value = decimals === 0
+    ? sign + digits
+    : `${sign}${digits.slice(0, -decimals)}.${digits.slice(-decimals)}`;
+  return { units, currency, decimals, value };
+}
+
+// Parses a user-entered decimal amount such as "12.50" or 12.5 into USDC.
+// Going through the decimal text keeps 0.1 + 0.2 style float errors out of
+// the amount.
+export function usdc(amount: number | string): Money {
+  const text = typeof amount === 'number' ? amount.toFixed(USDC_DECIMALS) : amount.trim();
+  const match = /^(-?)(\d*)(?:\.(\d*))?$/.exec(text);
+  if (!match || (match[2] === '' && !match[3])) {
+    throw new Error(`Invalid amount: ${amount}`);
+  }
+  const [, sign, whole, frac = ''] = match;
+  if (frac.length > USDC_DECIMALS) {
+    throw new Error(`USDC supports at most ${USDC_DECIMALS} decimals`);
+  }
+  const units = Number((whole || '0') + frac.padEnd(USDC_DECIMALS, '0'));
+  return money(sign ? -units : units);
+}
+
+export function addMoney(a: Money, b: Money): Money {
+  if (a.currency !== b.currency) {
+    throw new Error(`Currency mismatch: ${a.currency} and ${b.currency}`);
+  }
+  return money(a.units + b.units, a.currency, a.decimals);
+}
+
+// Formats an amount for display, e.g. "12.50 USDC".
+export function formatMoney(m: Money, fractionDigits = 2): string {
+  const [whole, frac = ''] = m.value.split('.');
+  const shown = fractionDigits > 0 ? `${whole}.${frac.padEnd(fractionDigits, '0').slice(0, fractionDigits)}` : whole;
+  return `${shown} ${m.currency}`;
+}
@@ -2,16 +2,19 @@
 pragma solidity ^0.8.24;
 
 import "@openzeppelin/contracts/token/ERC20/IERC20.sol";
+import "@openzeppelin/contracts/token/ERC20/extensions/IERC20Permit.sol";
 import "@openzeppelin/contracts/utils/ReentrancyGuard.sol";
 import "@openzeppelin/contracts/access/Ownable.sol";
 import "@openzeppelin/contracts/utils/Pausable.sol";
+import "@openzeppelin/contracts/utils/cryptography/EIP712.sol";
+import "@openzeppelin/contracts/utils/cryptography/ECDSA.sol";
 
 /**
  * @title AASharing - A Love & Gratitude Ledger
  * @dev Smart contract for couples/friends to record gratitude and share funds
  * @author AA Sharing Team
  */
-contract AASharing is ReentrancyGuard, Ownable, Pausable {
+contract AASharing is ReentrancyGuard, Ownable, Pausable, EIP712 {
     // USDC Token interface
     IERC20 public immutable usdc;
     
@@ -36,7 +39,7 @@ contract AASharing is ReentrancyGuard, Ownable, Pausable {
         uint256 indexed partnershipId,
         address indexed depositor,
         uint256 amount,
-        string source // "gratitude", "direct", "cross-chain"
+        string source // "gratitude", "direct", "cross-chain", "gasless"
     );
     
     event FundsWithdrawn(
@@ -93,6 +96,11 @@ contract AASharing is ReentrancyGuard, Ownable, Pausable {
     mapping(uint256 => mapping(uint256 => Goal)) public partnershipGoals;
     mapping(address => uint256[]) public userPartnerships;
     mapping(bytes32 => bool) public usedNonces; // For cross-chain deposits
+    mapping(address => uint256) public contributionNonces; // For signed deposits
+    
+    bytes32 public constant CONTRIBUTION_TYPEHASH = keccak256(
+        "Contribution(uint256 partnershipId,address contributor,uint256 amount,uint256 nonce,uint256 deadline)"
+    );
     
     // Modifiers
     modifier onlyPartner(uint256 partnershipId) {
@@ -110,7 +118,7 @@ contract AASharing is ReentrancyGuard, Ownable, Pausable {
         _;
     }
     
-    constructor(address _usdcToken) Ownable(msg.sender) {
+    constructor(address _usdcToken) Ownable(msg.sender) EIP712("AASharing", "1") {
         require(_usdcToken != address(0), "Invalid USDC token address");
         usdc = IERC20(_usdcToken);
     }
@@ -197,6 +205,49 @@ contract AASharing is ReentrancyGuard, Ownable, Pausable {
         emit FundsDeposited(partnershipId, msg.sender, amount, "direct");
     }
     
+    /**
+     * @dev Deposit on behalf of a partner who signed an EIP-712 Contribution,
+     * so that a relayer can pay the gas. The contributor also signs an
+     * EIP-2612 permit of the amount for this contract with the same deadline,
+     * so they never send an approve transaction either. A permit that fails,
+     * e.g. because someone submitted it first, is ignored; the transfer then
+     * goes through as long as the allowance is there.
+     */
+    function depositFundsWithSignature(
+        uint256 partnershipId,
+        address contributor,
+        uint256 amount,
+        uint256 nonce,
+        uint256 deadline,
+        bytes calldata signature,
+        uint8 permitV,
+        bytes32 permitR,
+        bytes32 permitS
+    ) external validPartnership(partnershipId) whenNotPaused nonReentrant {
+        require(block.timestamp <= deadline, "Signature expired");
+        require(amount > 0, "Amount must be greater than 0");
+        require(nonce == contributionNonces[contributor], "Invalid nonce");
+        require(
+            contributor == partnerships[partnershipId].partner1 || contributor == partnerships[partnershipId].partner2,
+            "Not a partner in this partnership"
+        );
+        
+        bytes32 structHash = keccak256(
+            abi.encode(CONTRIBUTION_TYPEHASH, partnershipId, contributor, amount, nonce, deadline)
+        );
+        require(ECDSA.recover(_hashTypedDataV4(structHash), signature) == contributor, "Invalid signature");
+        
+        contributionNonces[contributor]++;
+        try IERC20Permit(address(usdc)).permit(contributor, address(this), amount, deadline, permitV, permitR, permitS) {
+        } catch {}
+        require(usdc.transferFrom(contributor, address(this), amount), "USDC transfer failed");
+        
+        partnerships[partnershipId].totalBalance += amount;
+        totalUSDCLocked += amount;
+        
+        emit FundsDeposited(partnershipId, contributor, amount, "gasless");
+    }
+    
     /**
      * @dev Cross-chain deposit with nonce to prevent replay attacks
      */
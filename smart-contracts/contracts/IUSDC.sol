@@ -0,0 +1,16 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.24;
+
+import "@openzeppelin/contracts/token/ERC20/extensions/IERC20Metadata.sol";
+import "@openzeppelin/contracts/token/ERC20/extensions/IERC20Permit.sol";
+import "@openzeppelin/contracts/interfaces/IERC5267.sol";
+
+/**
+ * @title IUSDC
+ * @dev The parts of the USDC token the backend uses: ERC-20 with EIP-2612
+ * permits. OpenZeppelin tokens such as MockUSDC describe their EIP-712 domain
+ * through eip712Domain (ERC-5267); Circle's USDC through name and version.
+ */
+interface IUSDC is IERC20Metadata, IERC20Permit, IERC5267 {
+    function version() external view returns (string memory);
+}
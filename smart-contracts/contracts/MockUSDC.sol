@@ -2,20 +2,22 @@
 pragma solidity ^0.8.24;
 
 import "@openzeppelin/contracts/token/ERC20/ERC20.sol";
+import "@openzeppelin/contracts/token/ERC20/extensions/ERC20Permit.sol";
 import "@openzeppelin/contracts/access/Ownable.sol";
 
 /**
  * @title MockUSDC
- * @dev Mock USDC token for testing purposes
+ * @dev Mock USDC token for testing purposes. Like USDC it supports EIP-2612
+ * permits, which gasless deposits rely on.
  */
-contract MockUSDC is ERC20, Ownable {
+contract MockUSDC is ERC20, ERC20Permit, Ownable {
     uint8 private _decimals;
 
     constructor(
         string memory name,
         string memory symbol,
         uint8 decimals_
-    ) ERC20(name, symbol) Ownable(msg.sender) {
+    ) ERC20(name, symbol) ERC20Permit(name) Ownable(msg.sender) {
         _decimals = decimals_;
     }
 
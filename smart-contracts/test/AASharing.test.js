@@ -151,6 +151,176 @@ describe("AASharing Contract", function () {
     });
   });
 
+  describe("Signed Deposits", function () {
+    let partnershipId;
+    let domain;
+    let permitDomain;
+    const types = {
+      Contribution: [
+        { name: "partnershipId", type: "uint256" },
+        { name: "contributor", type: "address" },
+        { name: "amount", type: "uint256" },
+        { name: "nonce", type: "uint256" },
+        { name: "deadline", type: "uint256" }
+      ]
+    };
+    const permitTypes = {
+      Permit: [
+        { name: "owner", type: "address" },
+        { name: "spender", type: "address" },
+        { name: "value", type: "uint256" },
+        { name: "nonce", type: "uint256" },
+        { name: "deadline", type: "uint256" }
+      ]
+    };
+
+    beforeEach(async function () {
+      await aaSharing.connect(partner1).createPartnership(
+        partner2.address,
+        "Alice",
+        "Bob"
+      );
+      partnershipId = 1;
+
+      const chainId = (await ethers.provider.getNetwork()).chainId;
+      domain = {
+        name: "AASharing",
+        version: "1",
+        chainId,
+        verifyingContract: await aaSharing.getAddress()
+      };
+      permitDomain = {
+        name: "Mock USDC",
+        version: "1",
+        chainId,
+        verifyingContract: await mockUSDC.getAddress()
+      };
+    });
+
+    async function signContribution(signer, nonce, deadline) {
+      const value = {
+        partnershipId,
+        contributor: partner1.address,
+        amount: USDC_AMOUNT,
+        nonce,
+        deadline
+      };
+      return signer.signTypedData(domain, types, value);
+    }
+
+    async function signPermit(signer, deadline) {
+      const value = {
+        owner: partner1.address,
+        spender: await aaSharing.getAddress(),
+        value: USDC_AMOUNT,
+        nonce: await mockUSDC.nonces(partner1.address),
+        deadline
+      };
+      const { v, r, s } = ethers.Signature.from(
+        await signer.signTypedData(permitDomain, permitTypes, value)
+      );
+      return [v, r, s];
+    }
+
+    it("Should deposit for a partner through a relayer", async function () {
+      const deadline = (await time.latest()) + 3600;
+      const signature = await signContribution(partner1, 0, deadline);
+      const permit = await signPermit(partner1, deadline);
+
+      const tx = await aaSharing.connect(otherUser).depositFundsWithSignature(
+        partnershipId, partner1.address, USDC_AMOUNT, 0, deadline, signature, ...permit
+      );
+
+      await expect(tx)
+        .to.emit(aaSharing, "FundsDeposited")
+        .withArgs(partnershipId, partner1.address, USDC_AMOUNT, "gasless");
+
+      const partnership = await aaSharing.getPartnership(partnershipId);
+      expect(partnership.totalBalance).to.equal(USDC_AMOUNT);
+      expect(await aaSharing.contributionNonces(partner1.address)).to.equal(1);
+      expect(await mockUSDC.nonces(partner1.address)).to.equal(1);
+      expect(await mockUSDC.allowance(partner1.address, await aaSharing.getAddress())).to.equal(0);
+    });
+
+    it("Should deposit when the permit was submitted first", async function () {
+      const deadline = (await time.latest()) + 3600;
+      const signature = await signContribution(partner1, 0, deadline);
+      const permit = await signPermit(partner1, deadline);
+
+      await mockUSDC.connect(otherUser).permit(
+        partner1.address, await aaSharing.getAddress(), USDC_AMOUNT, deadline, ...permit
+      );
+      await expect(
+        aaSharing.connect(otherUser).depositFundsWithSignature(
+          partnershipId, partner1.address, USDC_AMOUNT, 0, deadline, signature, ...permit
+        )
+      ).to.emit(aaSharing, "FundsDeposited");
+    });
+
+    it("Should deposit with an existing allowance and no permit", async function () {
+      const deadline = (await time.latest()) + 3600;
+      const signature = await signContribution(partner1, 0, deadline);
+      await mockUSDC.connect(partner1).approve(await aaSharing.getAddress(), USDC_AMOUNT);
+
+      await expect(
+        aaSharing.connect(otherUser).depositFundsWithSignature(
+          partnershipId, partner1.address, USDC_AMOUNT, 0, deadline, signature, 0, ethers.ZeroHash, ethers.ZeroHash
+        )
+      ).to.emit(aaSharing, "FundsDeposited");
+    });
+
+    it("Should fail without a permit or an allowance", async function () {
+      const deadline = (await time.latest()) + 3600;
+      const signature = await signContribution(partner1, 0, deadline);
+      const permit = await signPermit(otherUser, deadline);
+
+      await expect(
+        aaSharing.connect(otherUser).depositFundsWithSignature(
+          partnershipId, partner1.address, USDC_AMOUNT, 0, deadline, signature, ...permit
+        )
+      ).to.be.revertedWithCustomError(mockUSDC, "ERC20InsufficientAllowance");
+    });
+
+    it("Should fail to replay a signature", async function () {
+      const deadline = (await time.latest()) + 3600;
+      const signature = await signContribution(partner1, 0, deadline);
+      const permit = await signPermit(partner1, deadline);
+
+      await aaSharing.connect(otherUser).depositFundsWithSignature(
+        partnershipId, partner1.address, USDC_AMOUNT, 0, deadline, signature, ...permit
+      );
+      await expect(
+        aaSharing.connect(otherUser).depositFundsWithSignature(
+          partnershipId, partner1.address, USDC_AMOUNT, 0, deadline, signature, ...permit
+        )
+      ).to.be.revertedWith("Invalid nonce");
+    });
+
+    it("Should fail with a signature from someone else", async function () {
+      const deadline = (await time.latest()) + 3600;
+      const signature = await signContribution(otherUser, 0, deadline);
+      const permit = await signPermit(partner1, deadline);
+
+      await expect(
+        aaSharing.connect(otherUser).depositFundsWithSignature(
+          partnershipId, partner1.address, USDC_AMOUNT, 0, deadline, signature, ...permit
+        )
+      ).to.be.revertedWith("Invalid signature");
+    });
+
+    it("Should fail after the deadline", async function () {
+      const deadline = (await time.latest()) - 1;
+      const signature = await signContribution(partner1, 0, deadline);
+      const permit = await signPermit(partner1, deadline);
+
+      await expect(
+        aaSharing.connect(otherUser).depositFundsWithSignature(
+          partnershipId, partner1.address, USDC_AMOUNT, 0, deadline, signature, ...permit
+        )
+      ).to.be.revertedWith("Signature expired");
+    });
+  });
+
   describe("Withdrawals", function () {
     let partnershipId;
 